@@ -8,19 +8,6 @@ import (
 	"github.com/cheekybits/is"
 )
 
-// mockEngineWithUnits is a mockEngine whose Units() returns a configured map.
-type mockEngineWithUnits struct {
-	mockEngine
-	units map[string]engine.UnitInfo
-}
-
-func (e *mockEngineWithUnits) Units() map[string]engine.UnitInfo {
-	if e.units == nil {
-		return make(map[string]engine.UnitInfo)
-	}
-	return e.units
-}
-
 func TestAutocomplete_NilSession_ReturnsEmpty(t *testing.T) {
 	result := Autocomplete(nil, "England")
 	if len(result) != 0 {
@@ -29,9 +16,7 @@ func TestAutocomplete_NilSession_ReturnsEmpty(t *testing.T) {
 }
 
 func TestAutocomplete_NoUnits_ReturnsEmpty(t *testing.T) {
-	eng := &mockEngineWithUnits{
-		mockEngine: mockEngine{phase: "Spring 1901 Movement", dump: []byte(`{}`)},
-	}
+	eng := &mockEngine{phase: "Spring 1901 Movement", dump: []byte(`{}`)}
 	sess := &session.Session{
 		Phase:        "Spring 1901 Movement",
 		Players:      map[string]string{"u1": "England"},
@@ -45,15 +30,12 @@ func TestAutocomplete_NoUnits_ReturnsEmpty(t *testing.T) {
 	}
 }
 
-func TestAutocomplete_ReturnsHoldOrdersForNation(t *testing.T) {
+func TestAutocomplete_ReturnsEngineValidOrders(t *testing.T) {
 	is := is.New(t)
-	eng := &mockEngineWithUnits{
-		mockEngine: mockEngine{phase: "Spring 1901 Movement", dump: []byte(`{}`)},
-		units: map[string]engine.UnitInfo{
-			"London":    {Type: "Fleet", Nation: "England"},
-			"Edinburgh": {Type: "Army", Nation: "England"},
-			"Paris":     {Type: "Army", Nation: "France"},
-		},
+	eng := &mockEngine{
+		phase: "Spring 1901 Movement",
+		dump:  []byte(`{}`),
+		valid: []string{"A Edi-Cly", "A Edi H", "F Lon-Nth"},
 	}
 	sess := &session.Session{
 		Phase:        "Spring 1901 Movement",
@@ -63,17 +45,14 @@ func TestAutocomplete_ReturnsHoldOrdersForNation(t *testing.T) {
 		Eng:          eng,
 	}
 	result := Autocomplete(sess, "England")
-	is.Equal(len(result), 2)
-	is.Equal(result[0], "A Edinburgh H")
-	is.Equal(result[1], "F London H")
+	is.Equal(len(result), 3)
+	is.Equal(result[0], "A Edi-Cly")
 }
 
-func TestAutocomplete_OtherNationUnitsExcluded(t *testing.T) {
-	eng := &mockEngineWithUnits{
-		mockEngine: mockEngine{phase: "Spring 1901 Movement", dump: []byte(`{}`)},
-		units: map[string]engine.UnitInfo{
-			"Paris": {Type: "Army", Nation: "France"},
-		},
+func TestAutocomplete_RealEngineListsMovesAndSupports(t *testing.T) {
+	eng, err := engine.New("classical")
+	if err != nil {
+		t.Fatalf("engine.New: %v", err)
 	}
 	sess := &session.Session{
 		Phase:        "Spring 1901 Movement",
@@ -83,7 +62,20 @@ func TestAutocomplete_OtherNationUnitsExcluded(t *testing.T) {
 		Eng:          eng,
 	}
 	result := Autocomplete(sess, "England")
-	if len(result) != 0 {
-		t.Errorf("expected no suggestions for other nation's units, got: %v", result)
+	for _, want := range []string{"F Lon H", "F Lon-Nth", "F Edi S F Lon-Nth", "A Lvp-Yor"} {
+		found := false
+		for _, r := range result {
+			if r == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected %q in suggestions, got: %v", want, result)
+		}
+	}
+	for _, r := range result {
+		if r == "A Vie H" {
+			t.Errorf("suggestions must not include other nations' units, got: %v", result)
+		}
 	}
 }
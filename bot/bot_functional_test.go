@@ -76,7 +76,7 @@ func (n *nopNotifier) Notify(_, _ string) error { return nil }
 // newDispatcher returns a Dispatcher wired to ch using real engine.New /
 // engine.Load so that no business logic is mocked.
 func newDispatcher(ch *memChannel) *bot.Dispatcher {
-	return bot.New(ch, &nopNotifier{}, engine.Load, engine.New)
+	return bot.New(ch, events.NewChannelStore(ch), &nopNotifier{}, engine.Load, engine.New)
 }
 
 // chanCmd builds a channel-sourced command.
@@ -125,7 +125,7 @@ func mustDispatch(t *testing.T, d *bot.Dispatcher, cmd bot.Command) string {
 // in the game channel.
 func hasEvent(t *testing.T, ch *memChannel, channelID string, want events.EventType) bool {
 	t.Helper()
-	envs, err := events.Scan(ch, channelID)
+	envs, _, err := events.Scan(ch, channelID)
 	if err != nil {
 		t.Fatalf("events.Scan: %v", err)
 	}
@@ -140,7 +140,7 @@ func hasEvent(t *testing.T, ch *memChannel, channelID string, want events.EventT
 // eventPayload returns the JSON payload of the last event of the given type.
 func eventPayload(t *testing.T, ch *memChannel, channelID string, want events.EventType) json.RawMessage {
 	t.Helper()
-	envs, _ := events.Scan(ch, channelID)
+	envs, _, _ := events.Scan(ch, channelID)
 	for i := len(envs) - 1; i >= 0; i-- {
 		if envs[i].Type == want {
 			return envs[i].Payload
@@ -300,6 +300,25 @@ func TestCommand_Clear(t *testing.T) {
 	t.Logf("Clear response: %q; orders after: %q", resp, ordersResp)
 }
 
+func TestCommand_Submit_WarnsBeforeSubmitting(t *testing.T) {
+	// /submit with units left unordered lists them and submits nothing until
+	// the player confirms.
+	is := is.New(t)
+	d, ch := startedGame(t)
+
+	mustDispatch(t, d, dmCmd("order", "u1", "game", "F Lon H"))
+	resp, err := d.Dispatch(dmCmd("submit", "u1", "game"))
+	is.NoErr(err)
+	is.True(strings.Contains(resp, "A Lvp: has no order and will hold"))
+	is.True(strings.Contains(resp, "/submit confirm"))
+	dmEnvs, _, err := events.ScanDM(ch, "u1")
+	is.NoErr(err)
+	for _, e := range dmEnvs {
+		is.True(e.Type != events.TypeOrderSubmitted)
+	}
+	t.Logf("Submit warnings: %q", resp)
+}
+
 func TestCommand_Submit_PartialDoesNotAdvance(t *testing.T) {
 	// /submit from one player must not advance the phase if the other player
 	// has not yet submitted.
@@ -322,11 +341,14 @@ func TestCommand_Submit_AllPlayersAdvancesPhase(t *testing.T) {
 	is := is.New(t)
 	d, ch := startedGame(t)
 
+	// Other units are left unordered, so each /submit warns first and is
+	// then confirmed past the lint warnings.
 	mustDispatch(t, d, dmCmd("order", "u1", "game", "A Lon H"))
 	mustDispatch(t, d, dmCmd("submit", "u1", "game"))
+	mustDispatch(t, d, dmCmd("submit", "u1", "game", "confirm"))
 
 	// Verify OrderSubmitted event is posted to DM on /submit.
-	dmEnvs, err := events.ScanDM(ch, "u1")
+	dmEnvs, _, err := events.ScanDM(ch, "u1")
 	is.NoErr(err)
 	foundOS := false
 	for _, e := range dmEnvs {
@@ -341,7 +363,8 @@ func TestCommand_Submit_AllPlayersAdvancesPhase(t *testing.T) {
 	is.Equal(foundOS, true)
 
 	mustDispatch(t, d, dmCmd("order", "u2", "game", "A Par H"))
-	_, err = d.Dispatch(dmCmd("submit", "u2", "game"))
+	mustDispatch(t, d, dmCmd("submit", "u2", "game"))
+	_, err = d.Dispatch(dmCmd("submit", "u2", "game", "confirm"))
 	is.NoErr(err)
 
 	is.Equal(hasEvent(t, ch, "game", events.TypePhaseResolved), true)
@@ -455,7 +478,7 @@ func TestCommand_Retreat(t *testing.T) {
 	is.NoErr(err)
 	is.Equal(resp != "", true)
 
-	dmEnvs, err := events.ScanDM(ch, "u1")
+	dmEnvs, _, err := events.ScanDM(ch, "u1")
 	is.NoErr(err)
 	foundOS := false
 	for _, e := range dmEnvs {
@@ -480,7 +503,7 @@ func TestCommand_Disband_InRetreatPhase(t *testing.T) {
 	is.NoErr(err)
 	is.Equal(resp != "", true)
 
-	dmEnvs, err := events.ScanDM(ch, "u1")
+	dmEnvs, _, err := events.ScanDM(ch, "u1")
 	is.NoErr(err)
 	foundOS := false
 	for _, e := range dmEnvs {
@@ -506,7 +529,7 @@ func TestCommand_Build(t *testing.T) {
 	is.NoErr(err)
 	is.Equal(resp != "", true)
 
-	dmEnvs, err := events.ScanDM(ch, "u2")
+	dmEnvs, _, err := events.ScanDM(ch, "u2")
 	is.NoErr(err)
 	foundOS := false
 	for _, e := range dmEnvs {
@@ -533,7 +556,7 @@ func TestCommand_Waive(t *testing.T) {
 	is.NoErr(err)
 	is.Equal(resp != "", true)
 
-	dmEnvs, err := events.ScanDM(ch, "u2")
+	dmEnvs, _, err := events.ScanDM(ch, "u2")
 	is.NoErr(err)
 	foundOS := false
 	for _, e := range dmEnvs {
@@ -14,23 +14,11 @@ import (
 	"github.com/burrbd/dip/dipmap"
 	"github.com/burrbd/dip/engine"
 	"github.com/burrbd/dip/events"
+	"github.com/burrbd/dip/lint"
 	"github.com/burrbd/dip/session"
 	"github.com/zond/godip"
-	"github.com/zond/godip/variants/classical"
-	"github.com/zond/godip/variants/classical/start"
 )
 
-// classicalNations is the set of valid nation names in the classical variant.
-var classicalNations = map[string]bool{
-	"Austria": true,
-	"England": true,
-	"France":  true,
-	"Germany": true,
-	"Italy":   true,
-	"Russia":  true,
-	"Turkey":  true,
-}
-
 // Command is a parsed bot command from any platform adapter.
 type Command struct {
 	Name          string   // command name without leading slash (e.g. "newgame")
@@ -47,6 +35,7 @@ type EngineFactory func(variant string) (engine.Engine, error)
 // Dispatcher routes commands to their handlers and holds in-process sessions.
 type Dispatcher struct {
 	ch             events.Channel
+	store          events.Store
 	notifier       session.Notifier
 	loader         session.EngineLoader
 	newEng         EngineFactory
@@ -58,10 +47,12 @@ type Dispatcher struct {
 	renderZoomedFn func(dipmap.EngineState, []byte, []string) ([]byte, error) // retained for Story 10c (zoomed /map with territory+radius)
 }
 
-// New returns a Dispatcher wired to the given dependencies.
-func New(ch events.Channel, notifier session.Notifier, loader session.EngineLoader, newEng EngineFactory) *Dispatcher {
+// New returns a Dispatcher wired to the given dependencies. Game events are
+// kept in store; ch carries only what players read, such as map images.
+func New(ch events.Channel, store events.Store, notifier session.Notifier, loader session.EngineLoader, newEng EngineFactory) *Dispatcher {
 	return &Dispatcher{
 		ch:             ch,
+		store:          store,
 		notifier:       notifier,
 		loader:         loader,
 		newEng:         newEng,
@@ -91,6 +82,8 @@ func (d *Dispatcher) Dispatch(cmd Command) (string, error) {
 		return d.handleClear(cmd)
 	case "submit":
 		return d.handleSubmit(cmd)
+	case "preview":
+		return d.handlePreview(cmd)
 	case "retreat":
 		return d.handleRetreat(cmd)
 	case "disband":
@@ -132,12 +125,15 @@ func (d *Dispatcher) Dispatch(cmd Command) (string, error) {
 	}
 }
 
-// gameState is the bot's view of a channel's game state, derived from an event scan.
+// gameState is the bot's view of a channel's game state: the events.Game
+// folded from its event log, with the bot's defaults filled in.
 type gameState struct {
 	created       bool
 	started       bool
 	ended         bool
 	gmID          string
+	variant       string // registry name from GameCreated, e.g. "classical"
+	nmrPolicy     engine.NMRPolicy
 	deadlineHours int
 	players       map[string]string // userID → nation
 	nations       map[string]string // nation → userID
@@ -145,87 +141,40 @@ type gameState struct {
 	drawVotes     map[string]bool // nation → true if voted yes
 }
 
-// readState scans the channel event log and returns the current game state.
+// readState reads the game's event log and returns the current game state.
 func (d *Dispatcher) readState(channelID string) (*gameState, error) {
-	envs, err := events.Scan(d.ch, channelID)
+	g, _, err := events.ReadGame(d.store, channelID)
 	if err != nil {
 		return nil, fmt.Errorf("bot: scan channel: %w", err)
 	}
 	gs := &gameState{
-		players:       make(map[string]string),
-		nations:       make(map[string]string),
-		drawVotes:     make(map[string]bool),
+		created:       g.Created,
+		started:       g.Started,
+		ended:         g.Ended,
+		gmID:          g.GMID,
+		players:       g.Players,
+		nations:       g.Nations,
+		drawProposed:  g.DrawProposed,
+		drawVotes:     g.DrawVotes,
 		deadlineHours: 24,
+		variant:       engine.DefaultVariant,
+		nmrPolicy:     engine.DefaultNMRPolicy,
 	}
-	for _, env := range envs {
-		switch env.Type {
-		case events.TypeGameCreated:
-			var gc events.GameCreated
-			if err := json.Unmarshal(env.Payload, &gc); err != nil {
-				continue
-			}
-			gs.created = true
-			gs.gmID = gc.GMUserID
-			if gc.DeadlineHours > 0 {
-				gs.deadlineHours = gc.DeadlineHours
-			}
-		case events.TypeGameStarted:
-			gs.started = true
-		case events.TypePlayerJoined:
-			var pj events.PlayerJoined
-			if err := json.Unmarshal(env.Payload, &pj); err != nil {
-				continue
-			}
-			gs.players[pj.UserID] = pj.Nation
-			gs.nations[pj.Nation] = pj.UserID
-		case events.TypeGameEnded:
-			gs.ended = true
-			gs.drawProposed = false
-			gs.drawVotes = make(map[string]bool)
-		case events.TypeDrawProposed:
-			var dp events.DrawProposed
-			if err := json.Unmarshal(env.Payload, &dp); err != nil {
-				continue
-			}
-			gs.drawProposed = true
-			gs.drawVotes[dp.ProposerNation] = true
-		case events.TypeDrawVoted:
-			var dv events.DrawVoted
-			if err := json.Unmarshal(env.Payload, &dv); err != nil {
-				continue
-			}
-			if dv.Accept {
-				gs.drawVotes[dv.Nation] = true
-			} else {
-				delete(gs.drawVotes, dv.Nation)
-			}
-		case events.TypePlayerBooted:
-			var pb events.PlayerBooted
-			if err := json.Unmarshal(env.Payload, &pb); err != nil {
-				continue
-			}
-			for uid, nation := range gs.players {
-				if nation == pb.Nation {
-					delete(gs.players, uid)
-					delete(gs.nations, pb.Nation)
-					break
-				}
-			}
-		case events.TypePlayerReplaced:
-			var pr events.PlayerReplaced
-			if err := json.Unmarshal(env.Payload, &pr); err != nil {
-				continue
-			}
-			oldUID := gs.nations[pr.Nation]
-			delete(gs.players, oldUID)
-			gs.players[pr.NewUserID] = pr.Nation
-			gs.nations[pr.Nation] = pr.NewUserID
-		}
+	if g.Variant != "" {
+		gs.variant = g.Variant
+	}
+	if p, err := engine.ParseNMRPolicy(g.NMRPolicy); err == nil {
+		gs.nmrPolicy = p
+	}
+	if g.DeadlineHours > 0 {
+		gs.deadlineHours = g.DeadlineHours
 	}
 	return gs, nil
 }
 
-// handleNewGame processes /newgame [settings].
+// handleNewGame processes /newgame [variant] [nmr-policy]. The variant
+// defaults to classical and must be registered with the engine; the NMR
+// policy defaults to engine.DefaultNMRPolicy.
 func (d *Dispatcher) handleNewGame(cmd Command) (string, error) {
 	state, err := d.readState(cmd.ChannelID)
 	if err != nil {
@@ -234,14 +183,30 @@ func (d *Dispatcher) handleNewGame(cmd Command) (string, error) {
 	if state.created {
 		return "", fmt.Errorf("bot: a game already exists in this channel")
 	}
-	if err := events.Write(d.ch, cmd.ChannelID, events.TypeGameCreated, events.GameCreated{
-		Variant:       "classical",
+	variant := engine.DefaultVariant
+	if len(cmd.Args) > 0 {
+		variant = strings.ToLower(cmd.Args[0])
+	}
+	info, err := engine.LookupVariant(variant)
+	if err != nil {
+		return "", fmt.Errorf("bot: unknown variant %q; available variants: %s", cmd.Args[0], strings.Join(engine.Variants(), ", "))
+	}
+	policy := engine.DefaultNMRPolicy
+	if len(cmd.Args) > 1 {
+		if policy, err = engine.ParseNMRPolicy(cmd.Args[1]); err != nil {
+			return "", fmt.Errorf("bot: unknown NMR policy %q; available policies: %s", cmd.Args[1], strings.Join(engine.NMRPolicies(), ", "))
+		}
+	}
+	if err := events.Record(d.store, cmd.ChannelID, events.TypeGameCreated, events.GameCreated{
+		Variant:       info.Name,
 		DeadlineHours: 24,
 		GMUserID:      cmd.UserID,
+		NMRPolicy:     string(policy),
 	}); err != nil {
 		return "", fmt.Errorf("bot: write GameCreated: %w", err)
 	}
-	return "Game created. You are the GM. Players can use /join <nation> to claim a nation. Use /start when everyone has joined.", nil
+	return fmt.Sprintf("%s game created (NMR policy: %s). You are the GM. Players can use /join <nation> to claim a nation (%s). Use /start when everyone has joined.",
+		info.DisplayName, policy, strings.Join(info.Nations, ", ")), nil
 }
 
 // handleJoin processes /join <nation>.
@@ -262,14 +227,18 @@ func (d *Dispatcher) handleJoin(cmd Command) (string, error) {
 	if len(cmd.Args) == 0 {
 		return "", fmt.Errorf("bot: usage: /join <nation>")
 	}
+	info, err := engine.LookupVariant(state.variant)
+	if err != nil {
+		return "", fmt.Errorf("bot: %w", err)
+	}
 	nation := cmd.Args[0]
-	if !classicalNations[nation] {
-		return "", fmt.Errorf("bot: unknown nation %q; valid nations are Austria, England, France, Germany, Italy, Russia, Turkey", nation)
+	if !containsString(info.Nations, nation) {
+		return "", fmt.Errorf("bot: unknown nation %q; valid nations are %s", nation, strings.Join(info.Nations, ", "))
 	}
 	if _, taken := state.nations[nation]; taken {
 		return "", fmt.Errorf("bot: nation %q is already taken", nation)
 	}
-	if err := events.Write(d.ch, cmd.ChannelID, events.TypePlayerJoined, events.PlayerJoined{
+	if err := events.Record(d.store, cmd.ChannelID, events.TypePlayerJoined, events.PlayerJoined{
 		UserID: cmd.UserID,
 		Nation: nation,
 	}); err != nil {
@@ -297,25 +266,33 @@ func (d *Dispatcher) handleStart(cmd Command) (string, error) {
 	if n < 2 {
 		return "", fmt.Errorf("bot: need at least 2 players to start (have %d)", n)
 	}
-	if n > 7 {
-		return "", fmt.Errorf("bot: too many players (max 7, have %d)", n)
+	info, err := engine.LookupVariant(state.variant)
+	if err != nil {
+		return "", fmt.Errorf("bot: %w", err)
+	}
+	if max := len(info.Nations); n > max {
+		return "", fmt.Errorf("bot: too many players (max %d, have %d)", max, n)
 	}
-	eng, err := d.newEng("classical")
+	eng, err := d.newEng(state.variant)
 	if err != nil {
 		return "", fmt.Errorf("bot: create engine: %w", err)
 	}
+	eng.SetNMRPolicy(state.nmrPolicy)
 	snapshot, err := eng.Dump()
 	if err != nil {
 		return "", fmt.Errorf("bot: dump initial state: %w", err)
 	}
-	if err := events.Write(d.ch, cmd.ChannelID, events.TypeGameStarted, events.GameStarted{
+	if err := events.Record(d.store, cmd.ChannelID, events.TypeGameStarted, events.GameStarted{
 		InitialState: json.RawMessage(snapshot),
 	}); err != nil {
 		return "", fmt.Errorf("bot: write GameStarted: %w", err)
 	}
-	sess := session.New(d.ch, cmd.ChannelID, state.gmID, eng.Phase(), state.players, state.deadlineHours, eng, d.notifier)
+	sess := session.New(d.store, cmd.ChannelID, state.gmID, eng.Phase(), state.players, state.deadlineHours, eng, d.notifier)
 	d.sessions[cmd.ChannelID] = sess
-	return "Game started! Spring 1901 Movement phase begins. Players, submit your orders via DM.", nil
+	if err := sess.RecordDeadline(); err != nil {
+		return "", fmt.Errorf("bot: %w", err)
+	}
+	return fmt.Sprintf("Game started! %s phase begins. Players, submit your orders via DM.", eng.Phase()), nil
 }
 
 // isMovementPhase returns true if the given phase string is a Movement phase.
@@ -325,6 +302,7 @@ func isMovementPhase(phase string) bool {
 
 // handleOrder processes /order <order-text> (DM only, Movement phase).
 // It parses the order via the engine, validates nation ownership, and stages it.
+// Changing an order withdraws the nation's submission, as /clear does.
 func (d *Dispatcher) handleOrder(cmd Command) (string, error) {
 	if !cmd.IsDM {
 		return "", fmt.Errorf("bot: /order must be sent as a direct message to the bot")
@@ -343,15 +321,29 @@ func (d *Dispatcher) handleOrder(cmd Command) (string, error) {
 	if len(cmd.Args) == 0 {
 		return "", fmt.Errorf("bot: usage: /order <order-text>")
 	}
-	orderText := strings.Join(cmd.Args, " ")
+	if block := splitOrders(cmd.Args); len(block) > 1 {
+		return stageOrderBlock(sess, nation, block)
+	}
+	orderText, err := sess.Eng.NormalizeOrder(nation, strings.Join(cmd.Args, " "))
+	if err != nil {
+		return "", fmt.Errorf("bot: invalid order: %w", err)
+	}
 	if err := sess.Eng.SubmitOrder(nation, orderText); err != nil {
 		return "", fmt.Errorf("bot: invalid order: %w", err)
 	}
-	sess.StagedOrders[nation] = append(sess.StagedOrders[nation], orderText)
+	replaced := sess.StageOrder(nation, orderText)
+	if err := sess.WithdrawSubmission(nation); err != nil {
+		return "", fmt.Errorf("bot: %w", err)
+	}
+	if replaced != "" && replaced != orderText {
+		return fmt.Sprintf("Order staged: %s (replaces %s)", orderText, replaced), nil
+	}
 	return fmt.Sprintf("Order staged: %s", orderText), nil
 }
 
 // handleOrders processes /orders (DM only) — lists the caller's staged orders.
+// /orders set <block> replaces them with a block of orders, as /order does
+// for more than one order.
 func (d *Dispatcher) handleOrders(cmd Command) (string, error) {
 	if !cmd.IsDM {
 		return "", fmt.Errorf("bot: /orders must be sent as a direct message to the bot")
@@ -364,6 +356,16 @@ func (d *Dispatcher) handleOrders(cmd Command) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("bot: you are not a player in this game")
 	}
+	if len(cmd.Args) > 0 && strings.EqualFold(cmd.Args[0], "set") {
+		if !isMovementPhase(sess.Phase) {
+			return "", fmt.Errorf("bot: /orders set is only valid during the Movement phase (current: %s)", sess.Phase)
+		}
+		block := splitOrders(cmd.Args[1:])
+		if len(block) == 0 {
+			return "", fmt.Errorf("bot: usage: /orders set <order>; <order>; ...")
+		}
+		return stageOrderBlock(sess, nation, block)
+	}
 	orders := sess.StagedOrders[nation]
 	if len(orders) == 0 {
 		return "No orders staged.", nil
@@ -376,7 +378,9 @@ func (d *Dispatcher) handleOrders(cmd Command) (string, error) {
 	return strings.TrimRight(sb.String(), "\n"), nil
 }
 
-// handleClear processes /clear [order] (DM only) — removes one or all staged orders.
+// handleClear processes /clear [order|province] (DM only) — removes one or
+// all staged orders. A single order is named by its text or by the province
+// of its unit, e.g. /clear vie.
 func (d *Dispatcher) handleClear(cmd Command) (string, error) {
 	if !cmd.IsDM {
 		return "", fmt.Errorf("bot: /clear must be sent as a direct message to the bot")
@@ -391,29 +395,40 @@ func (d *Dispatcher) handleClear(cmd Command) (string, error) {
 	}
 	if len(cmd.Args) == 0 {
 		sess.StagedOrders[nation] = nil
-		sess.Submitted[nation] = false
+		if err := sess.WithdrawSubmission(nation); err != nil {
+			return "", fmt.Errorf("bot: %w", err)
+		}
+		sess.Eng.ClearOrders(nation)
 		return "All orders cleared.", nil
 	}
 	target := strings.Join(cmd.Args, " ")
-	var filtered []string
-	found := false
+	removed, found := sess.UnstageOrder(nation, target)
+	if !found {
+		return "", fmt.Errorf("bot: order %q not found", target)
+	}
+	if err := sess.WithdrawSubmission(nation); err != nil {
+		return "", fmt.Errorf("bot: %w", err)
+	}
+	// The engine has no per-order withdrawal, so restage what is left. Waives
+	// name no unit and are never given to the engine.
+	sess.Eng.ClearOrders(nation)
 	for _, o := range sess.StagedOrders[nation] {
-		if o == target && !found {
-			found = true
+		if engine.OrderProvince(o) == "" {
 			continue
 		}
-		filtered = append(filtered, o)
-	}
-	if !found {
-		return "", fmt.Errorf("bot: order %q not found", target)
+		if err := sess.Eng.SubmitOrder(nation, o); err != nil {
+			return "", fmt.Errorf("bot: restage order %q: %w", o, err)
+		}
 	}
-	sess.StagedOrders[nation] = filtered
-	sess.Submitted[nation] = false
-	return fmt.Sprintf("Order removed: %s", target), nil
+	return fmt.Sprintf("Order removed: %s", removed), nil
 }
 
-// handleSubmit processes /submit (DM only) — finalises staged orders and checks
-// whether all nations have submitted; if so fires AdvanceTurn immediately.
+// handleSubmit processes /submit [confirm] (DM only) — finalises staged orders
+// and checks whether all nations have submitted; if so fires AdvanceTurn
+// immediately. Likely mistakes found by lint are reported instead, and the
+// orders are only submitted once the player sends /submit confirm without
+// changing them in between; a confirm for changed orders reports the
+// warnings again.
 func (d *Dispatcher) handleSubmit(cmd Command) (string, error) {
 	if !cmd.IsDM {
 		return "", fmt.Errorf("bot: /submit must be sent as a direct message to the bot")
@@ -429,7 +444,21 @@ func (d *Dispatcher) handleSubmit(cmd Command) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("bot: you are not a player in this game")
 	}
-	if err := events.WriteDM(d.ch, cmd.UserID, events.TypeOrderSubmitted, events.OrderSubmitted{
+	confirmed := len(cmd.Args) > 0 && strings.EqualFold(cmd.Args[0], "confirm")
+	if warnings := lint.Check(sess.Eng, nation, sess.StagedOrders[nation]); len(warnings) > 0 && !(confirmed && sess.Warned(nation)) {
+		var sb strings.Builder
+		if confirmed {
+			sb.WriteString("Your orders have changed since you were last warned.\n")
+		}
+		sb.WriteString("Check your orders before submitting:\n")
+		for _, w := range warnings {
+			fmt.Fprintf(&sb, "  - %s\n", w)
+		}
+		sb.WriteString("Nothing has been submitted. Fix your orders, or send /submit confirm to submit them as they are.")
+		sess.MarkWarned(nation)
+		return sb.String(), nil
+	}
+	if err := events.RecordDM(d.store, cmd.UserID, events.TypeOrderSubmitted, events.OrderSubmitted{
 		UserID: cmd.UserID,
 		Nation: nation,
 		Orders: sess.StagedOrders[nation],
@@ -437,7 +466,9 @@ func (d *Dispatcher) handleSubmit(cmd Command) (string, error) {
 	}); err != nil {
 		return "", fmt.Errorf("bot: write OrderSubmitted: %w", err)
 	}
-	sess.Submitted[nation] = true
+	if err := sess.FinaliseSubmission(nation); err != nil {
+		return "", fmt.Errorf("bot: %w", err)
+	}
 
 	allDone, err := d.allNationsSubmitted(sess)
 	if err != nil {
@@ -452,6 +483,96 @@ func (d *Dispatcher) handleSubmit(cmd Command) (string, error) {
 	return "Orders submitted.", nil
 }
 
+// handlePreview processes /preview [assumed orders] (DM only) — adjudicates
+// the caller's staged orders plus optional assumed orders for other powers,
+// separated by ";", on a copy of the game. The projected outcome is returned
+// and the projected board is posted to the DM; the real game is untouched.
+func (d *Dispatcher) handlePreview(cmd Command) (string, error) {
+	if !cmd.IsDM {
+		return "", fmt.Errorf("bot: /preview must be sent as a direct message to the bot")
+	}
+	sess, ok := d.sessions[cmd.GameChannelID]
+	if !ok || sess == nil {
+		return "", fmt.Errorf("bot: no active game found")
+	}
+	nation, ok := sess.Players[cmd.UserID]
+	if !ok {
+		return "", fmt.Errorf("bot: you are not a player in this game")
+	}
+	orders := append(append([]string(nil), sess.StagedOrders[nation]...), splitOrders(cmd.Args)...)
+	result, projected, err := sess.Eng.Preview(orders)
+	if err != nil {
+		return "", fmt.Errorf("bot: preview: %w", err)
+	}
+	img, err := d.renderMap(projected)
+	if err != nil {
+		return "", fmt.Errorf("bot: render preview map: %w", err)
+	}
+	if err := d.ch.PostImage(cmd.ChannelID, img); err != nil {
+		return "", fmt.Errorf("bot: post preview map: %w", err)
+	}
+	return fmt.Sprintf("Preview of %s (nothing has been submitted):\n%s", sess.Phase, FormatResult(result)), nil
+}
+
+// splitOrders splits command arguments into orders separated by ";" or line
+// breaks, dropping blank entries.
+func splitOrders(args []string) []string {
+	var orders []string
+	for _, line := range strings.Split(strings.Join(args, " "), "\n") {
+		for _, o := range strings.Split(line, ";") {
+			if o = strings.TrimSpace(o); o != "" {
+				orders = append(orders, o)
+			}
+		}
+	}
+	return orders
+}
+
+// stageOrderBlock validates each order in block on a copy of the game and,
+// only if every order is accepted, replaces nation's staged orders with the
+// block. The reply reports each order as accepted, in canonical form, or
+// rejected with the reason; when any order is rejected nothing is staged.
+func stageOrderBlock(sess *session.Session, nation string, block []string) (string, error) {
+	trial, err := sess.Eng.Clone()
+	if err != nil {
+		return "", fmt.Errorf("bot: validate orders: %w", err)
+	}
+	trial.ClearOrders(nation)
+	var report strings.Builder
+	canonical := make([]string, 0, len(block))
+	rejected := 0
+	for i, text := range block {
+		orderText, err := trial.NormalizeOrder(nation, text)
+		if err == nil {
+			err = trial.SubmitOrder(nation, orderText)
+		}
+		if err != nil {
+			rejected++
+			fmt.Fprintf(&report, "\n  %d. rejected: %s (%v)", i+1, text, err)
+			continue
+		}
+		canonical = append(canonical, orderText)
+		fmt.Fprintf(&report, "\n  %d. accepted: %s", i+1, orderText)
+	}
+	if rejected > 0 {
+		return "", fmt.Errorf("bot: %d of %d orders rejected; staged orders unchanged:%s", rejected, len(block), report.String())
+	}
+	sess.Eng.ClearOrders(nation)
+	for _, orderText := range canonical {
+		if err := sess.Eng.SubmitOrder(nation, orderText); err != nil {
+			return "", fmt.Errorf("bot: invalid order: %w", err)
+		}
+	}
+	sess.StagedOrders[nation] = nil
+	for _, orderText := range canonical {
+		sess.StageOrder(nation, orderText)
+	}
+	if err := sess.WithdrawSubmission(nation); err != nil {
+		return "", fmt.Errorf("bot: %w", err)
+	}
+	return fmt.Sprintf("Staged orders for %s, replacing any staged before:%s", nation, report.String()), nil
+}
+
 // isRetreatPhase returns true if the given phase string is a Retreat phase.
 func isRetreatPhase(phase string) bool {
 	return strings.HasSuffix(phase, "Retreat")
@@ -489,12 +610,15 @@ func (d *Dispatcher) handleRetreat(cmd Command) (string, error) {
 	if n, exists := dislodgeds[src]; !exists || n != nation {
 		return "", fmt.Errorf("bot: no dislodged %s unit at %s belonging to %s", unitType, src, nation)
 	}
-	orderText := fmt.Sprintf("%s %s-%s", unitType, src, dest)
+	orderText, err := sess.Eng.NormalizeOrder(nation, fmt.Sprintf("%s %s-%s", unitType, src, dest))
+	if err != nil {
+		return "", fmt.Errorf("bot: invalid retreat order: %w", err)
+	}
 	if err := sess.Eng.SubmitOrder(nation, orderText); err != nil {
 		return "", fmt.Errorf("bot: invalid retreat order: %w", err)
 	}
-	sess.StagedOrders[nation] = append(sess.StagedOrders[nation], orderText)
-	if err := events.WriteDM(d.ch, cmd.UserID, events.TypeOrderSubmitted, events.OrderSubmitted{
+	sess.StageOrder(nation, orderText)
+	if err := events.RecordDM(d.store, cmd.UserID, events.TypeOrderSubmitted, events.OrderSubmitted{
 		UserID: cmd.UserID,
 		Nation: nation,
 		Orders: []string{orderText},
@@ -502,7 +626,9 @@ func (d *Dispatcher) handleRetreat(cmd Command) (string, error) {
 	}); err != nil {
 		return "", fmt.Errorf("bot: write OrderSubmitted: %w", err)
 	}
-	sess.Submitted[nation] = true
+	if err := sess.FinaliseSubmission(nation); err != nil {
+		return "", fmt.Errorf("bot: %w", err)
+	}
 	if allRetreatActionsSubmitted(sess) {
 		if err := sess.AdvanceTurn(); err != nil {
 			return "", fmt.Errorf("bot: advance turn: %w", err)
@@ -540,12 +666,15 @@ func (d *Dispatcher) handleDisband(cmd Command) (string, error) {
 			return "", fmt.Errorf("bot: no dislodged %s unit at %s belonging to %s", unitType, src, nation)
 		}
 	}
-	orderText := fmt.Sprintf("%s %s disband", unitType, src)
+	orderText, err := sess.Eng.NormalizeOrder(nation, fmt.Sprintf("%s %s disband", unitType, src))
+	if err != nil {
+		return "", fmt.Errorf("bot: invalid disband order: %w", err)
+	}
 	if err := sess.Eng.SubmitOrder(nation, orderText); err != nil {
 		return "", fmt.Errorf("bot: invalid disband order: %w", err)
 	}
-	sess.StagedOrders[nation] = append(sess.StagedOrders[nation], orderText)
-	if err := events.WriteDM(d.ch, cmd.UserID, events.TypeOrderSubmitted, events.OrderSubmitted{
+	sess.StageOrder(nation, orderText)
+	if err := events.RecordDM(d.store, cmd.UserID, events.TypeOrderSubmitted, events.OrderSubmitted{
 		UserID: cmd.UserID,
 		Nation: nation,
 		Orders: []string{orderText},
@@ -553,7 +682,9 @@ func (d *Dispatcher) handleDisband(cmd Command) (string, error) {
 	}); err != nil {
 		return "", fmt.Errorf("bot: write OrderSubmitted: %w", err)
 	}
-	sess.Submitted[nation] = true
+	if err := sess.FinaliseSubmission(nation); err != nil {
+		return "", fmt.Errorf("bot: %w", err)
+	}
 	if isRetreatPhase(sess.Phase) {
 		if allRetreatActionsSubmitted(sess) {
 			if err := sess.AdvanceTurn(); err != nil {
@@ -593,12 +724,15 @@ func (d *Dispatcher) handleBuild(cmd Command) (string, error) {
 		return "", fmt.Errorf("bot: usage: /build <unit_type> <province>")
 	}
 	unitType, province := cmd.Args[0], cmd.Args[1]
-	orderText := fmt.Sprintf("build %s %s", unitType, province)
+	orderText, err := sess.Eng.NormalizeOrder(nation, fmt.Sprintf("build %s %s", unitType, province))
+	if err != nil {
+		return "", fmt.Errorf("bot: invalid build order: %w", err)
+	}
 	if err := sess.Eng.SubmitOrder(nation, orderText); err != nil {
 		return "", fmt.Errorf("bot: invalid build order: %w", err)
 	}
-	sess.StagedOrders[nation] = append(sess.StagedOrders[nation], orderText)
-	if err := events.WriteDM(d.ch, cmd.UserID, events.TypeOrderSubmitted, events.OrderSubmitted{
+	sess.StageOrder(nation, orderText)
+	if err := events.RecordDM(d.store, cmd.UserID, events.TypeOrderSubmitted, events.OrderSubmitted{
 		UserID: cmd.UserID,
 		Nation: nation,
 		Orders: []string{orderText},
@@ -606,7 +740,9 @@ func (d *Dispatcher) handleBuild(cmd Command) (string, error) {
 	}); err != nil {
 		return "", fmt.Errorf("bot: write OrderSubmitted: %w", err)
 	}
-	sess.Submitted[nation] = true
+	if err := sess.FinaliseSubmission(nation); err != nil {
+		return "", fmt.Errorf("bot: %w", err)
+	}
 	if allAdjustmentActionsSubmitted(sess) {
 		if err := sess.AdvanceTurn(); err != nil {
 			return "", fmt.Errorf("bot: advance turn: %w", err)
@@ -634,8 +770,8 @@ func (d *Dispatcher) handleWaive(cmd Command) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("bot: you are not a player in this game")
 	}
-	sess.StagedOrders[nation] = append(sess.StagedOrders[nation], "Waive")
-	if err := events.WriteDM(d.ch, cmd.UserID, events.TypeOrderSubmitted, events.OrderSubmitted{
+	sess.StageOrder(nation, "Waive")
+	if err := events.RecordDM(d.store, cmd.UserID, events.TypeOrderSubmitted, events.OrderSubmitted{
 		UserID: cmd.UserID,
 		Nation: nation,
 		Orders: []string{"Waive"},
@@ -643,7 +779,9 @@ func (d *Dispatcher) handleWaive(cmd Command) (string, error) {
 	}); err != nil {
 		return "", fmt.Errorf("bot: write OrderSubmitted: %w", err)
 	}
-	sess.Submitted[nation] = true
+	if err := sess.FinaliseSubmission(nation); err != nil {
+		return "", fmt.Errorf("bot: %w", err)
+	}
 	if allAdjustmentActionsSubmitted(sess) {
 		if err := sess.AdvanceTurn(); err != nil {
 			return "", fmt.Errorf("bot: advance turn: %w", err)
@@ -654,40 +792,50 @@ func (d *Dispatcher) handleWaive(cmd Command) (string, error) {
 }
 
 // handleStatus processes /status — shows current phase, SC counts, and
-// order submission status per nation. Requires an active in-memory session.
+// order submission status per nation. The GM also sees any snapshots the
+// session skipped when it was loaded. Requires an active in-memory session.
 func (d *Dispatcher) handleStatus(cmd Command) (string, error) {
 	sess, ok := d.sessions[cmd.ChannelID]
 	if !ok || sess == nil {
 		return "", fmt.Errorf("bot: no active game found in this channel")
 	}
 	scCounts := sess.Eng.SupplyCenters()
-	return FormatStatus(sess.Phase, sess.Players, sess.Submitted, scCounts), nil
+	status := FormatStatus(sess.Phase, sess.Players, sess.Submitted, scCounts)
+	if n := len(sess.Rejected); n > 0 {
+		status += fmt.Sprintf("\nIgnored %d forged or tampered event message(s) in this channel.", n)
+	}
+	if len(sess.Skipped) > 0 && cmd.UserID == sess.GMID {
+		status += "\nRecovered from an earlier snapshot; submissions since then were not restored. Skipped:"
+		for _, sk := range sess.Skipped {
+			status += fmt.Sprintf("\n  event %d (%s %s): %v", sk.Seq, sk.Type, sk.Phase, sk.Err)
+		}
+	}
+	return status, nil
 }
 
 // handleHistory processes /history <turn> — fetches the PhaseResolved event
-// matching the given turn string and returns its result summary.
+// matching the given turn string and returns its result summary, formatted
+// per order with failure reasons.
 func (d *Dispatcher) handleHistory(cmd Command) (string, error) {
 	if len(cmd.Args) == 0 {
 		return "", fmt.Errorf("bot: usage: /history <turn>")
 	}
 	turn := strings.Join(cmd.Args, " ")
 
-	envs, err := events.Scan(d.ch, cmd.ChannelID)
+	g, _, err := events.ReadGame(d.store, cmd.ChannelID)
 	if err != nil {
 		return "", fmt.Errorf("bot: scan history: %w", err)
 	}
 
 	// Search in reverse order so the most recent matching phase is preferred.
-	for i := len(envs) - 1; i >= 0; i-- {
-		if envs[i].Type != events.TypePhaseResolved {
-			continue
-		}
-		var pr events.PhaseResolved
-		if err := json.Unmarshal(envs[i].Payload, &pr); err != nil {
-			continue
-		}
+	for i := len(g.History) - 1; i >= 0; i-- {
+		pr := g.History[i]
 		if strings.Contains(pr.Phase, turn) {
 			if len(pr.ResultSummary) > 0 {
+				var result engine.ResolutionResult
+				if err := json.Unmarshal(pr.ResultSummary, &result); err == nil {
+					return FormatResult(result), nil
+				}
 				return string(pr.ResultSummary), nil
 			}
 			return fmt.Sprintf("Phase %s resolved (no result summary).", pr.Phase), nil
@@ -712,34 +860,53 @@ func (d *Dispatcher) handleMap(cmd Command) (string, error) {
 		return "", fmt.Errorf("bot: no active game found in this channel")
 	}
 
-	// Step 1: load SVG.
-	svg, err := d.svgFn(sess.Eng)
+	// NOTE: zoomed /map <territory> <n> is deferred — see Story 10c in PLAN.md.
+	img, err := d.renderMap(sess.Eng)
 	if err != nil {
 		return "", fmt.Errorf("bot: render map: %w", err)
 	}
 
-	// Step 2: overlay unit positions.
-	engUnits := sess.Eng.Units()
+	if err := d.ch.PostImage(cmd.ChannelID, img); err != nil {
+		return "", fmt.Errorf("bot: post map: %w", err)
+	}
+	return "Map posted.", nil
+}
+
+// renderMap runs the full-board pipeline for eng: load the SVG, overlay the
+// units and rasterise to PNG.
+func (d *Dispatcher) renderMap(eng engine.Engine) ([]byte, error) {
+	svg, err := d.loadMapSVG(eng)
+	if err != nil {
+		return nil, err
+	}
+	engUnits := eng.Units()
 	units := make(map[string]dipmap.Unit, len(engUnits))
 	for p, u := range engUnits {
 		units[p] = dipmap.Unit{Type: u.Type, Nation: u.Nation}
 	}
 	svg, err = d.overlayFn(svg, units)
 	if err != nil {
-		return "", fmt.Errorf("bot: render map: %w", err)
+		return nil, err
 	}
+	return d.imgFn(svg)
+}
 
-	// Step 3: rasterise full board.
-	// NOTE: zoomed /map <territory> <n> is deferred — see Story 10c in PLAN.md.
-	img, err := d.imgFn(svg)
+// loadMapSVG returns the board SVG for eng's variant. Classical uses svgFn,
+// whose map carries the unit placeholders Overlay fills in; other variants
+// use the map shipped with the variant.
+func (d *Dispatcher) loadMapSVG(eng engine.Engine) ([]byte, error) {
+	variant := eng.Variant()
+	if variant == "" || variant == engine.DefaultVariant {
+		return d.svgFn(eng)
+	}
+	info, err := engine.LookupVariant(variant)
 	if err != nil {
-		return "", fmt.Errorf("bot: render map: %w", err)
+		return nil, err
 	}
-
-	if err := d.ch.PostImage(cmd.ChannelID, img); err != nil {
-		return "", fmt.Errorf("bot: post map: %w", err)
+	if info.SVGMap == nil {
+		return nil, fmt.Errorf("variant %s has no map", info.DisplayName)
 	}
-	return "Map posted.", nil
+	return info.SVGMap()
 }
 
 // commandDetail holds the structured help text for a single command.
@@ -754,11 +921,11 @@ type commandDetail struct {
 // commandDetails maps command names to their detailed help information.
 var commandDetails = map[string]commandDetail{
 	"newgame": {
-		usage:       "/newgame",
-		description: "Start a new game in this channel. You become the GM.",
+		usage:       "/newgame [variant] [nmr-policy]",
+		description: "Start a new game in this channel. You become the GM. The variant defaults to classical. The NMR policy decides the orders given for nations that send none: hold (default), disband-retreats or civil-disorder. Builds a nation does not order are waived.",
 		phase:       "Any (pre-game)",
 		access:      "Anyone",
-		examples:    []string{"/newgame"},
+		examples:    []string{"/newgame", "/newgame classical", "/newgame classical civil-disorder"},
 	},
 	"join": {
 		usage:       "/join <nation>",
@@ -769,38 +936,45 @@ var commandDetails = map[string]commandDetail{
 	},
 	"start": {
 		usage:       "/start",
-		description: "Start the game. Requires 2–7 players to have joined.",
+		description: "Start the game. Requires at least 2 players, and at most one per nation, to have joined.",
 		phase:       "Any (pre-game)",
 		access:      "GM",
 		examples:    []string{"/start"},
 	},
 	"order": {
-		usage:       "/order <order-text>",
-		description: "Submit a movement order for your nation.",
+		usage:       "/order <order-text>[; <order-text>...]",
+		description: "Submit a movement order for your nation; a new order for a unit replaces its old one. Full province names, arrows and coasts such as StP(nc) are accepted; the canonical order is echoed back. Several orders separated by \";\" or on separate lines replace all your staged orders, but only if every one is valid.",
 		phase:       "Movement",
 		access:      "Own nation (DM only)",
-		examples:    []string{"/order A Vie-Bud", "/order F Lon-NTH", "/order A Par S A Mar-Bur"},
+		examples:    []string{"/order A Vie-Bud", "/order army vienna -> budapest", "/order A Par supports Mar to Bur", "/order A Vie-Gal; A Bud-Rum; F Tri H"},
 	},
 	"orders": {
-		usage:       "/orders",
-		description: "List your staged orders for the current phase.",
+		usage:       "/orders [set <order>; <order>...]",
+		description: "List your staged orders for the current phase, or replace them all with a block of orders separated by \";\" or line breaks. The block is staged only if every order is valid.",
 		phase:       "Movement",
 		access:      "Own nation (DM only)",
-		examples:    []string{"/orders"},
+		examples:    []string{"/orders", "/orders set A Vie-Gal; A Bud-Rum; F Tri H"},
 	},
 	"clear": {
-		usage:       "/clear [order]",
-		description: "Clear all staged orders or remove a specific one.",
+		usage:       "/clear [order|province]",
+		description: "Clear all staged orders or remove one, named by its text or its unit's province.",
 		phase:       "Movement",
 		access:      "Own nation (DM only)",
-		examples:    []string{"/clear", "/clear A Vie-Bud"},
+		examples:    []string{"/clear", "/clear A Vie-Bud", "/clear vie"},
 	},
 	"submit": {
-		usage:       "/submit",
-		description: "Finalise and submit your orders. If all nations submit, the phase resolves immediately.",
+		usage:       "/submit [confirm]",
+		description: "Finalise and submit your orders. Likely mistakes, such as unordered units or a support for a move you did not order, are listed first; send /submit confirm to submit anyway. If all nations submit, the phase resolves immediately.",
 		phase:       "Movement",
 		access:      "Own nation (DM only)",
-		examples:    []string{"/submit"},
+		examples:    []string{"/submit", "/submit confirm"},
+	},
+	"preview": {
+		usage:       "/preview [order; order; ...]",
+		description: "Adjudicate your staged orders plus any assumed orders for other powers on a copy of the game, and show the projected outcome and map. Nothing is submitted.",
+		phase:       "Any",
+		access:      "Own nation (DM only)",
+		examples:    []string{"/preview", "/preview A Mun-Bur", "/preview A Mun-Bur; A Kie-Hol"},
 	},
 	"retreat": {
 		usage:       "/retreat <unit_type> <source> <destination>",
@@ -860,7 +1034,7 @@ var commandDetails = map[string]commandDetail{
 	},
 	"nations": {
 		usage:       "/nations [nation]",
-		description: "List all classical powers with abbreviations and home SCs, or show detail for one nation.",
+		description: "List all powers in this game's variant with abbreviations and home SCs, or show detail for one nation.",
 		phase:       "Any",
 		access:      "Anyone",
 		examples:    []string{"/nations", "/nations England", "/nations Eng"},
@@ -936,7 +1110,7 @@ var helpCategories = []struct {
 	commands []string
 }{
 	{"Setup", []string{"newgame", "join", "start"}},
-	{"Movement", []string{"order", "orders", "clear", "submit"}},
+	{"Movement", []string{"order", "orders", "clear", "submit", "preview"}},
 	{"Retreat", []string{"retreat", "disband"}},
 	{"Adjustment", []string{"build", "disband", "waive"}},
 	{"Info", []string{"status", "history", "map", "help", "nations", "provinces"}},
@@ -947,7 +1121,7 @@ var helpCategories = []struct {
 // commandList defines the canonical display order for /help (used for coverage checks).
 var commandList = []string{
 	"newgame", "join", "start",
-	"order", "orders", "clear", "submit",
+	"order", "orders", "clear", "submit", "preview",
 	"retreat", "disband", "build", "waive",
 	"status", "history", "map", "help", "nations", "provinces",
 	"draw", "concede",
@@ -955,10 +1129,11 @@ var commandList = []string{
 }
 
 // helpRules is the condensed game rules overview returned by /help rules.
+// The powers and win condition are filled in from the variant by rulesText.
 const helpRules = `Diplomacy — Quick Rules
 
-Powers: Austria, England, France, Germany, Italy, Russia, Turkey (7 classical powers)
-Win condition: Control 18 of 34 supply centres (SCs).
+Powers: %s (%d %s powers)
+Win condition: Control %d of %d supply centres (SCs).
 
 Phase sequence (repeating):
   Spring Movement → Spring Retreat → Fall Movement → Fall Retreat → Winter Adjustment → repeat
@@ -977,6 +1152,17 @@ Draw: any player may propose a draw with /draw; all remaining nations must agree
 with /draw for the game to end in a draw.
 Concede: a player may end the game immediately with /concede.`
 
+// rulesText renders helpRules for a variant, followed by the variant's own
+// rules where it has any.
+func rulesText(info engine.VariantInfo) string {
+	text := fmt.Sprintf(helpRules, strings.Join(info.Nations, ", "), len(info.Nations),
+		strings.ToLower(info.DisplayName), info.SoloSCCount, info.SupplyCenters)
+	if info.Rules != "" {
+		text += "\n\n" + info.DisplayName + " rules:\n" + info.Rules
+	}
+	return text
+}
+
 // handleHelp processes /help [command|rules] — lists all commands grouped by category,
 // shows detailed usage for a specific command, or returns the rules overview.
 func (d *Dispatcher) handleHelp(cmd Command) (string, error) {
@@ -999,7 +1185,11 @@ func (d *Dispatcher) handleHelp(cmd Command) (string, error) {
 	}
 
 	if arg == "rules" {
-		return helpRules, nil
+		info, err := d.channelVariant(cmd.ChannelID)
+		if err != nil {
+			return "", err
+		}
+		return rulesText(info), nil
 	}
 
 	det, ok := commandDetails[arg]
@@ -1018,105 +1208,118 @@ func (d *Dispatcher) handleHelp(cmd Command) (string, error) {
 	return strings.TrimRight(sb.String(), "\n"), nil
 }
 
-// nationInfo holds static data about one classical power.
+// nationInfo holds static data about one power of a variant.
 type nationInfo struct {
-	name       string
-	abbrev     string
-	homeSCs    []string // province codes (lowercase), sorted
-	startUnits []string // e.g. "F Edinburgh", "A Liverpool"
+	name    string
+	abbrev  string
+	homeSCs []string // province codes (lowercase), sorted
 }
 
-// abbrevToNation maps 3-letter abbreviations (lowercase) to full nation names.
-var abbrevToNation = map[string]string{
-	"eng": "England",
-	"fra": "France",
-	"ger": "Germany",
-	"ita": "Italy",
-	"aus": "Austria",
-	"rus": "Russia",
-	"tur": "Turkey",
+// buildNationInfo constructs the nationInfo table from the variant's nations
+// and home supply centres, in the variant's order, with the variant's
+// abbreviation for each.
+func buildNationInfo(info engine.VariantInfo) []nationInfo {
+	result := make([]nationInfo, 0, len(info.Nations))
+	for _, name := range info.Nations {
+		result = append(result, nationInfo{
+			name:    name,
+			abbrev:  info.NationAbbrevs[name],
+			homeSCs: info.HomeCenters[name],
+		})
+	}
+	return result
 }
 
-// buildNationInfo constructs the nationInfo table from godip classical data.
-// This is called once and cached.
-func buildNationInfo() []nationInfo {
-	scs := start.SupplyCenters()
-	units := start.Units()
-	longNames := classical.ClassicalVariant.ProvinceLongNames
-
-	// Collect home SCs and starting units per nation.
-	homeSCsMap := make(map[godip.Nation][]string)
-	for prov, nation := range scs {
-		homeSCsMap[nation] = append(homeSCsMap[nation], string(prov))
+// startUnitNames returns nation's starting units, sorted, e.g.
+// "F Edinburgh", "A Liverpool".
+func startUnitNames(info engine.VariantInfo, nation string) ([]string, error) {
+	units, err := info.StartUnits()
+	if err != nil {
+		return nil, fmt.Errorf("bot: %w", err)
 	}
-
-	startUnitsMap := make(map[godip.Nation][]string)
+	var names []string
 	for prov, unit := range units {
+		if unit.Nation != nation {
+			continue
+		}
 		utype := "A"
-		if unit.Type == godip.Fleet {
+		if unit.Type == string(godip.Fleet) {
 			utype = "F"
 		}
 		// Use the base province for the long name (strip /sc, /nc suffixes).
-		baseProv := strings.Split(string(prov), "/")[0]
-		longName := longNames[godip.Province(baseProv)]
-		startUnitsMap[unit.Nation] = append(startUnitsMap[unit.Nation], utype+" "+longName)
+		baseProv := strings.Split(prov, "/")[0]
+		names = append(names, utype+" "+longName(info, baseProv))
 	}
+	sort.Strings(names)
+	return names, nil
+}
 
-	orderedNations := []string{"England", "France", "Germany", "Italy", "Austria", "Russia", "Turkey"}
-	abbrevs := map[string]string{
-		"England": "Eng", "France": "Fra", "Germany": "Ger",
-		"Italy": "Ita", "Austria": "Aus", "Russia": "Rus", "Turkey": "Tur",
+// longName returns the full name of a province, falling back to its code for
+// variants without long names.
+func longName(info engine.VariantInfo, prov string) string {
+	if name, ok := info.ProvinceLongNames[prov]; ok {
+		return name
 	}
+	return prov
+}
 
-	result := make([]nationInfo, 0, len(orderedNations))
-	for _, name := range orderedNations {
-		n := godip.Nation(name)
-		scsForNation := homeSCsMap[n]
-		sort.Strings(scsForNation)
-		unitsForNation := startUnitsMap[n]
-		sort.Strings(unitsForNation)
-		result = append(result, nationInfo{
-			name:       name,
-			abbrev:     abbrevs[name],
-			homeSCs:    scsForNation,
-			startUnits: unitsForNation,
-		})
+// resolveNation returns the full nation name for a given input (full name or
+// abbreviation, case-insensitive). Returns empty string if not found.
+func resolveNation(info engine.VariantInfo, input string) string {
+	for _, name := range info.Nations {
+		if strings.EqualFold(name, input) {
+			return name
+		}
 	}
-	return result
+	for _, name := range info.Nations {
+		if strings.EqualFold(info.NationAbbrevs[name], input) {
+			return name
+		}
+	}
+	return ""
 }
 
-// nationInfoTable is the lazily initialised nation data table.
-var nationInfoTable []nationInfo
+// unknownNationError lists the variant's nations and abbreviations.
+func unknownNationError(info engine.VariantInfo, input string) error {
+	names := make([]string, 0, len(info.Nations))
+	for _, name := range info.Nations {
+		names = append(names, fmt.Sprintf("%s (%s)", name, info.NationAbbrevs[name]))
+	}
+	return fmt.Errorf("bot: unknown nation %q; valid names: %s", input, strings.Join(names, ", "))
+}
 
-// getNationInfoTable returns the singleton nation info table, building it once.
-func getNationInfoTable() []nationInfo {
-	if nationInfoTable == nil {
-		nationInfoTable = buildNationInfo()
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
 	}
-	return nationInfoTable
+	return false
 }
 
-// resolveNation returns the full nation name for a given input (full name or
-// abbreviation, case-insensitive). Returns empty string if not found.
-func resolveNation(input string) string {
-	// Try full name (title-cased).
-	titled := strings.Title(strings.ToLower(input)) //nolint:staticcheck
-	if classicalNations[titled] {
-		return titled
+// channelVariant returns the variant of the game in channelID, or the
+// default variant if no game has been created there.
+func (d *Dispatcher) channelVariant(channelID string) (engine.VariantInfo, error) {
+	state, err := d.readState(channelID)
+	if err != nil {
+		return engine.VariantInfo{}, err
 	}
-	// Try abbreviation.
-	abbrev := strings.ToLower(input)
-	if full, ok := abbrevToNation[abbrev]; ok {
-		return full
+	info, err := engine.LookupVariant(state.variant)
+	if err != nil {
+		return engine.VariantInfo{}, fmt.Errorf("bot: %w", err)
 	}
-	return ""
+	return info, nil
 }
 
-// handleNations processes /nations [nation] — lists all classical powers or
-// shows detail for one nation.
+// handleNations processes /nations [nation] — lists all powers of the
+// channel's variant or shows detail for one nation.
 func (d *Dispatcher) handleNations(cmd Command) (string, error) {
-	table := getNationInfoTable()
-	longNames := classical.ClassicalVariant.ProvinceLongNames
+	info, err := d.channelVariant(cmd.ChannelID)
+	if err != nil {
+		return "", err
+	}
+	table := buildNationInfo(info)
 
 	if len(cmd.Args) == 0 {
 		// Table of all nations.
@@ -1126,8 +1329,7 @@ func (d *Dispatcher) handleNations(cmd Command) (string, error) {
 			// Format home SCs as "Edinburgh (edi), London (lon), ..."
 			scParts := make([]string, 0, len(ni.homeSCs))
 			for _, sc := range ni.homeSCs {
-				longName := longNames[godip.Province(sc)]
-				scParts = append(scParts, fmt.Sprintf("%s (%s)", longName, sc))
+				scParts = append(scParts, fmt.Sprintf("%s (%s)", longName(info, sc), sc))
 			}
 			fmt.Fprintf(&sb, "%-10s %-7s %s\n", ni.name, ni.abbrev, strings.Join(scParts, ", "))
 		}
@@ -1135,9 +1337,9 @@ func (d *Dispatcher) handleNations(cmd Command) (string, error) {
 	}
 
 	// Detail for one nation.
-	nationName := resolveNation(strings.Join(cmd.Args, " "))
+	nationName := resolveNation(info, strings.Join(cmd.Args, " "))
 	if nationName == "" {
-		return "", fmt.Errorf("bot: unknown nation %q; valid names: Austria (Aus), England (Eng), France (Fra), Germany (Ger), Italy (Ita), Russia (Rus), Turkey (Tur)", strings.Join(cmd.Args, " "))
+		return "", unknownNationError(info, strings.Join(cmd.Args, " "))
 	}
 
 	var ni nationInfo
@@ -1148,61 +1350,59 @@ func (d *Dispatcher) handleNations(cmd Command) (string, error) {
 		}
 	}
 
+	startUnits, err := startUnitNames(info, nationName)
+	if err != nil {
+		return "", err
+	}
+
 	// Format home SCs.
 	scParts := make([]string, 0, len(ni.homeSCs))
 	for _, sc := range ni.homeSCs {
-		longName := longNames[godip.Province(sc)]
-		scParts = append(scParts, fmt.Sprintf("%s (%s)", longName, sc))
+		scParts = append(scParts, fmt.Sprintf("%s (%s)", longName(info, sc), sc))
 	}
 
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "%s (%s)\n", ni.name, ni.abbrev)
 	fmt.Fprintf(&sb, "  Home SCs:       %s\n", strings.Join(scParts, ", "))
-	fmt.Fprintf(&sb, "  Starting units: %s\n", strings.Join(ni.startUnits, ", "))
-	fmt.Fprintf(&sb, "  Win condition:  Control 18 of 34 supply centres")
+	fmt.Fprintf(&sb, "  Starting units: %s\n", strings.Join(startUnits, ", "))
+	fmt.Fprintf(&sb, "  Win condition:  Control %d of %d supply centres", info.SoloSCCount, info.SupplyCenters)
 	return sb.String(), nil
 }
 
 // handleProvinces processes /provinces [nation] — lists all province codes with
 // full names, or filters to a nation's home SCs.
 func (d *Dispatcher) handleProvinces(cmd Command) (string, error) {
-	longNames := classical.ClassicalVariant.ProvinceLongNames
+	info, err := d.channelVariant(cmd.ChannelID)
+	if err != nil {
+		return "", err
+	}
 
 	if len(cmd.Args) == 0 {
 		// Full alphabetical list.
-		codes := make([]string, 0, len(longNames))
-		for prov := range longNames {
-			codes = append(codes, string(prov))
+		codes := make([]string, 0, len(info.ProvinceLongNames))
+		for prov := range info.ProvinceLongNames {
+			codes = append(codes, prov)
 		}
 		sort.Strings(codes)
 
 		var sb strings.Builder
-		fmt.Fprintln(&sb, "Province reference (Classical Diplomacy):")
+		fmt.Fprintf(&sb, "Province reference (%s Diplomacy):\n", info.DisplayName)
 		for _, code := range codes {
-			fmt.Fprintf(&sb, "  %-7s — %s\n", code, longNames[godip.Province(code)])
+			fmt.Fprintf(&sb, "  %-7s — %s\n", code, info.ProvinceLongNames[code])
 		}
 		return strings.TrimRight(sb.String(), "\n"), nil
 	}
 
 	// Filter to home SCs for the given nation.
-	nationName := resolveNation(strings.Join(cmd.Args, " "))
+	nationName := resolveNation(info, strings.Join(cmd.Args, " "))
 	if nationName == "" {
-		return "", fmt.Errorf("bot: unknown nation %q; valid names: Austria (Aus), England (Eng), France (Fra), Germany (Ger), Italy (Ita), Russia (Rus), Turkey (Tur)", strings.Join(cmd.Args, " "))
-	}
-
-	scs := start.SupplyCenters()
-	var codes []string
-	for prov, nation := range scs {
-		if string(nation) == nationName {
-			codes = append(codes, string(prov))
-		}
+		return "", unknownNationError(info, strings.Join(cmd.Args, " "))
 	}
-	sort.Strings(codes)
 
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "%s home provinces:\n", nationName)
-	for _, code := range codes {
-		fmt.Fprintf(&sb, "  %-7s — %s\n", code, longNames[godip.Province(code)])
+	for _, code := range info.HomeCenters[nationName] {
+		fmt.Fprintf(&sb, "  %-7s — %s\n", code, longName(info, code))
 	}
 	return strings.TrimRight(sb.String(), "\n"), nil
 }
@@ -1225,7 +1425,7 @@ func (d *Dispatcher) handleDraw(cmd Command) (string, error) {
 
 	if !state.drawProposed {
 		// First call: post the proposal.
-		if err := events.Write(d.ch, cmd.ChannelID, events.TypeDrawProposed, events.DrawProposed{
+		if err := events.Record(d.store, cmd.ChannelID, events.TypeDrawProposed, events.DrawProposed{
 			ProposerNation: nation,
 		}); err != nil {
 			return "", fmt.Errorf("bot: write DrawProposed: %w", err)
@@ -1236,7 +1436,7 @@ func (d *Dispatcher) handleDraw(cmd Command) (string, error) {
 			if sess := d.sessions[cmd.ChannelID]; sess != nil {
 				finalState, _ = sess.Eng.Dump()
 			}
-			if err := events.Write(d.ch, cmd.ChannelID, events.TypeGameEnded, events.GameEnded{
+			if err := events.Record(d.store, cmd.ChannelID, events.TypeGameEnded, events.GameEnded{
 				Result: "draw", FinalState: finalState,
 			}); err != nil {
 				return "", fmt.Errorf("bot: write GameEnded: %w", err)
@@ -1250,7 +1450,7 @@ func (d *Dispatcher) handleDraw(cmd Command) (string, error) {
 	if state.drawVotes[nation] {
 		return "You have already voted for this draw.", nil
 	}
-	if err := events.Write(d.ch, cmd.ChannelID, events.TypeDrawVoted, events.DrawVoted{
+	if err := events.Record(d.store, cmd.ChannelID, events.TypeDrawVoted, events.DrawVoted{
 		Nation: nation, Accept: true,
 	}); err != nil {
 		return "", fmt.Errorf("bot: write DrawVoted: %w", err)
@@ -1270,7 +1470,7 @@ func (d *Dispatcher) handleDraw(cmd Command) (string, error) {
 		if sess := d.sessions[cmd.ChannelID]; sess != nil {
 			finalState, _ = sess.Eng.Dump()
 		}
-		if err := events.Write(d.ch, cmd.ChannelID, events.TypeGameEnded, events.GameEnded{
+		if err := events.Record(d.store, cmd.ChannelID, events.TypeGameEnded, events.GameEnded{
 			Result: "draw", FinalState: finalState,
 		}); err != nil {
 			return "", fmt.Errorf("bot: write GameEnded: %w", err)
@@ -1301,7 +1501,7 @@ func (d *Dispatcher) handleConcede(cmd Command) (string, error) {
 	if sess := d.sessions[cmd.ChannelID]; sess != nil {
 		finalState, _ = sess.Eng.Dump()
 	}
-	if err := events.Write(d.ch, cmd.ChannelID, events.TypeGameEnded, events.GameEnded{
+	if err := events.Record(d.store, cmd.ChannelID, events.TypeGameEnded, events.GameEnded{
 		Result: "concession", Winner: nation, FinalState: finalState,
 	}); err != nil {
 		return "", fmt.Errorf("bot: write GameEnded: %w", err)
@@ -1318,7 +1518,9 @@ func (d *Dispatcher) handlePause(cmd Command) (string, error) {
 	if cmd.UserID != sess.GMID {
 		return "", fmt.Errorf("bot: only the GM can pause the game")
 	}
-	sess.CancelDeadline()
+	if err := sess.Pause(); err != nil {
+		return "", fmt.Errorf("bot: pause: %w", err)
+	}
 	return "Game paused. Use /resume to restart the deadline.", nil
 }
 
@@ -1331,7 +1533,9 @@ func (d *Dispatcher) handleResume(cmd Command) (string, error) {
 	if cmd.UserID != sess.GMID {
 		return "", fmt.Errorf("bot: only the GM can resume the game")
 	}
-	sess.RestartDeadline()
+	if err := sess.Resume(); err != nil {
+		return "", fmt.Errorf("bot: resume: %w", err)
+	}
 	return "Game resumed. Deadline restarted.", nil
 }
 
@@ -1352,7 +1556,9 @@ func (d *Dispatcher) handleExtend(cmd Command) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("bot: invalid duration %q: %w", cmd.Args[0], err)
 	}
-	sess.ExtendDeadline(dur)
+	if err := sess.Extend(dur); err != nil {
+		return "", fmt.Errorf("bot: extend: %w", err)
+	}
 	return fmt.Sprintf("Deadline extended by %s.", dur), nil
 }
 
@@ -1399,7 +1605,7 @@ func (d *Dispatcher) handleBoot(cmd Command) (string, error) {
 		return "", fmt.Errorf("bot: nation %q not found in this game", nation)
 	}
 
-	if err := events.Write(d.ch, cmd.ChannelID, events.TypePlayerBooted, events.PlayerBooted{
+	if err := events.Record(d.store, cmd.ChannelID, events.TypePlayerBooted, events.PlayerBooted{
 		Nation: nation,
 	}); err != nil {
 		return "", fmt.Errorf("bot: write PlayerBooted: %w", err)
@@ -1435,7 +1641,7 @@ func (d *Dispatcher) handleReplace(cmd Command) (string, error) {
 		return "", fmt.Errorf("bot: nation %q not found in this game", nation)
 	}
 
-	if err := events.Write(d.ch, cmd.ChannelID, events.TypePlayerReplaced, events.PlayerReplaced{
+	if err := events.Record(d.store, cmd.ChannelID, events.TypePlayerReplaced, events.PlayerReplaced{
 		Nation: nation, NewUserID: newUserID,
 	}); err != nil {
 		return "", fmt.Errorf("bot: write PlayerReplaced: %w", err)
@@ -1478,33 +1684,17 @@ func allRetreatActionsSubmitted(sess *session.Session) bool {
 	return true
 }
 
-// allNationsSubmitted reads each player's DM thread to check whether every
-// nation has an OrderSubmitted event for the current phase.
+// allNationsSubmitted reads each player's private events to check whether
+// every nation has an OrderSubmitted event for the current phase.
 func (d *Dispatcher) allNationsSubmitted(sess *session.Session) (bool, error) {
-	for userID, nation := range sess.Players {
-		msgs, err := d.ch.DMHistory(userID)
-		if err != nil {
-			return false, fmt.Errorf("bot: dm history for %s: %w", nation, err)
+	g := events.NewGame()
+	for userID := range sess.Players {
+		if err := g.ReadDM(d.store, userID); err != nil {
+			return false, fmt.Errorf("bot: dm history for %s: %w", sess.Players[userID], err)
 		}
-		found := false
-		for _, msg := range msgs {
-			var env events.Envelope
-			if err := json.Unmarshal([]byte(msg), &env); err != nil {
-				continue
-			}
-			if env.Type != events.TypeOrderSubmitted {
-				continue
-			}
-			var os events.OrderSubmitted
-			if err := json.Unmarshal(env.Payload, &os); err != nil {
-				continue
-			}
-			if os.Phase == sess.Phase && os.Nation == nation {
-				found = true
-				break
-			}
-		}
-		if !found {
+	}
+	for _, nation := range sess.Players {
+		if !g.HasSubmitted(sess.Phase, nation) {
 			return false, nil
 		}
 	}
@@ -3,6 +3,7 @@ package bot
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/burrbd/dip/dipmap"
@@ -10,6 +11,9 @@ import (
 	"github.com/burrbd/dip/events"
 	"github.com/burrbd/dip/session"
 	"github.com/cheekybits/is"
+	"github.com/zond/godip"
+	"github.com/zond/godip/state"
+	"github.com/zond/godip/variants/classical"
 )
 
 // ---- mock channel -----------------------------------------------------------
@@ -80,6 +84,18 @@ func (m *mockChannel) lastEventType() events.EventType {
 	return env.Type
 }
 
+// eventTypes returns the type of each event posted to the channel, in order.
+func (m *mockChannel) eventTypes() []events.EventType {
+	var types []events.EventType
+	for _, msg := range m.msgs {
+		var env events.Envelope
+		if json.Unmarshal([]byte(msg), &env) == nil && env.Type != "" {
+			types = append(types, env.Type)
+		}
+	}
+	return types
+}
+
 // ---- mock notifier ----------------------------------------------------------
 
 type mockNotifier struct{}
@@ -98,15 +114,58 @@ type mockEngine struct {
 	soloWinner string
 	dislodgeds map[string]string
 	units      map[string]engine.UnitInfo
-}
-
-func (e *mockEngine) SubmitOrder(_, _ string) error {
+	valid      []string
+	variant    string
+	// preview* configure Preview and record the orders it was given.
+	previewOrders []string
+	previewResult engine.ResolutionResult
+	previewEng    engine.Engine
+	previewErr    error
+	nmrPolicy     engine.NMRPolicy
+	// normalized maps raw order text to the canonical form NormalizeOrder returns.
+	normalized   map[string]string
+	normalizeErr error
+	// provinces maps names to the province NormalizeProvince returns.
+	provinces map[string]string
+	// rejected maps order text to the error SubmitOrder returns for it;
+	// submitted and cleared record what was staged and withdrawn.
+	rejected  map[string]error
+	submitted []string
+	cleared   []string
+}
+
+func (e *mockEngine) SubmitOrder(_, text string) error {
+	if err := e.rejected[text]; err != nil {
+		return err
+	}
+	if e.orderErr == nil {
+		e.submitted = append(e.submitted, text)
+	}
 	return e.orderErr
 }
+func (e *mockEngine) ClearOrders(nation string) {
+	e.cleared = append(e.cleared, nation)
+	e.submitted = nil
+}
+func (e *mockEngine) NormalizeProvince(name string) (string, error) {
+	if prov, ok := e.provinces[name]; ok {
+		return prov, nil
+	}
+	return strings.ToLower(name), nil
+}
+func (e *mockEngine) NormalizeOrder(_, text string) (string, error) {
+	if e.normalizeErr != nil {
+		return "", e.normalizeErr
+	}
+	if canonical, ok := e.normalized[text]; ok {
+		return canonical, nil
+	}
+	return text, nil
+}
 func (e *mockEngine) Resolve() (engine.ResolutionResult, error) {
 	return engine.ResolutionResult{Phase: e.phase}, e.resolveErr
 }
-func (e *mockEngine) Advance() error     { return e.advanceErr }
+func (e *mockEngine) Advance() (map[string][]string, error) { return nil, e.advanceErr }
 func (e *mockEngine) SoloWinner() string { return e.soloWinner }
 func (e *mockEngine) Dump() ([]byte, error) { return e.dump, e.dumpErr }
 func (e *mockEngine) Phase() string         { return e.phase }
@@ -117,6 +176,23 @@ func (e *mockEngine) Dislodgeds() map[string]string {
 	return e.dislodgeds
 }
 func (e *mockEngine) SupplyCenters() map[string]int { return make(map[string]int) }
+func (e *mockEngine) SupplyCenterOwners() map[string]string { return make(map[string]string) }
+func (e *mockEngine) Adjacent(_, _, _ string) bool          { return true }
+func (e *mockEngine) ValidOrders(_ string) []string { return e.valid }
+func (e *mockEngine) Variant() string               { return e.variant }
+func (e *mockEngine) Clone() (engine.Engine, error) { c := *e; return &c, nil }
+func (e *mockEngine) SetNMRPolicy(p engine.NMRPolicy) { e.nmrPolicy = p }
+func (e *mockEngine) Preview(orders []string) (engine.ResolutionResult, engine.Engine, error) {
+	e.previewOrders = orders
+	if e.previewErr != nil {
+		return engine.ResolutionResult{}, nil, e.previewErr
+	}
+	projected := e.previewEng
+	if projected == nil {
+		projected = e
+	}
+	return e.previewResult, projected, nil
+}
 func (e *mockEngine) Units() map[string]engine.UnitInfo {
 	if e.units != nil {
 		return e.units
@@ -135,7 +211,7 @@ func goodFactory() EngineFactory {
 }
 
 func newTestDispatcher(ch *mockChannel) *Dispatcher {
-	d := New(ch, &mockNotifier{}, nil, goodFactory())
+	d := New(ch, events.NewChannelStore(ch), &mockNotifier{}, nil, goodFactory())
 	// Stub all rendering functions so unit tests don't invoke real SVG rasterisation
 	// (which is expensive and would make the test suite prohibitively slow).
 	d.svgFn = func(_ dipmap.EngineState) ([]byte, error) { return []byte(`<svg/>`), nil }
@@ -204,6 +280,36 @@ func TestDispatchNewGame_SetsGMToCallerUserID(t *testing.T) {
 	is.Equal(gc.GMUserID, "gm42")
 }
 
+func TestDispatch_EventsKeptInStoreNotChannel(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	store := events.NewMemoryStore()
+	d := newTestDispatcher(ch)
+	d.store = store
+
+	for _, cmd := range []Command{
+		{Name: "newgame", ChannelID: "chan1", UserID: "gm1"},
+		{Name: "join", ChannelID: "chan1", UserID: "u1", Args: []string{"England"}},
+		{Name: "join", ChannelID: "chan1", UserID: "u2", Args: []string{"France"}},
+		{Name: "start", ChannelID: "chan1", UserID: "gm1"},
+	} {
+		_, err := d.Dispatch(cmd)
+		is.NoErr(err)
+	}
+
+	envs, _, err := events.Events(store, "chan1")
+	is.NoErr(err)
+	is.Equal(len(envs), 5)
+	is.Equal(envs[3].Type, events.TypeGameStarted)
+	is.Equal(envs[4].Type, events.TypeDeadlineSet)
+	for _, msg := range ch.msgs {
+		var env events.Envelope
+		if json.Unmarshal([]byte(msg), &env) == nil && env.Type != "" {
+			t.Errorf("event posted to the channel: %s", msg)
+		}
+	}
+}
+
 func TestDispatchNewGame_ReturnsNonEmptyText(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
@@ -245,6 +351,129 @@ func TestDispatchNewGame_RejectsWhenPostFails(t *testing.T) {
 	is.Err(err)
 }
 
+func TestDispatchNewGame_RecordsVariant(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+
+	_, err := d.Dispatch(Command{Name: "newgame", Args: []string{"Classical"}, ChannelID: "chan1", UserID: "gm1"})
+	is.NoErr(err)
+
+	var env events.Envelope
+	is.NoErr(json.Unmarshal([]byte(ch.msgs[0]), &env))
+	var gc events.GameCreated
+	is.NoErr(json.Unmarshal(env.Payload, &gc))
+	is.Equal(gc.Variant, "classical")
+}
+
+func TestDispatchNewGame_RejectsUnknownVariant(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+
+	_, err := d.Dispatch(Command{Name: "newgame", Args: []string{"gondor"}, ChannelID: "chan1", UserID: "gm1"})
+	is.Err(err)
+	is.Equal(len(ch.msgs), 0)
+}
+
+func TestDispatchNewGame_RecordsNMRPolicy(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+
+	resp, err := d.Dispatch(Command{Name: "newgame", Args: []string{"classical", "Civil-Disorder"}, ChannelID: "chan1", UserID: "gm1"})
+	is.NoErr(err)
+	is.True(contains(resp, "civil-disorder"))
+
+	var env events.Envelope
+	is.NoErr(json.Unmarshal([]byte(ch.msgs[0]), &env))
+	var gc events.GameCreated
+	is.NoErr(json.Unmarshal(env.Payload, &gc))
+	is.Equal(gc.NMRPolicy, "civil-disorder")
+}
+
+func TestDispatchNewGame_RejectsUnknownNMRPolicy(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+
+	_, err := d.Dispatch(Command{Name: "newgame", Args: []string{"classical", "anarchy"}, ChannelID: "chan1", UserID: "gm1"})
+	is.Err(err)
+	is.Equal(len(ch.msgs), 0)
+}
+
+// registerTwoPower registers a two-player variant on the classical map.
+func registerTwoPower() {
+	v := classical.ClassicalVariant
+	v.Name = "Two Power"
+	v.Nations = []godip.Nation{godip.England, godip.France}
+	v.SoloSCCount = func(*state.State) int { return 10 }
+	v.SVGMap = func() ([]byte, error) { return []byte(`<svg id="twopower"/>`), nil }
+	engine.RegisterVariant("twopower", v)
+}
+
+// TestDispatchNations_VariantWithClashingNames covers a variant whose
+// nations share their first three letters and that sets no solo count.
+func TestDispatchNations_VariantWithClashingNames(t *testing.T) {
+	is := is.New(t)
+	v := classical.ClassicalVariant
+	v.Name = "Antipodes"
+	v.Nations = []godip.Nation{"Austria", "Australia"}
+	v.SoloSCCount = nil
+	v.BlankStart = nil
+	engine.RegisterVariant("antipodes", v)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	_, err := d.Dispatch(Command{Name: "newgame", Args: []string{"antipodes"}, ChannelID: "chan1", UserID: "gm1"})
+	is.NoErr(err)
+
+	resp, err := d.Dispatch(Command{Name: "nations", Args: []string{"austra"}, ChannelID: "chan1", UserID: "u1"})
+	is.NoErr(err)
+	if !containsStr(resp, "Australia (Austra)") || !containsStr(resp, "Control 18 of 34") {
+		t.Errorf("expected Australia's detail with a majority win condition, got: %q", resp)
+	}
+	resp, err = d.Dispatch(Command{Name: "nations", Args: []string{"austri"}, ChannelID: "chan1", UserID: "u1"})
+	is.NoErr(err)
+	if !containsStr(resp, "Austria (Austri)") {
+		t.Errorf("expected Austria's detail, got: %q", resp)
+	}
+}
+
+func TestDispatch_RegisteredVariantDrivesJoinStartAndNations(t *testing.T) {
+	is := is.New(t)
+	registerTwoPower()
+	ch := &mockChannel{}
+	var gotVariant string
+	d := New(ch, events.NewChannelStore(ch), &mockNotifier{}, nil, func(v string) (engine.Engine, error) {
+		gotVariant = v
+		return goodEngine(), nil
+	})
+
+	_, err := d.Dispatch(Command{Name: "newgame", Args: []string{"twopower"}, ChannelID: "chan1", UserID: "gm1"})
+	is.NoErr(err)
+	_, err = d.Dispatch(Command{Name: "join", Args: []string{"Austria"}, ChannelID: "chan1", UserID: "u1"})
+	is.Err(err) // not a nation in this variant
+	_, err = d.Dispatch(Command{Name: "join", Args: []string{"France"}, ChannelID: "chan1", UserID: "u1"})
+	is.NoErr(err)
+	_, err = d.Dispatch(Command{Name: "join", Args: []string{"England"}, ChannelID: "chan1", UserID: "u2"})
+	is.NoErr(err)
+
+	resp, err := d.Dispatch(Command{Name: "nations", ChannelID: "chan1", UserID: "u1"})
+	is.NoErr(err)
+	if !containsStr(resp, "France") || containsStr(resp, "Austria") {
+		t.Errorf("expected /nations to list the variant's nations, got: %q", resp)
+	}
+	resp, err = d.Dispatch(Command{Name: "help", Args: []string{"rules"}, ChannelID: "chan1", UserID: "u1"})
+	is.NoErr(err)
+	if !containsStr(resp, "Control 10 of 34") {
+		t.Errorf("expected the variant's solo count in /help rules, got: %q", resp)
+	}
+
+	_, err = d.Dispatch(Command{Name: "start", ChannelID: "chan1", UserID: "gm1"})
+	is.NoErr(err)
+	is.Equal(gotVariant, "twopower")
+}
+
 // ---- /join ------------------------------------------------------------------
 
 func TestDispatchJoin_PostsPlayerJoinedEvent(t *testing.T) {
@@ -360,7 +589,8 @@ func TestDispatchStart_PostsGameStartedEvent(t *testing.T) {
 
 	_, err := d.Dispatch(Command{Name: "start", ChannelID: "chan1", UserID: "gm1"})
 	is.NoErr(err)
-	is.Equal(ch.lastEventType(), events.TypeGameStarted)
+	types := ch.eventTypes()
+	is.Equal(types[len(types)-2:], []events.EventType{events.TypeGameStarted, events.TypeDeadlineSet})
 }
 
 func TestDispatchStart_GameStartedContainsSnapshot(t *testing.T) {
@@ -374,7 +604,7 @@ func TestDispatchStart_GameStartedContainsSnapshot(t *testing.T) {
 	is.NoErr(err)
 
 	var env events.Envelope
-	is.NoErr(json.Unmarshal([]byte(ch.msgs[len(ch.msgs)-1]), &env))
+	is.NoErr(json.Unmarshal([]byte(ch.msgs[len(ch.msgs)-2]), &env))
 	var gs events.GameStarted
 	is.NoErr(json.Unmarshal(env.Payload, &gs))
 	is.NotNil(gs.InitialState)
@@ -392,6 +622,20 @@ func TestDispatchStart_StoresSession(t *testing.T) {
 	is.NotNil(d.sessions["chan1"])
 }
 
+func TestDispatchStart_AppliesNMRPolicy(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "chan1", events.TypeGameCreated, events.GameCreated{
+		Variant: "classical", DeadlineHours: 24, GMUserID: "gm1", NMRPolicy: "civil-disorder",
+	})
+	joinPlayers(ch, "chan1", 2)
+	d := newTestDispatcher(ch)
+
+	_, err := d.Dispatch(Command{Name: "start", ChannelID: "chan1", UserID: "gm1"})
+	is.NoErr(err)
+	is.Equal(d.sessions["chan1"].Eng.(*mockEngine).nmrPolicy, engine.NMRCivilDisorder)
+}
+
 func TestDispatchStart_RequiresGM(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
@@ -460,7 +704,7 @@ func TestDispatchStart_RejectsWhenEngineFactoryFails(t *testing.T) {
 	ch := &mockChannel{}
 	seedGameCreated(ch, "gm1")
 	joinPlayers(ch, "chan1", 2)
-	d := New(ch, &mockNotifier{}, nil, func(_ string) (engine.Engine, error) {
+	d := New(ch, events.NewChannelStore(ch), &mockNotifier{}, nil, func(_ string) (engine.Engine, error) {
 		return nil, errors.New("engine create failed")
 	})
 
@@ -473,7 +717,7 @@ func TestDispatchStart_RejectsWhenDumpFails(t *testing.T) {
 	ch := &mockChannel{}
 	seedGameCreated(ch, "gm1")
 	joinPlayers(ch, "chan1", 2)
-	d := New(ch, &mockNotifier{}, nil, func(_ string) (engine.Engine, error) {
+	d := New(ch, events.NewChannelStore(ch), &mockNotifier{}, nil, func(_ string) (engine.Engine, error) {
 		return &mockEngine{dump: nil, dumpErr: errors.New("dump failed")}, nil
 	})
 
@@ -507,9 +751,7 @@ func TestDispatchStart_RejectsWhenPostFails(t *testing.T) {
 
 // seedMalformed injects an envelope with a bad JSON payload for the given type.
 func seedMalformed(ch *mockChannel, channelID string, evtType events.EventType) {
-	env := events.Envelope{Type: evtType, Payload: json.RawMessage(`"bad"`)}
-	data, _ := json.Marshal(env)
-	ch.msgs = append(ch.msgs, string(data))
+	_ = events.Write(ch, channelID, evtType, json.RawMessage(`"bad"`))
 }
 
 func TestReadState_SkipsMalformedGameCreated(t *testing.T) {
@@ -562,7 +804,7 @@ func TestDispatchJoin_RejectsWhenPostFails(t *testing.T) {
 func makeDMSession(d *Dispatcher, ch *mockChannel, gameChID string) *session.Session {
 	players := map[string]string{"u1": "England", "u2": "France"}
 	eng := goodEngine()
-	sess := session.New(ch, gameChID, "gm1", "Spring 1901 Movement", players, 0, eng, &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), gameChID, "gm1", "Spring 1901 Movement", players, 0, eng, &mockNotifier{})
 	d.sessions[gameChID] = sess
 	return sess
 }
@@ -570,7 +812,7 @@ func makeDMSession(d *Dispatcher, ch *mockChannel, gameChID string) *session.Ses
 // makeSinglePlayerSession creates a started session with one player (u1→England).
 func makeSinglePlayerSession(d *Dispatcher, ch *mockChannel, gameChID string) *session.Session {
 	players := map[string]string{"u1": "England"}
-	sess := session.New(ch, gameChID, "gm1", "Spring 1901 Movement", players, 0, goodEngine(), &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), gameChID, "gm1", "Spring 1901 Movement", players, 0, goodEngine(), &mockNotifier{})
 	d.sessions[gameChID] = sess
 	return sess
 }
@@ -668,6 +910,33 @@ func TestDispatchOrder_StagesOrder(t *testing.T) {
 	is.Equal(sess.StagedOrders["England"][0], "A Vie-Bud")
 }
 
+func TestDispatchOrder_ReplacesOrderForSameUnit(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+
+	_, err := d.Dispatch(dmCmd("order", "chan1", "u1", "A", "Vie-Bud"))
+	is.NoErr(err)
+	_, err = d.Dispatch(dmCmd("order", "chan1", "u1", "F", "Tri-Alb"))
+	is.NoErr(err)
+	resp, err := d.Dispatch(dmCmd("order", "chan1", "u1", "A", "Vie-Tyr"))
+	is.NoErr(err)
+	is.Equal(resp, "Order staged: A Vie-Tyr (replaces A Vie-Bud)")
+	is.Equal(sess.StagedOrders["England"], []string{"A Vie-Tyr", "F Tri-Alb"})
+}
+
+func TestDispatchOrder_BlockKeepsLastOrderForEachUnit(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+
+	_, err := d.Dispatch(dmCmd("order", "chan1", "u1", "A", "Vie-Bud;", "F", "Tri", "H;", "A", "Vie-Tyr"))
+	is.NoErr(err)
+	is.Equal(sess.StagedOrders["England"], []string{"A Vie-Tyr", "F Tri H"})
+}
+
 func TestDispatchOrder_MultiWordOrderJoined(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
@@ -679,6 +948,117 @@ func TestDispatchOrder_MultiWordOrderJoined(t *testing.T) {
 	is.Equal(sess.StagedOrders["England"][0], "A Vie S F Tri-Alb")
 }
 
+func TestDispatchOrder_StagesAndEchoesCanonicalOrder(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	sess.Eng = &mockEngine{
+		phase:      "Spring 1901 Movement",
+		dump:       []byte(`{}`),
+		normalized: map[string]string{"army vienna -> budapest": "A Vie-Bud"},
+	}
+
+	resp, err := d.Dispatch(dmCmd("order", "chan1", "u1", "army", "vienna", "->", "budapest"))
+	is.NoErr(err)
+	is.Equal(resp, "Order staged: A Vie-Bud")
+	is.Equal(sess.StagedOrders["England"], []string{"A Vie-Bud"})
+}
+
+func TestDispatchOrder_RejectsUnparseableOrder(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	sess.Eng = &mockEngine{
+		phase:        "Spring 1901 Movement",
+		dump:         []byte(`{}`),
+		normalizeErr: errors.New("unknown province"),
+	}
+
+	_, err := d.Dispatch(dmCmd("order", "chan1", "u1", "A", "Gondor-Bud"))
+	is.Err(err)
+	is.Equal(len(sess.StagedOrders["England"]), 0)
+}
+
+func TestDispatchOrder_WithdrawsSubmission(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	sess.Eng = &mockEngine{phase: "Spring 1901 Movement", dump: []byte(`{}`)}
+	sess.StagedOrders["England"] = []string{"F Lon H"}
+	is.NoErr(sess.FinaliseSubmission("England"))
+
+	_, err := d.Dispatch(dmCmd("order", "chan1", "u1", "F", "Lon-Nth"))
+	is.NoErr(err)
+	is.Equal(sess.StagedOrders["England"], []string{"F Lon-Nth"})
+	is.Equal(sess.Submitted["England"], false)
+	g, _, err := events.ReadGame(events.NewChannelStore(ch), "chan1")
+	is.NoErr(err)
+	is.Equal(g.Submitted["England"], false)
+}
+
+func TestDispatchOrder_BlockReplacesStagedOrders(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	eng := &mockEngine{phase: "Spring 1901 Movement", dump: []byte(`{}`)}
+	sess.Eng = eng
+	sess.StagedOrders["England"] = []string{"F Lon H"}
+	sess.Submitted["England"] = true
+
+	resp, err := d.Dispatch(dmCmd("order", "chan1", "u1", "A", "Vie-Gal;", "A", "Bud-Rum", "\n", "F", "Tri", "H"))
+	is.NoErr(err)
+	is.True(contains(resp, "Staged orders for England"))
+	is.True(contains(resp, "2. accepted: A Bud-Rum"))
+	is.Equal(sess.StagedOrders["England"], []string{"A Vie-Gal", "A Bud-Rum", "F Tri H"})
+	is.Equal(sess.Submitted["England"], false)
+	is.Equal(eng.cleared, []string{"England"})
+	is.Equal(eng.submitted, []string{"A Vie-Gal", "A Bud-Rum", "F Tri H"})
+}
+
+func TestDispatchOrder_BlockStagesCanonicalOrders(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	sess.Eng = &mockEngine{
+		phase:      "Spring 1901 Movement",
+		dump:       []byte(`{}`),
+		normalized: map[string]string{"vienna -> galicia": "A Vie-Gal"},
+	}
+
+	resp, err := d.Dispatch(dmCmd("order", "chan1", "u1", "vienna", "->", "galicia;", "A", "Bud-Rum"))
+	is.NoErr(err)
+	is.True(contains(resp, "1. accepted: A Vie-Gal"))
+	is.Equal(sess.StagedOrders["England"], []string{"A Vie-Gal", "A Bud-Rum"})
+}
+
+func TestDispatchOrder_BlockWithRejectedOrderStagesNothing(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	eng := &mockEngine{
+		phase:    "Spring 1901 Movement",
+		dump:     []byte(`{}`),
+		rejected: map[string]error{"A Xyz-Foo": errors.New("unknown province")},
+	}
+	sess.Eng = eng
+	sess.StagedOrders["England"] = []string{"F Lon H"}
+
+	_, err := d.Dispatch(dmCmd("order", "chan1", "u1", "A", "Vie-Gal;", "A", "Xyz-Foo;", "F", "Tri", "H"))
+	is.Err(err)
+	is.True(contains(err.Error(), "1 of 3 orders rejected"))
+	is.True(contains(err.Error(), "1. accepted: A Vie-Gal"))
+	is.True(contains(err.Error(), "2. rejected: A Xyz-Foo (unknown province)"))
+	is.Equal(sess.StagedOrders["England"], []string{"F Lon H"})
+	is.Equal(len(eng.cleared), 0)
+	is.Equal(len(eng.submitted), 0)
+}
+
 // ---- /orders ----------------------------------------------------------------
 
 func TestDispatchOrders_RejectsNonDM(t *testing.T) {
@@ -751,6 +1131,40 @@ func containsStr(s, sub string) bool {
 	return false
 }
 
+func TestDispatchOrdersSet_ReplacesStagedOrders(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	sess.StagedOrders["England"] = []string{"F Lon H", "A Lvp H"}
+
+	resp, err := d.Dispatch(dmCmd("orders", "chan1", "u1", "set", "F", "Lon-Nth"))
+	is.NoErr(err)
+	is.True(contains(resp, "Staged orders for England"))
+	is.Equal(sess.StagedOrders["England"], []string{"F Lon-Nth"})
+}
+
+func TestDispatchOrdersSet_RejectsEmptyBlock(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	makeDMSession(d, ch, "chan1")
+
+	_, err := d.Dispatch(dmCmd("orders", "chan1", "u1", "set", ";"))
+	is.Err(err)
+}
+
+func TestDispatchOrdersSet_RejectsNonMovementPhase(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	sess.Phase = "Spring 1901 Retreat"
+
+	_, err := d.Dispatch(dmCmd("orders", "chan1", "u1", "set", "A", "Vie-Gal"))
+	is.Err(err)
+}
+
 // ---- /clear -----------------------------------------------------------------
 
 func TestDispatchClear_RejectsNonDM(t *testing.T) {
@@ -808,6 +1222,59 @@ func TestDispatchClear_ClearsSpecificOrder(t *testing.T) {
 	is.Equal(sess.StagedOrders["England"][0], "F Tri-Alb")
 }
 
+func TestDispatchClear_ClearsOrderByProvince(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	eng := &mockEngine{phase: "Spring 1901 Movement", dump: []byte(`{}`)}
+	sess.Eng = eng
+	sess.StagedOrders["England"] = []string{"A Vie-Bud", "F Tri-Alb"}
+
+	resp, err := d.Dispatch(dmCmd("clear", "chan1", "u1", "vie"))
+	is.NoErr(err)
+	is.Equal(resp, "Order removed: A Vie-Bud")
+	is.Equal(sess.StagedOrders["England"], []string{"F Tri-Alb"})
+	is.Equal(eng.cleared, []string{"England"})
+	is.Equal(eng.submitted, []string{"F Tri-Alb"})
+}
+
+func TestDispatchClear_ClearsOrderByLooseText(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	sess.Eng = &mockEngine{
+		phase:      "Spring 1901 Movement",
+		dump:       []byte(`{}`),
+		normalized: map[string]string{"a vie-bud": "A Vie-Bud"},
+		provinces:  map[string]string{"trieste": "tri"},
+	}
+	sess.StagedOrders["England"] = []string{"A Vie-Bud", "F Tri-Alb", "A Bud-Ser"}
+
+	resp, err := d.Dispatch(dmCmd("clear", "chan1", "u1", "a", "vie-bud"))
+	is.NoErr(err)
+	is.Equal(resp, "Order removed: A Vie-Bud")
+	resp, err = d.Dispatch(dmCmd("clear", "chan1", "u1", "trieste"))
+	is.NoErr(err)
+	is.Equal(resp, "Order removed: F Tri-Alb")
+	is.Equal(sess.StagedOrders["England"], []string{"A Bud-Ser"})
+}
+
+func TestDispatchClear_ClearsEngineOrders(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	eng := &mockEngine{phase: "Spring 1901 Movement", dump: []byte(`{}`)}
+	sess.Eng = eng
+	sess.StagedOrders["England"] = []string{"A Vie-Bud"}
+
+	_, err := d.Dispatch(dmCmd("clear", "chan1", "u1"))
+	is.NoErr(err)
+	is.Equal(eng.cleared, []string{"England"})
+}
+
 func TestDispatchClear_RejectsOrderNotFound(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
@@ -881,7 +1348,7 @@ func TestDispatchSubmit_WritesOrderSubmittedToDM(t *testing.T) {
 	is.NoErr(err)
 
 	// DM thread for u1 must contain an OrderSubmitted event.
-	envs, scanErr := events.ScanDM(ch, "u1")
+	envs, _, scanErr := events.ScanDM(ch, "u1")
 	is.NoErr(scanErr)
 	is.Equal(len(envs), 1)
 	is.Equal(envs[0].Type, events.TypeOrderSubmitted)
@@ -894,6 +1361,66 @@ func TestDispatchSubmit_WritesOrderSubmittedToDM(t *testing.T) {
 	is.Equal(os.Orders[0], "A Vie-Bud")
 }
 
+func TestDispatchSubmit_ListsLintWarningsWithoutSubmitting(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	sess.Eng = &mockEngine{
+		phase: "Spring 1901 Movement",
+		dump:  []byte(`{}`),
+		units: map[string]engine.UnitInfo{
+			"vie": {Type: "Army", Nation: "England"},
+			"bud": {Type: "Army", Nation: "England"},
+		},
+	}
+	sess.StagedOrders["England"] = []string{"A Vie-Gal"}
+
+	resp, err := d.Dispatch(dmCmd("submit", "chan1", "u1"))
+	is.NoErr(err)
+	is.True(contains(resp, "A Bud: has no order and will hold"))
+	is.True(contains(resp, "/submit confirm"))
+	is.Equal(sess.Submitted["England"], false)
+	envs, _, scanErr := events.ScanDM(ch, "u1")
+	is.NoErr(scanErr)
+	is.Equal(len(envs), 0)
+
+	resp, err = d.Dispatch(dmCmd("submit", "chan1", "u1", "confirm"))
+	is.NoErr(err)
+	is.Equal(resp, "Orders submitted.")
+	is.Equal(sess.Submitted["England"], true)
+}
+
+func TestDispatchSubmit_ConfirmAfterChangingOrdersWarnsAgain(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	sess.Eng = &mockEngine{
+		phase: "Spring 1901 Movement",
+		dump:  []byte(`{}`),
+		units: map[string]engine.UnitInfo{
+			"vie": {Type: "Army", Nation: "England"},
+			"bud": {Type: "Army", Nation: "England"},
+		},
+	}
+	sess.StagedOrders["England"] = []string{"A Vie-Gal"}
+	_, err := d.Dispatch(dmCmd("submit", "chan1", "u1"))
+	is.NoErr(err)
+
+	// A new mistake staged after the warning is shown before it is submitted.
+	sess.StagedOrders["England"] = []string{"A Vie-Bud"}
+	resp, err := d.Dispatch(dmCmd("submit", "chan1", "u1", "confirm"))
+	is.NoErr(err)
+	is.True(contains(resp, "changed since you were last warned"))
+	is.True(contains(resp, "A Vie-Bud: moves into Bud"))
+	is.Equal(sess.Submitted["England"], false)
+
+	resp, err = d.Dispatch(dmCmd("submit", "chan1", "u1", "confirm"))
+	is.NoErr(err)
+	is.Equal(resp, "Orders submitted.")
+}
+
 func TestDispatchSubmit_DoesNotFireAdvanceTurnIfNotAllSubmitted(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
@@ -905,8 +1432,8 @@ func TestDispatchSubmit_DoesNotFireAdvanceTurnIfNotAllSubmitted(t *testing.T) {
 	is.NoErr(err)
 	is.Equal(resp, "Orders submitted.")
 
-	// No PhaseResolved event should have been posted to the game channel.
-	is.Equal(len(ch.msgs), 0)
+	// Only the submission should have been recorded; no PhaseResolved.
+	is.Equal(ch.eventTypes(), []events.EventType{events.TypeSubmissionFinalised})
 }
 
 func TestDispatchSubmit_FiresAdvanceTurnWhenAllSubmitted(t *testing.T) {
@@ -922,10 +1449,7 @@ func TestDispatchSubmit_FiresAdvanceTurnWhenAllSubmitted(t *testing.T) {
 	}
 
 	// PhaseResolved event must have been posted to the game channel.
-	is.Equal(len(ch.msgs), 1)
-	var env events.Envelope
-	is.NoErr(json.Unmarshal([]byte(ch.msgs[0]), &env))
-	is.Equal(env.Type, events.TypePhaseResolved)
+	is.Equal(ch.eventTypes()[:2], []events.EventType{events.TypeSubmissionFinalised, events.TypePhaseResolved})
 }
 
 func TestDispatchSubmit_RejectsDMHistoryError(t *testing.T) {
@@ -956,6 +1480,84 @@ func TestDispatchSubmit_RejectsAdvanceTurnError(t *testing.T) {
 	is.Err(err)
 }
 
+// ---- /preview ---------------------------------------------------------------
+
+func TestDispatchPreview_RejectsNonDM(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	makeDMSession(d, ch, "chan1")
+
+	_, err := d.Dispatch(Command{Name: "preview", ChannelID: "chan1", UserID: "u1"})
+	is.Err(err)
+}
+
+func TestDispatchPreview_RejectsNonPlayer(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	makeDMSession(d, ch, "chan1")
+
+	_, err := d.Dispatch(dmCmd("preview", "chan1", "outsider"))
+	is.Err(err)
+}
+
+func TestDispatchPreview_AdjudicatesStagedAndAssumedOrders(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	sess.StagedOrders["England"] = []string{"F Lon-Nth"}
+	sess.StagedOrders["France"] = []string{"A Par-Bur"}
+	eng := sess.Eng.(*mockEngine)
+	eng.previewResult = engine.ResolutionResult{Phase: "Movement", Orders: []engine.OrderResult{
+		{Province: "lon", Nation: "England", Text: "F Lon-Nth", Success: true, Outcome: engine.OutcomeSucceeded},
+		{Province: "mun", Nation: "Germany", Text: "A Mun-Bur", Outcome: engine.OutcomeBounced, By: "par"},
+	}}
+
+	resp, err := d.Dispatch(dmCmd("preview", "chan1", "u1", "A", "Mun-Bur;", "A", "Kie-Hol"))
+	is.NoErr(err)
+	// Only the caller's staged orders are used, followed by the assumed ones.
+	is.Equal(eng.previewOrders, []string{"F Lon-Nth", "A Mun-Bur", "A Kie-Hol"})
+	is.True(contains(resp, "nothing has been submitted"))
+	is.True(contains(resp, "Germany A Mun-Bur: bounced (standoff with par)"))
+	is.Equal(len(ch.imgs), 1)
+	// Nothing is staged or submitted in the real game.
+	is.Equal(sess.StagedOrders["England"], []string{"F Lon-Nth"})
+	is.False(sess.Submitted["England"])
+}
+
+func TestDispatchPreview_RendersProjectedBoard(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	projected := goodEngine()
+	projected.units = map[string]engine.UnitInfo{"bur": {Type: "Army", Nation: "France"}}
+	sess.Eng.(*mockEngine).previewEng = projected
+	var overlaid map[string]dipmap.Unit
+	d.overlayFn = func(svg []byte, units map[string]dipmap.Unit) ([]byte, error) {
+		overlaid = units
+		return svg, nil
+	}
+
+	_, err := d.Dispatch(dmCmd("preview", "chan1", "u1"))
+	is.NoErr(err)
+	is.Equal(overlaid, map[string]dipmap.Unit{"bur": {Type: "Army", Nation: "France"}})
+}
+
+func TestDispatchPreview_RejectsInvalidOrder(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	sess.Eng.(*mockEngine).previewErr = errors.New("illegal order")
+
+	_, err := d.Dispatch(dmCmd("preview", "chan1", "u1", "A", "Par-Mos"))
+	is.Err(err)
+	is.Equal(len(ch.imgs), 0)
+}
+
 // ---- allNationsSubmitted edge cases -----------------------------------------
 
 // TestAllNationsSubmitted_SkipsNonOrderSubmittedEvents verifies that
@@ -970,9 +1572,7 @@ func TestAllNationsSubmitted_SkipsNonOrderSubmittedEvents(t *testing.T) {
 	_ = ch.SendDM("u1", "not-json-at-all")
 	_ = events.WriteDM(ch, "u1", events.TypeGameCreated, events.GameCreated{Variant: "classical"})
 	// Malformed OrderSubmitted payload.
-	badEnv := events.Envelope{Type: events.TypeOrderSubmitted, Payload: json.RawMessage(`"bad"`)}
-	badData, _ := json.Marshal(badEnv)
-	_ = ch.SendDM("u1", string(badData))
+	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, json.RawMessage(`"bad"`))
 	// Wrong phase.
 	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, events.OrderSubmitted{
 		Nation: "England", Phase: "Fall 1901 Movement",
@@ -1001,7 +1601,7 @@ func makeRetreatSession(d *Dispatcher, ch *mockChannel, gameChID string) *sessio
 		dump:       []byte(`{}`),
 		dislodgeds: map[string]string{"vie": "England"},
 	}
-	sess := session.New(ch, gameChID, "gm1", "Spring 1901 Retreat", players, 0, eng, &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), gameChID, "gm1", "Spring 1901 Retreat", players, 0, eng, &mockNotifier{})
 	d.sessions[gameChID] = sess
 	return sess
 }
@@ -1016,7 +1616,7 @@ func makeTwoNationRetreatSession(d *Dispatcher, ch *mockChannel, gameChID string
 		dump:       []byte(`{}`),
 		dislodgeds: map[string]string{"vie": "England", "par": "France"},
 	}
-	sess := session.New(ch, gameChID, "gm1", "Spring 1901 Retreat", players, 0, eng, &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), gameChID, "gm1", "Spring 1901 Retreat", players, 0, eng, &mockNotifier{})
 	d.sessions[gameChID] = sess
 	return sess
 }
@@ -1028,7 +1628,7 @@ func makeAdjustmentSession(d *Dispatcher, ch *mockChannel, gameChID string) *ses
 		phase: "Fall 1901 Adjustment",
 		dump:  []byte(`{}`),
 	}
-	sess := session.New(ch, gameChID, "gm1", "Fall 1901 Adjustment", players, 0, eng, &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), gameChID, "gm1", "Fall 1901 Adjustment", players, 0, eng, &mockNotifier{})
 	d.sessions[gameChID] = sess
 	return sess
 }
@@ -1048,7 +1648,7 @@ func makeTwoNationAdjustmentSession(d *Dispatcher, ch *mockChannel, gameChID str
 		},
 		// SupplyCenters returns empty map by default (0 SCs for each).
 	}
-	sess := session.New(ch, gameChID, "gm1", "Fall 1901 Adjustment", players, 0, eng, &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), gameChID, "gm1", "Fall 1901 Adjustment", players, 0, eng, &mockNotifier{})
 	d.sessions[gameChID] = sess
 	return sess
 }
@@ -1597,6 +2197,26 @@ func TestDispatchStatus_ReturnsPhaseAndNations(t *testing.T) {
 	}
 }
 
+func TestDispatchStatus_ShowsSkippedSnapshotsToGM(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	sess.Skipped = []events.SkippedSnapshot{{Seq: 7, Type: events.TypePhaseResolved, Phase: "Movement", Err: engine.ErrInvalidSnapshot}}
+
+	resp, err := d.Dispatch(Command{Name: "status", ChannelID: "chan1", UserID: "gm1"})
+	is.NoErr(err)
+	if !containsStr(resp, "event 7 (PhaseResolved Movement): invalid snapshot") {
+		t.Errorf("expected skipped snapshot in GM status output, got: %q", resp)
+	}
+
+	resp, err = d.Dispatch(Command{Name: "status", ChannelID: "chan1", UserID: "u1"})
+	is.NoErr(err)
+	if containsStr(resp, "Skipped") {
+		t.Errorf("expected no skipped snapshots in player status output, got: %q", resp)
+	}
+}
+
 // ---- /history ---------------------------------------------------------------
 
 func TestDispatchHistory_RejectsMissingArg(t *testing.T) {
@@ -1643,6 +2263,29 @@ func TestDispatchHistory_ReturnsResultSummary(t *testing.T) {
 	}
 }
 
+func TestDispatchHistory_FormatsResolutionResult(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	summary, _ := json.Marshal(engine.ResolutionResult{
+		Phase: "Movement",
+		Orders: []engine.OrderResult{
+			{Province: "par", Nation: "France", Text: "A Par-Bur", Outcome: engine.OutcomeBounced, By: "mun"},
+		},
+	})
+	_ = events.Write(ch, "chan1", events.TypePhaseResolved, events.PhaseResolved{
+		Phase:         "Spring 1901 Movement",
+		StateSnapshot: json.RawMessage(`{}`),
+		ResultSummary: summary,
+	})
+	d := newTestDispatcher(ch)
+
+	resp, err := d.Dispatch(Command{Name: "history", Args: []string{"Spring 1901"}, ChannelID: "chan1", UserID: "u1"})
+	is.NoErr(err)
+	if !containsStr(resp, "France A Par-Bur: bounced (standoff with mun)") {
+		t.Errorf("expected formatted order outcome in response, got: %q", resp)
+	}
+}
+
 func TestDispatchHistory_NoResultSummaryFallback(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
@@ -1669,9 +2312,7 @@ func TestDispatchHistory_SkipsMalformedPhaseResolved(t *testing.T) {
 		ResultSummary: json.RawMessage(`"Fall 1901 resolved"`),
 	})
 	// Malformed PhaseResolved envelope after (latest in history — hit first by reverse scan).
-	bad := events.Envelope{Type: events.TypePhaseResolved, Payload: json.RawMessage(`"bad"`)}
-	data, _ := json.Marshal(bad)
-	ch.msgs = append(ch.msgs, string(data))
+	_ = events.Write(ch, "chan1", events.TypePhaseResolved, json.RawMessage(`"bad"`))
 	d := newTestDispatcher(ch)
 
 	resp, err := d.Dispatch(Command{Name: "history", Args: []string{"Fall 1901"}, ChannelID: "chan1", UserID: "u1"})
@@ -1719,6 +2360,24 @@ func TestDispatchMap_PostsImageNoArgs(t *testing.T) {
 	is.Equal(len(ch.imgs), 1)
 }
 
+func TestDispatchMap_UsesVariantMapForNonClassical(t *testing.T) {
+	is := is.New(t)
+	registerTwoPower()
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := makeDMSession(d, ch, "chan1")
+	sess.Eng.(*mockEngine).variant = "twopower"
+	var rendered []byte
+	d.imgFn = func(svg []byte) ([]byte, error) {
+		rendered = svg
+		return []byte("fakeimg"), nil
+	}
+
+	_, err := d.Dispatch(Command{Name: "map", ChannelID: "chan1", UserID: "u1"})
+	is.NoErr(err)
+	is.Equal(string(rendered), `<svg id="twopower"/>`)
+}
+
 func TestDispatchMap_PostsImageWithTerritory(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
@@ -1906,7 +2565,7 @@ func TestDispatchHelp_ShowsRules(t *testing.T) {
 
 	resp, err := d.Dispatch(Command{Name: "help", Args: []string{"rules"}, ChannelID: "chan1", UserID: "u1"})
 	is.NoErr(err)
-	for _, keyword := range []string{"supply centres", "phase"} {
+	for _, keyword := range []string{"supply centres", "phase", "Control 18 of 34"} {
 		if !containsStr(resp, keyword) {
 			t.Errorf("expected %q in /help rules output, got: %q", keyword, resp)
 		}
@@ -2075,7 +2734,7 @@ func seedStartedGame(d *Dispatcher, ch *mockChannel, channelID, gmID string, pla
 		InitialState: json.RawMessage(`{}`),
 	})
 	eng := goodEngine()
-	sess := session.New(ch, channelID, gmID, "Spring 1901 Movement", players, 0, eng, &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), channelID, gmID, "Spring 1901 Movement", players, 0, eng, &mockNotifier{})
 	d.sessions[channelID] = sess
 	return sess
 }
@@ -2529,6 +3188,27 @@ func TestDispatchPause_CancelsDeadline(t *testing.T) {
 	}
 }
 
+func TestDispatchPause_RecordsGamePaused(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	d := newTestDispatcher(ch)
+	sess := twoPlayerGame(d, ch)
+	sess.DeadlineHours = 24
+
+	_, err := d.Dispatch(gameCmd("pause", "chan1", "gm1"))
+	is.NoErr(err)
+	is.Equal(ch.lastEventType(), events.TypeGamePaused)
+
+	_, err = d.Dispatch(gameCmd("extend", "chan1", "gm1", "2h"))
+	is.NoErr(err)
+	is.Equal(ch.lastEventType(), events.TypeDeadlineSet)
+
+	g, _, err := events.ReadGame(events.NewChannelStore(ch), "chan1")
+	is.NoErr(err)
+	is.True(g.Paused)
+	is.True(!g.Deadline.IsZero())
+}
+
 func TestDispatchPause_RejectsIfNotGM(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
@@ -2827,7 +3507,7 @@ func TestAllRetreatActionsSubmitted_FalseWhenSecondNationPending(t *testing.T) {
 		},
 	}
 	ch := &mockChannel{}
-	sess := session.New(ch, "chan1", "gm1", "Spring 1901 Retreat", players, 0, eng, &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), "chan1", "gm1", "Spring 1901 Retreat", players, 0, eng, &mockNotifier{})
 	sess.Submitted["England"] = true
 	is.Equal(allRetreatActionsSubmitted(sess), false)
 }
@@ -2845,7 +3525,7 @@ func TestAllAdjustmentActionsSubmitted_FalseWhenSecondNationPending(t *testing.T
 		// SupplyCenters returns empty map: England 0 SCs, 1 unit → delta -1
 	}
 	ch := &mockChannel{}
-	sess := session.New(ch, "chan1", "gm1", "Fall 1901 Adjustment", players, 0, eng, &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), "chan1", "gm1", "Fall 1901 Adjustment", players, 0, eng, &mockNotifier{})
 	is.Equal(allAdjustmentActionsSubmitted(sess), false)
 }
 
@@ -2932,7 +3612,7 @@ func TestDispatchWaive_ReturnsErrorWhenAdvanceTurnFails(t *testing.T) {
 		resolveErr: errors.New("resolve failed"),
 		// 0 SCs, 0 units → allAdjustmentActionsSubmitted returns true immediately.
 	}
-	sess := session.New(ch, "chan1", "gm1", "Fall 1901 Adjustment", players, 0, eng, &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), "chan1", "gm1", "Fall 1901 Adjustment", players, 0, eng, &mockNotifier{})
 	d.sessions["chan1"] = sess
 
 	_, err := d.Dispatch(dmCmd("waive", "chan1", "u1"))
@@ -2951,7 +3631,7 @@ func TestDispatchRetreat_ReturnsErrorWhenAdvanceTurnFails(t *testing.T) {
 		dislodgeds: map[string]string{"vie": "England"},
 		resolveErr: errors.New("resolve failed"),
 	}
-	sess := session.New(ch, "chan1", "gm1", "Spring 1901 Retreat", players, 0, eng, &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), "chan1", "gm1", "Spring 1901 Retreat", players, 0, eng, &mockNotifier{})
 	d.sessions["chan1"] = sess
 
 	_, err := d.Dispatch(dmCmd("retreat", "chan1", "u1", "A", "Vie", "Bud"))
@@ -2970,7 +3650,7 @@ func TestDispatchDisband_ReturnsErrorWhenAdvanceTurnFailsInRetreat(t *testing.T)
 		dislodgeds: map[string]string{"vie": "England"},
 		resolveErr: errors.New("resolve failed"),
 	}
-	sess := session.New(ch, "chan1", "gm1", "Spring 1901 Retreat", players, 0, eng, &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), "chan1", "gm1", "Spring 1901 Retreat", players, 0, eng, &mockNotifier{})
 	d.sessions["chan1"] = sess
 
 	_, err := d.Dispatch(dmCmd("disband", "chan1", "u1", "A", "Vie"))
@@ -2989,7 +3669,7 @@ func TestDispatchDisband_ReturnsErrorWhenAdvanceTurnFailsInAdjustment(t *testing
 		resolveErr: errors.New("resolve failed"),
 		// 0 SCs, 0 units → allAdjustmentActionsSubmitted returns true immediately.
 	}
-	sess := session.New(ch, "chan1", "gm1", "Fall 1901 Adjustment", players, 0, eng, &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), "chan1", "gm1", "Fall 1901 Adjustment", players, 0, eng, &mockNotifier{})
 	d.sessions["chan1"] = sess
 
 	_, err := d.Dispatch(dmCmd("disband", "chan1", "u1", "A", "Lon"))
@@ -3008,7 +3688,7 @@ func TestDispatchBuild_ReturnsErrorWhenAdvanceTurnFails(t *testing.T) {
 		resolveErr: errors.New("resolve failed"),
 		// 0 SCs, 0 units → allAdjustmentActionsSubmitted returns true immediately.
 	}
-	sess := session.New(ch, "chan1", "gm1", "Fall 1901 Adjustment", players, 0, eng, &mockNotifier{})
+	sess := session.New(events.NewChannelStore(ch), "chan1", "gm1", "Fall 1901 Adjustment", players, 0, eng, &mockNotifier{})
 	d.sessions["chan1"] = sess
 
 	_, err := d.Dispatch(dmCmd("build", "chan1", "u1", "A", "Lon"))
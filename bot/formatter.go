@@ -8,20 +8,57 @@ import (
 	"github.com/burrbd/dip/engine"
 )
 
-// FormatResult renders a ResolutionResult as a human-readable plain-text string.
+// FormatResult renders a ResolutionResult as a human-readable plain-text string,
+// one line per order with the reason any failed order did not succeed.
 func FormatResult(r engine.ResolutionResult) string {
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "Phase %s resolved. %d orders processed.\n", r.Phase, len(r.Orders))
 	for _, o := range r.Orders {
-		status := "succeeded"
-		if !o.Success {
-			status = "failed"
+		label := o.Text
+		if label == "" {
+			label = o.Province + " " + o.Order
 		}
-		fmt.Fprintf(&sb, "  %s %s: %s\n", o.Province, o.Order, status)
+		if o.Nation != "" {
+			label = o.Nation + " " + label
+		}
+		fmt.Fprintf(&sb, "  %s: %s\n", label, describeOutcome(o))
 	}
 	return strings.TrimRight(sb.String(), "\n")
 }
 
+// describeOutcome explains an order's outcome in words, naming the province
+// responsible for a failure when the engine reports one.
+func describeOutcome(o engine.OrderResult) string {
+	switch o.Outcome {
+	case "":
+		if o.Success {
+			return "succeeded"
+		}
+		return "failed"
+	case engine.OutcomeBounced:
+		if o.By != "" {
+			return fmt.Sprintf("bounced (standoff with %s)", o.By)
+		}
+	case engine.OutcomeSupportCut:
+		if o.By != "" {
+			return fmt.Sprintf("support cut by %s", o.By)
+		}
+	case engine.OutcomeDislodged:
+		if o.By != "" {
+			return fmt.Sprintf("dislodged by %s", o.By)
+		}
+	case engine.OutcomeConvoyDisrupted:
+		if o.By != "" {
+			return fmt.Sprintf("convoy disrupted (fleet in %s dislodged)", o.By)
+		}
+	case engine.OutcomeVoid:
+		if o.Reason != "" {
+			return fmt.Sprintf("void (%s)", o.Reason)
+		}
+	}
+	return string(o.Outcome)
+}
+
 // FormatStatus renders the current game status as plain text, listing each
 // nation with their SC count and order submission status for the current phase.
 func FormatStatus(phase string, players map[string]string, submitted map[string]bool, scCounts map[string]int) string {
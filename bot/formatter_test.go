@@ -54,6 +54,29 @@ func TestFormatResult_EmptyOrders(t *testing.T) {
 	is.NotNil(out) // must not panic; empty orders is valid
 }
 
+func TestFormatResult_ExplainsFailures(t *testing.T) {
+	r := engine.ResolutionResult{
+		Phase: "Movement",
+		Orders: []engine.OrderResult{
+			{Province: "par", Nation: "France", Text: "A Par-Bur", Outcome: engine.OutcomeBounced, By: "mun"},
+			{Province: "mar", Nation: "France", Text: "A Mar S A Par-Bur", Outcome: engine.OutcomeSupportCut, By: "pie"},
+			{Province: "bur", Nation: "Germany", Text: "A Bur H", Outcome: engine.OutcomeDislodged, By: "par"},
+			{Province: "lon", Nation: "England", Text: "A Lon-Par", Outcome: engine.OutcomeVoid, Reason: "the unit cannot reach its destination"},
+		},
+	}
+	out := FormatResult(r)
+	for _, want := range []string{
+		"France A Par-Bur: bounced (standoff with mun)",
+		"France A Mar S A Par-Bur: support cut by pie",
+		"Germany A Bur H: dislodged by par",
+		"England A Lon-Par: void (the unit cannot reach its destination)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %q", want, out)
+		}
+	}
+}
+
 func TestFormatStatus_IncludesPhase(t *testing.T) {
 	out := FormatStatus("Spring 1901 Movement", map[string]string{"u1": "England"}, map[string]bool{}, map[string]int{})
 	if !strings.Contains(out, "Spring 1901 Movement") {
@@ -28,6 +28,7 @@ import (
 
 	"github.com/burrbd/dip/bot"
 	"github.com/burrbd/dip/engine"
+	"github.com/burrbd/dip/events"
 	"github.com/burrbd/dip/platform/local"
 )
 
@@ -39,6 +40,7 @@ var dmCommands = map[string]bool{
 	"orders":  true,
 	"clear":   true,
 	"submit":  true,
+	"preview": true,
 	"retreat": true,
 	"disband": true,
 	"build":   true,
@@ -48,7 +50,7 @@ var dmCommands = map[string]bool{
 func main() {
 	ch := local.NewChannel()
 	notifier := local.NewNotifier(ch)
-	d := bot.New(ch, notifier, engine.Load, engine.New)
+	d := bot.New(ch, events.NewMemoryStore(), notifier, engine.Load, engine.New)
 
 	activeUser := "gm"
 	scanner := bufio.NewScanner(os.Stdin)
@@ -6,17 +6,28 @@
 //
 //	TELEGRAM_BOT_TOKEN  — required; Telegram Bot API token
 //	DATA_DIR            — directory for the JSONL history store (default: ./data)
+//	EVENT_STORE         — where game events are kept: "chat", posted into the chat
+//	                      history, or "file", under DATA_DIR/events (default: chat).
+//	                      Games are not copied between stores, so switching a
+//	                      deployment to "file" starts it with no games
+//	EVENT_SIGNING_KEY   — required when EVENT_STORE is "chat"; secret key that
+//	                      signs game event messages
+//	EVENT_ALLOW_LEGACY  — set to 1 to replay games whose chat history was written
+//	                      before event messages were signed (see events.AllowLegacy)
 //	PORT                — HTTP listen port (default: 8080)
 package main
 
 import (
+	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 
 	"github.com/burrbd/dip/bot"
 	"github.com/burrbd/dip/engine"
+	"github.com/burrbd/dip/events"
 	"github.com/burrbd/dip/platform/telegram"
 	"github.com/burrbd/dip/session"
 )
@@ -32,8 +43,12 @@ func main() {
 	}
 
 	ch := telegram.New(token, store)
+	eventStore, err := newEventStore(envOr("EVENT_STORE", "chat"), ch, filepath.Join(dataDir, "events"))
+	if err != nil {
+		log.Fatalf("telegrambot: %v", err)
+	}
 	notifier := telegram.NewNotifier(ch)
-	d := bot.New(ch, notifier, engine.Load, engine.New)
+	d := bot.New(ch, eventStore, notifier, engine.Load, engine.New)
 
 	http.HandleFunc("/webhook", makeWebhookHandler(ch, d))
 
@@ -76,6 +91,23 @@ func makeWebhookHandler(ch *telegram.Channel, d *bot.Dispatcher) http.HandlerFun
 	}
 }
 
+// newEventStore returns the event store named by kind.
+func newEventStore(kind string, ch events.Channel, dir string) (events.Store, error) {
+	switch kind {
+	case "file":
+		st, err := events.NewFileStore(dir)
+		if err != nil {
+			return nil, fmt.Errorf("create event store: %w", err)
+		}
+		return st, nil
+	case "chat":
+		events.SetKey([]byte(mustEnv("EVENT_SIGNING_KEY")))
+		events.AllowLegacy(os.Getenv("EVENT_ALLOW_LEGACY") == "1")
+		return events.NewChannelStore(ch), nil
+	}
+	return nil, fmt.Errorf("unknown EVENT_STORE %q", kind)
+}
+
 func mustEnv(key string) string {
 	v := os.Getenv(key)
 	if v == "" {
@@ -0,0 +1,294 @@
+// Package crosscheck adjudicates the same movement phase with the native
+// resolver in game and with the godip-backed engine, and reports every unit
+// the two leave in a different place. Cases come from the DATC catalogue,
+// from randomly generated positions, or from anywhere else a position and a
+// set of orders can be written down.
+package crosscheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/burrbd/dip/engine"
+	"github.com/burrbd/dip/game"
+	"github.com/burrbd/dip/game/order"
+	"github.com/burrbd/dip/game/order/board"
+	"github.com/zond/godip"
+)
+
+// Case is a classical movement phase to adjudicate. Provinces are written
+// the way godip writes them, e.g. "eng" or "spa/nc".
+type Case struct {
+	Name string
+	// Units holds the units on the board keyed by province.
+	Units  map[string]engine.UnitInfo
+	Orders []Order
+}
+
+// Order is order text given by a nation, e.g. "A Lon-Bel". Both
+// adjudicators treat an order they cannot accept as no order at all.
+type Order struct {
+	Nation string
+	Text   string
+}
+
+// Outcome is where a unit stands after adjudication.
+type Outcome struct {
+	Province  string
+	Dislodged bool
+}
+
+func (o Outcome) String() string {
+	if o.Dislodged {
+		return o.Province + " dislodged"
+	}
+	return o.Province
+}
+
+// Disagreement is a unit the two adjudicators leave in different places.
+// Unit names the unit by where it started, e.g. "F nth", and Order is the
+// order it was given, if any.
+type Disagreement struct {
+	Unit   string
+	Order  string
+	Native Outcome
+	Godip  Outcome
+}
+
+// Report is the result of adjudicating one Case both ways.
+type Report struct {
+	Case          string
+	Disagreements []Disagreement
+	// Rejected lists the orders the engine refused; their units hold.
+	Rejected []string
+	// Unread lists the orders the native decoder could not read; their
+	// units hold.
+	Unread []string
+}
+
+// Agrees reports whether both adjudicators left every unit in the same place.
+func (r Report) Agrees() bool {
+	return len(r.Disagreements) == 0
+}
+
+// String describes the report, one disagreement per line, e.g.
+//
+//	6.B.5: 1 disagreement
+//	  F gol (F gol H): native gol, godip gol dislodged
+func (r Report) String() string {
+	var b strings.Builder
+	switch n := len(r.Disagreements); n {
+	case 0:
+		fmt.Fprintf(&b, "%s: agree", r.Case)
+	case 1:
+		fmt.Fprintf(&b, "%s: 1 disagreement", r.Case)
+	default:
+		fmt.Fprintf(&b, "%s: %d disagreements", r.Case, n)
+	}
+	for _, d := range r.Disagreements {
+		unit := d.Unit
+		if d.Order != "" {
+			unit = fmt.Sprintf("%s (%s)", d.Unit, d.Order)
+		}
+		fmt.Fprintf(&b, "\n  %s: native %s, godip %s", unit, d.Native, d.Godip)
+	}
+	for _, text := range r.Rejected {
+		fmt.Fprintf(&b, "\n  rejected by godip: %s", text)
+	}
+	for _, text := range r.Unread {
+		fmt.Fprintf(&b, "\n  unread by native: %s", text)
+	}
+	return b.String()
+}
+
+// Compare adjudicates c with both adjudicators and reports where they
+// disagree. Orders are first rewritten as the engine's canonical text, so
+// both sides read the same orders.
+func Compare(c Case) (Report, error) {
+	eng, err := load(c)
+	if err != nil {
+		return Report{}, fmt.Errorf("crosscheck: %s: %w", c.Name, err)
+	}
+	report := Report{Case: c.Name}
+	orders := make([]Order, 0, len(c.Orders))
+	given := make(map[string]string)
+	for _, o := range c.Orders {
+		text, err := eng.NormalizeOrder(o.Nation, o.Text)
+		if err != nil {
+			text = o.Text
+		}
+		orders = append(orders, Order{Nation: o.Nation, Text: text})
+		if err := eng.SubmitOrder(o.Nation, text); err != nil {
+			report.Rejected = append(report.Rejected, o.Text)
+			continue
+		}
+		given[super(unitProvince(text))] = text
+	}
+
+	data, err := eng.Dump()
+	if err != nil {
+		return Report{}, fmt.Errorf("crosscheck: %s: %w", c.Name, err)
+	}
+	godipOutcomes, err := adjudicate(eng, c)
+	if err != nil {
+		return Report{}, fmt.Errorf("crosscheck: %s: %w", c.Name, err)
+	}
+	nativeOutcomes, unread, err := resolve(data, orders)
+	if err != nil {
+		return Report{}, fmt.Errorf("crosscheck: %s: %w", c.Name, err)
+	}
+	report.Unread = unread
+
+	for _, prov := range provinces(c) {
+		native, other := nativeOutcomes[prov], godipOutcomes[prov]
+		if native == other {
+			continue
+		}
+		report.Disagreements = append(report.Disagreements, Disagreement{
+			Unit:   unitLetter(c.Units[prov].Type) + " " + prov,
+			Order:  given[super(prov)],
+			Native: native,
+			Godip:  other,
+		})
+	}
+	return report, nil
+}
+
+// snapshot is the part of an engine snapshot that sets up a position.
+type snapshot struct {
+	Year      int                           `json:"year"`
+	Season    godip.Season                  `json:"season"`
+	PhaseType godip.PhaseType               `json:"phase_type"`
+	Units     map[godip.Province]godip.Unit `json:"units"`
+}
+
+// load returns a classical engine in Spring 1901 Movement with c's units on
+// the board.
+func load(c Case) (engine.Engine, error) {
+	snap := snapshot{
+		Year:      1901,
+		Season:    godip.Spring,
+		PhaseType: godip.Movement,
+		Units:     make(map[godip.Province]godip.Unit),
+	}
+	for prov, u := range c.Units {
+		snap.Units[godip.Province(prov)] = godip.Unit{Type: godip.UnitType(u.Type), Nation: godip.Nation(u.Nation)}
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return nil, err
+	}
+	return engine.Load(data)
+}
+
+// adjudicate resolves the orders staged on eng and returns the outcome for
+// each unit of c, keyed by the province it started in.
+func adjudicate(eng engine.Engine, c Case) (map[string]Outcome, error) {
+	result, err := eng.Resolve()
+	if err != nil {
+		return nil, err
+	}
+	moved := make(map[string]string)
+	dislodged := make(map[string]bool)
+	for _, or := range result.Orders {
+		if or.Order == string(godip.Move) && or.Success {
+			moved[super(or.Province)] = super(moveDestination(or.Text))
+		}
+		// godip disbands a dislodged unit with nowhere to retreat at
+		// once, so only its order result records that it was dislodged.
+		if or.Outcome == engine.OutcomeDislodged {
+			dislodged[super(or.Province)] = true
+		}
+	}
+	after := make(map[string]string)
+	for prov := range eng.Units() {
+		after[super(prov)] = prov
+	}
+	for prov := range eng.Dislodgeds() {
+		dislodged[super(prov)] = true
+	}
+
+	outcomes := make(map[string]Outcome)
+	for prov := range c.Units {
+		if dst, ok := moved[super(prov)]; ok {
+			outcomes[prov] = Outcome{Province: after[dst]}
+			continue
+		}
+		outcomes[prov] = Outcome{Province: prov, Dislodged: dislodged[super(prov)]}
+	}
+	return outcomes, nil
+}
+
+// resolve adjudicates orders with the native resolver on the position data,
+// an engine snapshot, and returns the outcome for each unit, keyed by the
+// province it started in, along with the orders it could not read.
+func resolve(data []byte, orders []Order) (map[string]Outcome, []string, error) {
+	staged := make(map[string][]string)
+	for _, o := range orders {
+		staged[o.Nation] = append(staged[o.Nation], o.Text)
+	}
+	phase, err := game.LoadSnapshot(data, staged)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	handler := game.OrderHandler{
+		Validator:   order.NewValidator(board.CreateArmyGraph(), board.CreateFleetGraph()),
+		ConvoyGraph: board.CreateConvoyGraph(),
+	}
+	handler.ApplyOrders(phase.Orders, phase.Board)
+	game.ResolveOrders(phase.Board)
+
+	outcomes := make(map[string]Outcome)
+	for prov, u := range phase.Units {
+		outcomes[prov] = Outcome{
+			Province:  string(phase.Board.Position(u).Territory.Godip()),
+			Dislodged: phase.Board.Defeated(u),
+		}
+	}
+	return outcomes, phase.Unread, nil
+}
+
+// unitProvince returns the province of the unit an order is for, e.g.
+// "lon" for "A Lon-Bel".
+func unitProvince(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) < 2 {
+		return ""
+	}
+	return strings.ToLower(strings.SplitN(fields[1], "-", 2)[0])
+}
+
+// moveDestination returns where a move order goes, e.g. "bel" for
+// "A Lon-Bel via convoy".
+func moveDestination(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) < 2 {
+		return ""
+	}
+	parts := strings.SplitN(fields[1], "-", 2)
+	return strings.ToLower(parts[len(parts)-1])
+}
+
+// provinces returns the provinces of c's units in order.
+func provinces(c Case) []string {
+	provs := make([]string, 0, len(c.Units))
+	for prov := range c.Units {
+		provs = append(provs, prov)
+	}
+	sort.Strings(provs)
+	return provs
+}
+
+func super(prov string) string {
+	return string(godip.Province(strings.ToLower(prov)).Super())
+}
+
+func unitLetter(t string) string {
+	if t == string(godip.Fleet) {
+		return "F"
+	}
+	return "A"
+}
@@ -0,0 +1,216 @@
+package crosscheck
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/burrbd/dip/engine"
+	"github.com/cheekybits/is"
+)
+
+func army(nation string) engine.UnitInfo  { return engine.UnitInfo{Type: "Army", Nation: nation} }
+func fleet(nation string) engine.UnitInfo { return engine.UnitInfo{Type: "Fleet", Nation: nation} }
+
+func TestCompare_Agrees(t *testing.T) {
+	is := is.New(t)
+	c := Case{
+		Name:  "supported attack",
+		Units: map[string]engine.UnitInfo{"vie": army("Austria"), "boh": army("Austria"), "gal": army("Russia")},
+		Orders: []Order{
+			{Nation: "Austria", Text: "A Vie-Gal"},
+			{Nation: "Austria", Text: "A Boh S A Vie-Gal"},
+			{Nation: "Russia", Text: "A Gal H"},
+		},
+	}
+	r, err := Compare(c)
+	is.NoErr(err)
+	is.True(r.Agrees())
+	is.Equal(r.String(), "supported attack: agree")
+}
+
+func TestCompare_TranslatesSeaNames(t *testing.T) {
+	is := is.New(t)
+	c := Case{
+		Name:  "convoy through the channel",
+		Units: map[string]engine.UnitInfo{"lon": army("England"), "eng": fleet("England"), "spa/nc": fleet("France")},
+		Orders: []Order{
+			{Nation: "England", Text: "A Lon-Bre via convoy"},
+			{Nation: "England", Text: "F Eng C A Lon-Bre"},
+			{Nation: "France", Text: "F Spa/nc-Mid"},
+		},
+	}
+	r, err := Compare(c)
+	is.NoErr(err)
+	is.True(r.Agrees())
+}
+
+func TestCompare_ReportsDisagreement(t *testing.T) {
+	is := is.New(t)
+	// DATC 6.D.10: a nation may not dislodge its own unit.
+	c := Case{
+		Name:  "6.D.10",
+		Units: map[string]engine.UnitInfo{"ber": army("Germany"), "kie": fleet("Germany"), "mun": army("Germany")},
+		Orders: []Order{
+			{Nation: "Germany", Text: "A Ber H"},
+			{Nation: "Germany", Text: "F Kie-Ber"},
+			{Nation: "Germany", Text: "A Mun S F Kie-Ber"},
+		},
+	}
+	r, err := Compare(c)
+	is.NoErr(err)
+	is.Equal(r.Disagreements, []Disagreement{
+		{Unit: "A ber", Order: "A Ber H", Native: Outcome{Province: "ber", Dislodged: true}, Godip: Outcome{Province: "ber"}},
+		{Unit: "F kie", Order: "F Kie-Ber", Native: Outcome{Province: "ber"}, Godip: Outcome{Province: "kie"}},
+	})
+	is.Equal(r.String(), "6.D.10: 2 disagreements\n"+
+		"  A ber (A Ber H): native ber dislodged, godip ber\n"+
+		"  F kie (F Kie-Ber): native ber, godip kie")
+}
+
+func TestCompare_ReportsRejectedOrders(t *testing.T) {
+	is := is.New(t)
+	c := Case{
+		Name:   "6.A.1",
+		Units:  map[string]engine.UnitInfo{"nth": fleet("England")},
+		Orders: []Order{{Nation: "England", Text: "F nth-pic"}},
+	}
+	r, err := Compare(c)
+	is.NoErr(err)
+	is.True(r.Agrees())
+	is.Equal(r.Rejected, []string{"F nth-pic"})
+	is.Equal(r.String(), "6.A.1: agree\n  rejected by godip: F nth-pic")
+}
+
+func TestCompare_InvalidPosition(t *testing.T) {
+	is := is.New(t)
+	_, err := Compare(Case{Name: "nowhere", Units: map[string]engine.UnitInfo{"xyz": army("England")}})
+	is.Err(err)
+}
+
+func TestParseDATC(t *testing.T) {
+	is := is.New(t)
+	text := `
+VARIANT_ALL Standard
+
+# a movement case
+CASE 6.A.1
+PRESTATE_SETPHASE Fall 1901, Movement
+PRESTATE
+	England: F nth
+	France: A spa	# trailing comment
+ORDERS
+	England: F nth-pic
+POSTSTATE_SAME
+END
+
+CASE 6.H.1
+PRESTATE_SETPHASE Spring 1901, Retreat
+PRESTATE
+	England: F nth
+ORDERS
+	England: F nth-nwy
+END
+`
+	cases, err := ParseDATC(strings.NewReader(text))
+	is.NoErr(err)
+	is.Equal(cases, []Case{{
+		Name:   "6.A.1",
+		Units:  map[string]engine.UnitInfo{"nth": fleet("England"), "spa": army("France")},
+		Orders: []Order{{Nation: "England", Text: "F nth-pic"}},
+	}})
+}
+
+func TestParseDATC_Errors(t *testing.T) {
+	is := is.New(t)
+	for _, text := range []string{
+		"END",
+		"PRESTATE\n\tEngland: F nth",
+		"CASE x\nPRESTATE\n\tEngland F\nEND",
+		"CASE x\nORDERS\n\tF nth-pic\nEND",
+	} {
+		_, err := ParseDATC(strings.NewReader(text))
+		is.Err(err)
+	}
+}
+
+// knownDisagreements are the DATC cases on which the native resolver does
+// not yet agree with godip. Take a case off the list once it agrees.
+var knownDisagreements = map[string]bool{
+	"6.B.2":      true,
+	"6.B.9":      true,
+	"6.B.10":     true,
+	"6.B.11":     true,
+	"6.B.12":     true,
+	"6.D.10":     true,
+	"6.D.11":     true,
+	"6.D.12":     true,
+	"6.D.13":     true,
+	"6.D.17":     true,
+	"6.D.19":     true,
+	"6.D.20":     true,
+	"6.D.32":     true,
+	"6.D.34":     true,
+	"6.E.1":      true,
+	"6.E.2":      true,
+	"6.E.3":      true,
+	"6.E.6":      true,
+	"6.E.7":      true,
+	"6.E.8":      true,
+	"6.E.10":     true,
+	"6.G.10.mod": true,
+}
+
+func TestDATC_NativeAgreesWithGodip(t *testing.T) {
+	cases, err := DATC()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) == 0 {
+		t.Fatal("no DATC cases")
+	}
+	for _, c := range cases {
+		r, err := Compare(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch {
+		case !r.Agrees() && !knownDisagreements[c.Name]:
+			t.Errorf("native resolver disagrees with godip:\n%s", r)
+		case r.Agrees() && knownDisagreements[c.Name]:
+			t.Errorf("%s now agrees; take it off knownDisagreements", c.Name)
+		}
+	}
+}
+
+func TestRandom_SameSeedSameCase(t *testing.T) {
+	is := is.New(t)
+	a, err := Random(rand.New(rand.NewSource(7)), 20)
+	is.NoErr(err)
+	b, err := Random(rand.New(rand.NewSource(7)), 20)
+	is.NoErr(err)
+	is.Equal(a, b)
+	is.Equal(len(a.Units), 20)
+	is.Equal(len(a.Orders), 20)
+}
+
+func TestRandom_Compare(t *testing.T) {
+	for seed := int64(1); seed <= 50; seed++ {
+		c, err := Random(rand.New(rand.NewSource(seed)), 22)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Name = fmt.Sprintf("random seed %d", seed)
+		r, err := Compare(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(r.Rejected) > 0 {
+			t.Errorf("godip rejected its own valid orders:\n%s", r)
+		}
+		if !r.Agrees() {
+			t.Log(r)
+		}
+	}
+}
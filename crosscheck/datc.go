@@ -0,0 +1,105 @@
+package crosscheck
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/burrbd/dip/engine"
+	"github.com/zond/godip"
+)
+
+// datcCatalogue is godip's copy of the DATC test cases, written with godip's
+// province names.
+//
+//go:embed assets/datc_v2.4_06.txt
+var datcCatalogue []byte
+
+// DATC returns the movement cases of the DATC catalogue.
+func DATC() ([]Case, error) {
+	return ParseDATC(bytes.NewReader(datcCatalogue))
+}
+
+// ParseDATC reads test cases written in the DATC file format godip uses:
+// a CASE line, an optional PRESTATE_SETPHASE, the units under PRESTATE and
+// the orders under ORDERS, up to END. Cases set in a retreat or adjustment
+// phase are left out, since the native resolver only adjudicates movement.
+// Expected results are ignored; Compare checks the resolvers against each
+// other, not against the catalogue.
+func ParseDATC(r io.Reader) ([]Case, error) {
+	var (
+		cases    []Case
+		c        *Case
+		section  string
+		movement bool
+	)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		keyword, rest, _ := strings.Cut(line, " ")
+		switch keyword {
+		case "CASE":
+			c = &Case{Name: strings.TrimSpace(rest), Units: make(map[string]engine.UnitInfo)}
+			section, movement = "", true
+			continue
+		case "END":
+			if c == nil {
+				return nil, fmt.Errorf("crosscheck: line %d: END outside a case", n)
+			}
+			if movement {
+				cases = append(cases, *c)
+			}
+			c = nil
+			continue
+		case "PRESTATE_SETPHASE":
+			movement = strings.HasSuffix(strings.ToLower(rest), "movement")
+			continue
+		case "VARIANT_ALL", "PRESTATE", "ORDERS", "PRESTATE_SUPPLYCENTER_OWNERS", "PRESTATE_DISLODGED",
+			"PRESTATE_RESULTS", "POSTSTATE", "POSTSTATE_SAME", "POSTSTATE_DISLODGED":
+			section = keyword
+			continue
+		}
+		if c == nil {
+			return nil, fmt.Errorf("crosscheck: line %d: %q outside a case", n, line)
+		}
+		switch section {
+		case "PRESTATE":
+			nation, unit, ok := strings.Cut(line, ":")
+			fields := strings.Fields(unit)
+			if !ok || len(fields) != 2 {
+				return nil, fmt.Errorf("crosscheck: line %d: malformed unit %q", n, line)
+			}
+			c.Units[strings.ToLower(fields[1])] = engine.UnitInfo{
+				Type:   string(datcUnitType(fields[0])),
+				Nation: strings.TrimSpace(nation),
+			}
+		case "ORDERS":
+			nation, text, ok := strings.Cut(line, ":")
+			if !ok {
+				return nil, fmt.Errorf("crosscheck: line %d: malformed order %q", n, line)
+			}
+			c.Orders = append(c.Orders, Order{Nation: strings.TrimSpace(nation), Text: strings.TrimSpace(text)})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("crosscheck: %w", err)
+	}
+	return cases, nil
+}
+
+func datcUnitType(letter string) godip.UnitType {
+	if strings.EqualFold(letter, "F") {
+		return godip.Fleet
+	}
+	return godip.Army
+}
@@ -0,0 +1,80 @@
+package crosscheck
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/burrbd/dip/engine"
+	"github.com/zond/godip"
+	"github.com/zond/godip/variants/classical"
+)
+
+// Random places up to units units on the classical board, at most one per
+// province and each owned by a random nation, and gives every unit one of
+// its legal orders at random. The same r gives the same case.
+func Random(r *rand.Rand, units int) (Case, error) {
+	g := classical.ClassicalVariant.Graph()
+	nations := classical.ClassicalVariant.Nations
+
+	var provs []godip.Province
+	for _, p := range g.Provinces() {
+		if p == p.Super() {
+			provs = append(provs, p)
+		}
+	}
+	sort.Slice(provs, func(i, j int) bool { return provs[i] < provs[j] })
+	r.Shuffle(len(provs), func(i, j int) { provs[i], provs[j] = provs[j], provs[i] })
+	if units > len(provs) {
+		units = len(provs)
+	}
+
+	c := Case{Name: "random", Units: make(map[string]engine.UnitInfo)}
+	for _, p := range provs[:units] {
+		nation := string(nations[r.Intn(len(nations))])
+		land := g.Flags(p)[godip.Land]
+		sea := g.Flags(p)[godip.Sea] || len(g.Coasts(p)) > 1
+		switch {
+		case land && (!sea || r.Intn(2) == 0):
+			c.Units[string(p)] = engine.UnitInfo{Type: string(godip.Army), Nation: nation}
+		default:
+			c.Units[string(fleetProvince(r, g, p))] = engine.UnitInfo{Type: string(godip.Fleet), Nation: nation}
+		}
+	}
+
+	eng, err := load(c)
+	if err != nil {
+		return Case{}, fmt.Errorf("crosscheck: random: %w", err)
+	}
+	for _, nation := range nations {
+		options := make(map[string][]string)
+		for _, text := range eng.ValidOrders(string(nation)) {
+			prov := super(unitProvince(text))
+			options[prov] = append(options[prov], text)
+		}
+		for _, prov := range provinces(c) {
+			texts := options[super(prov)]
+			if c.Units[prov].Nation != string(nation) || len(texts) == 0 {
+				continue
+			}
+			c.Orders = append(c.Orders, Order{Nation: string(nation), Text: texts[r.Intn(len(texts))]})
+		}
+	}
+	return c, nil
+}
+
+// fleetProvince returns where a fleet in p stands: p itself, or one of its
+// coasts at random if it has more than one.
+func fleetProvince(r *rand.Rand, g godip.Graph, p godip.Province) godip.Province {
+	var coasts []godip.Province
+	for _, coast := range g.Coasts(p) {
+		if coast != p {
+			coasts = append(coasts, coast)
+		}
+	}
+	if len(coasts) == 0 {
+		return p
+	}
+	sort.Slice(coasts, func(i, j int) bool { return coasts[i] < coasts[j] })
+	return coasts[r.Intn(len(coasts))]
+}
@@ -6,10 +6,12 @@ package engine
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/zond/godip"
+	"github.com/zond/godip/phase"
 	"github.com/zond/godip/state"
-	"github.com/zond/godip/variants/classical"
 	"github.com/zond/godip/variants/common"
 )
 
@@ -36,51 +38,89 @@ type gameState interface {
 	Dislodgeds() map[godip.Province]godip.Unit
 	SupplyCenters() map[godip.Province]godip.Nation
 	SetOrder(godip.Province, adjOrder)
+	// RemoveOrder drops the order staged for a province, if any.
+	RemoveOrder(godip.Province)
+	// Validate runs godip's legality check for an order against the current
+	// position and returns the nation that owns the ordered unit.
+	Validate(adjOrder) (godip.Nation, error)
+	// Options returns godip's tree of legal orders for nation.
+	Options(godip.Nation) godip.Options
 	Resolve(godip.Province) error
+	// CivilDisorder returns nation's units in the order the civil-disorder
+	// rule removes them: farthest from a home supply centre first.
+	CivilDisorder(godip.Nation) ([]godip.Province, error)
+	// Resolutions returns the per-province adjudication errors recorded by
+	// the most recent Next(); a nil entry means the order succeeded.
+	Resolutions() map[godip.Province]error
 	Next() (gameState, error)
 	SoloWinner() godip.Nation
 	Dump() ([]byte, error)
 }
 
-// Load restores an Engine from a JSON snapshot produced by Dump.
+// Load restores an Engine from a JSON snapshot produced by Dump. The variant
+// recorded in the snapshot is looked up in the registry; snapshots written
+// before variants were recorded load as classical.
 func Load(snapshot []byte) (Engine, error) {
-	return loadFromSnapshot(snapshot, classicalLoader)
+	name := snapshotVariant(snapshot)
+	v, err := lookupVariant(name)
+	if err != nil {
+		return nil, fmt.Errorf("engine: load snapshot: %w", err)
+	}
+	eng, err := loadFromSnapshot(snapshot, variantLoader(v), name, parserFor(v))
+	if err != nil {
+		return nil, err
+	}
+	eng.(*game).norm = newOrderNormalizer(v)
+	return eng, nil
+}
+
+// snapshotVariant returns the registry name of the variant recorded in
+// snapshot, or DefaultVariant if none is recorded.
+func snapshotVariant(snapshot []byte) string {
+	var snap stateSnapshot
+	if err := json.Unmarshal(snapshot, &snap); err != nil || snap.Variant == "" {
+		return DefaultVariant
+	}
+	return strings.ToLower(snap.Variant)
 }
 
 // loadFromSnapshot restores an Engine using loader to deserialise the snapshot.
 // Separated from Load so tests can inject a failing loader.
-func loadFromSnapshot(snapshot []byte, loader func([]byte) (gameState, error)) (Engine, error) {
+func loadFromSnapshot(snapshot []byte, loader func([]byte) (gameState, error), name string, p orderParser) (Engine, error) {
 	gs, err := loader(snapshot)
 	if err != nil {
 		return nil, fmt.Errorf("engine: load snapshot: %w", err)
 	}
-	return &game{adj: gs, parser: &classicalOrderParser{}}, nil
+	return &game{adj: gs, parser: p, variant: name}, nil
 }
 
-// classicalLoader deserialises a JSON snapshot into a classical game state.
-func classicalLoader(snapshot []byte) (gameState, error) {
-	return classicalLoaderWith(snapshot, classical.Start)
+// variantLoader returns a function that deserialises a JSON snapshot into a
+// game state for v.
+func variantLoader(v common.Variant) func([]byte) (gameState, error) {
+	return func(snapshot []byte) (gameState, error) {
+		return variantLoaderWith(v, snapshot)
+	}
 }
 
-// classicalLoaderWith is the testable core of classicalLoader.
-// startFn is injected so tests can simulate errors from classical.Start.
-func classicalLoaderWith(snapshot []byte, startFn func() (*state.State, error)) (gameState, error) {
+// variantLoaderWith is the core of variantLoader. Snapshots that do not parse,
+// have no phase, or name provinces or nations the variant does not have are
+// rejected with ErrInvalidSnapshot rather than replaced by a fresh game.
+func variantLoaderWith(v common.Variant, snapshot []byte) (gameState, error) {
 	var snap stateSnapshot
-	if err := json.Unmarshal(snapshot, &snap); err != nil || snap.Year == 0 {
-		// Empty or unparseable snapshot: start a fresh classical game.
-		st, err := startFn()
-		if err != nil {
-			return nil, err
-		}
-		return newStateWrapper(st, classical.ClassicalVariant), nil
+	if err := json.Unmarshal(snapshot, &snap); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if err := snap.validate(v); err != nil {
+		return nil, err
 	}
-	ph := classical.NewPhase(snap.Year, snap.Season, snap.PhaseType)
-	return buildStateFromSnapshot(classical.Blank(ph), &snap)
+	ph := v.Phase(snap.Year, snap.Season, snap.PhaseType)
+	return buildStateFromSnapshot(v.Blank(ph), &snap, v)
 }
 
 // buildStateFromSnapshot loads units, supply centres, and dislodgeds from snap
-// into st. Extracted so tests can trigger SetUnits/SetDislodgeds error paths.
-func buildStateFromSnapshot(st *state.State, snap *stateSnapshot) (gameState, error) {
+// into st, followed by the adjudication bookkeeping schema 2 snapshots carry.
+// Extracted so tests can trigger SetUnits/SetDislodgeds error paths.
+func buildStateFromSnapshot(st *state.State, snap *stateSnapshot, v common.Variant) (gameState, error) {
 	if len(snap.Units) > 0 {
 		if err := st.SetUnits(snap.Units); err != nil {
 			return nil, fmt.Errorf("engine: set units: %w", err)
@@ -94,7 +134,13 @@ func buildStateFromSnapshot(st *state.State, snap *stateSnapshot) (gameState, er
 			return nil, fmt.Errorf("engine: set dislodgeds: %w", err)
 		}
 	}
-	return newStateWrapper(st, classical.ClassicalVariant), nil
+	resolutions, err := restoreState(st, snap, parserFor(v))
+	if err != nil {
+		return nil, err
+	}
+	w := newStateWrapper(st, v)
+	w.resolutions = resolutions
+	return w, nil
 }
 
 // UnitInfo holds the type and owning nation of a unit on the board.
@@ -106,13 +152,29 @@ type UnitInfo struct {
 // Engine is the public interface for interacting with a running Diplomacy game.
 // All methods operate on the current game phase.
 type Engine interface {
-	// SubmitOrder parses orderText and stages it for nation's unit.
+	// SubmitOrder parses orderText and stages it for nation's unit,
+	// replacing any order already staged for that unit. Orders for another
+	// nation's unit, for an empty province, or that are illegal in the
+	// current phase are rejected with an *OrderError. orderText may be
+	// written loosely; see NormalizeOrder.
 	SubmitOrder(nation, orderText string) error
+	// NormalizeOrder rewrites loosely written order text — full or misspelt
+	// province names, arrows, words such as "supports", coasts like
+	// "StP(nc)", missing unit types — as the canonical text SubmitOrder
+	// stages, e.g. "army vienna -> budapest" becomes "A Vie-Bud".
+	NormalizeOrder(nation, orderText string) (string, error)
+	// NormalizeProvince returns the lower-case province, without coast,
+	// that name refers to — an abbreviation, a full or misspelt name or an
+	// alias — e.g. "vienna" becomes "vie".
+	NormalizeProvince(name string) (string, error)
+	// ClearOrders withdraws every order nation has staged this phase.
+	ClearOrders(nation string)
 	// Resolve adjudicates all staged orders and returns a summary of outcomes.
 	Resolve() (ResolutionResult, error)
 	// Advance fills any missing (NMR) orders, calls godip Next(), and skips
-	// empty retreat or adjustment phases.
-	Advance() error
+	// empty retreat or adjustment phases. It returns the orders it gave on
+	// each nation's behalf, keyed by nation as ResolutionResult.NMR is.
+	Advance() (map[string][]string, error)
 	// SoloWinner returns the nation that has achieved a solo victory, or ""
 	// if no solo winner exists yet.
 	SoloWinner() string
@@ -127,8 +189,30 @@ type Engine interface {
 	Dislodgeds() map[string]string
 	// SupplyCenters returns the number of supply centers owned by each nation.
 	SupplyCenters() map[string]int
+	// SupplyCenterOwners returns the owning nation of each owned supply
+	// center, keyed by province name.
+	SupplyCenterOwners() map[string]string
+	// Adjacent reports whether a unit of unitType ("Army" or "Fleet") at src
+	// can move to dst without a convoy.
+	Adjacent(unitType, src, dst string) bool
 	// Units returns all units on the board keyed by province name.
 	Units() map[string]UnitInfo
+	// ValidOrders returns every legal order for nation in the current phase
+	// as canonical order text, sorted.
+	ValidOrders(nation string) []string
+	// Variant returns the registry name of the game's variant, e.g. "classical".
+	Variant() string
+	// Clone returns an independent copy of the game, including staged
+	// orders. Nothing done to the copy affects the original.
+	Clone() (Engine, error)
+	// Preview adjudicates a hypothetical order set on a copy of the current
+	// position and returns the outcome with the copy after adjudication.
+	// Orders staged in the real game are ignored, each order is credited to
+	// the nation owning the ordered unit, and units without an order hold.
+	Preview(orders []string) (ResolutionResult, Engine, error)
+	// SetNMRPolicy chooses how orders are filled in for nations that sent
+	// none. Games use DefaultNMRPolicy until it is called.
+	SetNMRPolicy(NMRPolicy)
 }
 
 // ResolutionResult summarises what happened when a phase was adjudicated.
@@ -136,29 +220,65 @@ type ResolutionResult struct {
 	Phase  string
 	Year   int
 	Orders []OrderResult
+	// NMR lists the orders the engine gave under its NMRPolicy, keyed by
+	// nation and sorted.
+	NMR map[string][]string `json:",omitempty"`
 }
 
 // OrderResult represents the outcome of a single order after adjudication.
 type OrderResult struct {
 	Province string
-	Order    string
-	Success  bool
+	// Order is the order type, e.g. "Move" or "Support".
+	Order   string
+	Success bool
+	// Nation is the nation that gave the order.
+	Nation string
+	// Text is the order as the player submitted it.
+	Text    string
+	Outcome Outcome
+	// By names the province responsible for a failure where one is known:
+	// the unit bounced against, the attacker that cut a support or
+	// dislodged the unit, or the dislodged convoying fleet.
+	By string
+	// Reason says in plain words why a failed order failed, e.g. "the unit
+	// cannot move there".
+	Reason string
+}
+
+// stagedOrder records who submitted an order and the text they used.
+type stagedOrder struct {
+	nation godip.Nation
+	text   string
 }
 
 // game implements Engine around a gameState.
 type game struct {
 	adj      gameState
 	parser   orderParser
-	advanced bool // true after Resolve() has called Next(); tells Advance() to skip Next()
+	norm     *orderNormalizer               // rewrites loose order text; nil accepts canonical text only
+	variant  string                         // registry name of the variant, e.g. "classical"
+	nmr      NMRPolicy                      // how missing orders are filled
+	staged   map[godip.Province]stagedOrder // submitter and text of orders staged this phase
+	advanced bool                           // true after Resolve() has called Next(); tells Advance() to skip Next()
+	graph    godip.Graph                    // the variant's map, built on the first Adjacent call
 }
 
-// New creates an Engine for the named Diplomacy variant (currently "classical").
+// New creates an Engine for the named Diplomacy variant. Any variant added
+// with RegisterVariant may be used; "classical" is always available.
 func New(variant string) (Engine, error) {
-	start, err := lookupVariantStart(variant)
+	v, err := lookupVariant(variant)
+	if err != nil {
+		return nil, err
+	}
+	start := func() (gameState, error) {
+		return variantStartWith(v, v.Start)
+	}
+	eng, err := newFromVariantStart(start, strings.ToLower(variant), parserFor(v))
 	if err != nil {
 		return nil, err
 	}
-	return newFromVariantStart(start, variant, &classicalOrderParser{})
+	eng.(*game).norm = newOrderNormalizer(v)
+	return eng, nil
 }
 
 // newFromVariantStart starts a game from the given start function and parser.
@@ -168,44 +288,65 @@ func newFromVariantStart(start func() (gameState, error), name string, p orderPa
 	if err != nil {
 		return nil, fmt.Errorf("engine: start %s: %w", name, err)
 	}
-	return &game{adj: gs, parser: p}, nil
-}
-
-// lookupVariantStart returns the start function for the given variant name.
-func lookupVariantStart(name string) (func() (gameState, error), error) {
-	switch name {
-	case "classical":
-		return func() (gameState, error) {
-			return classicalStartWith(classical.Start)
-		}, nil
-	default:
-		return nil, fmt.Errorf("engine: unknown variant %q", name)
-	}
+	return &game{adj: gs, parser: p, variant: name}, nil
 }
 
-// classicalStartWith is the testable core of the classical start function.
-// startFn is injected so tests can simulate errors from classical.Start.
-func classicalStartWith(startFn func() (*state.State, error)) (gameState, error) {
+// variantStartWith is the testable core of starting a variant.
+// startFn is injected so tests can simulate errors from the variant's Start.
+func variantStartWith(v common.Variant, startFn func() (*state.State, error)) (gameState, error) {
 	st, err := startFn()
 	if err != nil {
 		return nil, err
 	}
-	return newStateWrapper(st, classical.ClassicalVariant), nil
+	return newStateWrapper(st, v), nil
 }
 
-// SubmitOrder parses the order text and stages it on the game state.
+// SubmitOrder normalises and parses the order text, checks that nation may
+// give it, and stages it on the game state.
 func (g *game) SubmitOrder(nation, orderText string) error {
+	orderText, err := g.NormalizeOrder(nation, orderText)
+	if err != nil {
+		return err
+	}
 	prov, order, err := g.parser.Parse(godip.Nation(nation), orderText)
 	if err != nil {
 		return fmt.Errorf("engine: parse order: %w", err)
 	}
+	if err := g.checkOrder(godip.Nation(nation), prov, order); err != nil {
+		return fmt.Errorf("engine: %w", err)
+	}
+	// One order per unit: a later order for the same unit replaces the
+	// earlier one.
+	for p := range g.adj.Orders() {
+		if p.Super() == prov.Super() {
+			g.adj.RemoveOrder(p)
+			delete(g.staged, p)
+		}
+	}
 	g.adj.SetOrder(prov, order)
+	if g.staged == nil {
+		g.staged = make(map[godip.Province]stagedOrder)
+	}
+	g.staged[prov] = stagedOrder{nation: godip.Nation(nation), text: orderText}
 	return nil
 }
 
+// ClearOrders withdraws every order nation has staged this phase, so a
+// replacement set can be submitted in its place.
+func (g *game) ClearOrders(nation string) {
+	for prov, s := range g.staged {
+		if s.nation == godip.Nation(nation) {
+			g.adj.RemoveOrder(prov)
+			delete(g.staged, prov)
+		}
+	}
+}
+
 // Resolve adjudicates all staged orders and returns a summary of outcomes.
-// It fills NMR orders, calls godip Next() to adjudicate, and compares unit
-// positions before and after to determine per-order success.
+// It fills NMR orders, calls godip Next() to adjudicate, and reports each
+// staged order with godip's resolution: bounced, support cut, dislodged,
+// void or convoy disrupted. Orders without a recorded resolution fall back
+// to comparing unit positions before and after.
 func (g *game) Resolve() (ResolutionResult, error) {
 	phase := g.adj.Phase()
 	result := ResolutionResult{
@@ -214,11 +355,13 @@ func (g *game) Resolve() (ResolutionResult, error) {
 	}
 
 	// Snapshot player orders and unit positions before NMR fill and Next().
-	stagedOrders := g.adj.Orders()
-	preUnits := g.adj.Units()
+	stagedOrders := copyOrders(g.adj.Orders())
+	preUnits := copyUnits(g.adj.Units())
+	preDislodgeds := copyUnits(g.adj.Dislodgeds())
 
 	// Fill NMR so all units have orders before adjudication.
-	fillNMR(g.adj)
+	result.NMR = fillNMR(g.adj, g.nmr).byNation()
+	applied := copyOrders(g.adj.Orders())
 
 	// Advance the state — this is where godip adjudicates all orders.
 	next, err := g.adj.Next()
@@ -229,17 +372,67 @@ func (g *game) Resolve() (ResolutionResult, error) {
 	g.advanced = true
 
 	postUnits := g.adj.Units()
+	resolutions := g.adj.Resolutions()
 
-	for prov, ord := range stagedOrders {
-		result.Orders = append(result.Orders, OrderResult{
+	provs := make([]godip.Province, 0, len(stagedOrders))
+	for prov := range stagedOrders {
+		provs = append(provs, prov)
+	}
+	sort.Slice(provs, func(i, j int) bool { return provs[i] < provs[j] })
+
+	for _, prov := range provs {
+		ord := stagedOrders[prov]
+		or := OrderResult{
 			Province: string(prov),
 			Order:    string(ord.Type()),
-			Success:  moveSucceeded(ord, prov, preUnits, postUnits),
-		})
+			Nation:   g.orderNation(prov, preUnits, preDislodgeds),
+			Text:     g.orderText(prov, ord),
+		}
+		resErr, resolved := resolutions[prov]
+		switch {
+		case !resolved:
+			or.Success = moveSucceeded(ord, prov, preUnits, postUnits)
+			or.Outcome = OutcomeSucceeded
+			if !or.Success {
+				or.Outcome = OutcomeFailed
+			}
+		case resErr != nil:
+			or.Outcome, or.By = classifyResolution(resErr)
+			or.Reason = resolutionReason(resErr)
+		default:
+			or.Success = true
+			or.Outcome = OutcomeSucceeded
+		}
+		// A unit that stayed put can still be dislodged by a successful attack.
+		if phase.Type() == godip.Movement && !(or.Success && ord.Type() == godip.Move) {
+			if by := dislodgerOf(prov, applied, resolutions); by != "" {
+				or.Success = false
+				or.Outcome = OutcomeDislodged
+				or.By = string(by)
+			}
+		}
+		result.Orders = append(result.Orders, or)
 	}
+	g.staged = nil
 	return result, nil
 }
 
+func copyOrders(in map[godip.Province]adjOrder) map[godip.Province]adjOrder {
+	out := make(map[godip.Province]adjOrder, len(in))
+	for p, o := range in {
+		out[p] = o
+	}
+	return out
+}
+
+func copyUnits(in map[godip.Province]godip.Unit) map[godip.Province]godip.Unit {
+	out := make(map[godip.Province]godip.Unit, len(in))
+	for p, u := range in {
+		out[p] = u
+	}
+	return out
+}
+
 // moveSucceeded reports whether an order succeeded. For Move orders it checks
 // whether the unit arrived at its destination; all other order types return true.
 func moveSucceeded(ord adjOrder, src godip.Province, pre, post map[godip.Province]godip.Unit) bool {
@@ -265,6 +458,11 @@ func moveSucceeded(ord adjOrder, src godip.Province, pre, post map[godip.Provinc
 	return false
 }
 
+// Variant returns the registry name of the game's variant.
+func (g *game) Variant() string {
+	return g.variant
+}
+
 // Dump serialises the current game state to JSON.
 func (g *game) Dump() ([]byte, error) {
 	return g.adj.Dump()
@@ -299,6 +497,44 @@ func (g *game) SupplyCenters() map[string]int {
 	return result
 }
 
+// SupplyCenterOwners returns the owning nation of each owned supply center,
+// keyed by province name.
+func (g *game) SupplyCenterOwners() map[string]string {
+	result := make(map[string]string)
+	for prov, nation := range g.adj.SupplyCenters() {
+		result[string(prov)] = string(nation)
+	}
+	return result
+}
+
+// Adjacent reports whether a unit of unitType at src borders dst over land
+// (armies) or sea (fleets) on the variant's map. A dst without a coast
+// matches any of its coasts.
+func (g *game) Adjacent(unitType, src, dst string) bool {
+	if g.graph == nil {
+		name := g.variant
+		if name == "" {
+			name = DefaultVariant
+		}
+		v, err := lookupVariant(name)
+		if err != nil {
+			return false
+		}
+		g.graph = v.Graph()
+	}
+	flag := godip.Land
+	if unitType == string(godip.Fleet) {
+		flag = godip.Sea
+	}
+	to := godip.Province(strings.ToLower(dst))
+	for p, flags := range g.graph.Edges(godip.Province(strings.ToLower(src)), false) {
+		if flags[flag] && (p == to || to == to.Super() && p.Super() == to) {
+			return true
+		}
+	}
+	return false
+}
+
 // Units returns all units on the board keyed by province name.
 func (g *game) Units() map[string]UnitInfo {
 	result := make(map[string]UnitInfo)
@@ -313,20 +549,12 @@ func (g *game) Units() map[string]UnitInfo {
 
 // ---- stateWrapper: adapts *state.State to gameState -------------------------
 
-// stateSnapshot is the JSON format used to persist and restore game state.
-type stateSnapshot struct {
-	Year          int                             `json:"year"`
-	Season        godip.Season                    `json:"season"`
-	PhaseType     godip.PhaseType                 `json:"phase_type"`
-	Units         map[godip.Province]godip.Unit   `json:"units"`
-	SupplyCenters map[godip.Province]godip.Nation `json:"supply_centers"`
-	Dislodgeds    map[godip.Province]godip.Unit   `json:"dislodgeds"`
-}
-
 // stateWrapper wraps *state.State to implement gameState.
 type stateWrapper struct {
 	st      *state.State
 	variant common.Variant
+	// resolutions restored from a snapshot, reported until the next Next().
+	resolutions map[godip.Province]error
 }
 
 func newStateWrapper(st *state.State, v common.Variant) *stateWrapper {
@@ -369,12 +597,54 @@ func (w *stateWrapper) SetOrder(p godip.Province, o adjOrder) {
 	// Stub orders from tests (not godip.Adjudicator) are silently ignored.
 }
 
+func (w *stateWrapper) RemoveOrder(p godip.Province) {
+	orders := make(map[godip.Province]godip.Adjudicator)
+	for prov, o := range w.st.Orders() {
+		if prov != p {
+			orders[prov] = o
+		}
+	}
+	w.st.SetOrders(orders)
+}
+
+func (w *stateWrapper) Validate(o adjOrder) (godip.Nation, error) {
+	// Stub orders cannot be validated; SetOrder ignores them anyway.
+	order, ok := o.(godip.Order)
+	if !ok {
+		return "", nil
+	}
+	return order.Validate(w.st)
+}
+
+func (w *stateWrapper) Options(n godip.Nation) godip.Options {
+	return w.st.Options(w.variant.Parser.Orders(), n)
+}
+
 func (w *stateWrapper) Resolve(_ godip.Province) error {
 	// Real adjudication is performed inside Next(). This is a no-op stub
 	// so that engine.Resolve() can gather order summaries before advancing.
 	return nil
 }
 
+func (w *stateWrapper) CivilDisorder(n godip.Nation) ([]godip.Province, error) {
+	return phase.SortedUnits(unresolvedState{w.st}, n)
+}
+
+// unresolvedState lets a *state.State outside adjudication be passed where
+// godip wants a godip.State; only the resolver has a working Resolve.
+type unresolvedState struct{ *state.State }
+
+func (unresolvedState) Resolve(p godip.Province) error {
+	return fmt.Errorf("engine: resolve %s outside adjudication", p)
+}
+
+func (w *stateWrapper) Resolutions() map[godip.Province]error {
+	if w.resolutions != nil {
+		return w.resolutions
+	}
+	return w.st.Resolutions()
+}
+
 func (w *stateWrapper) Next() (gameState, error) {
 	return w.nextWith(w.st.Next)
 }
@@ -385,6 +655,7 @@ func (w *stateWrapper) nextWith(advance func() error) (gameState, error) {
 	if err := advance(); err != nil {
 		return nil, err
 	}
+	w.resolutions = nil
 	return w, nil // *state.State is mutated in-place by Next()
 }
 
@@ -396,14 +667,9 @@ func (w *stateWrapper) SoloWinner() godip.Nation {
 }
 
 func (w *stateWrapper) Dump() ([]byte, error) {
-	ph := w.st.Phase()
-	snap := stateSnapshot{
-		Year:          ph.Year(),
-		Season:        ph.Season(),
-		PhaseType:     ph.Type(),
-		Units:         w.st.Units(),
-		SupplyCenters: w.st.SupplyCenters(),
-		Dislodgeds:    w.st.Dislodgeds(),
+	snap, err := w.snapshot()
+	if err != nil {
+		return nil, err
 	}
 	return json.Marshal(snap)
 }
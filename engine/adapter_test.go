@@ -2,6 +2,7 @@ package engine
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -22,9 +23,13 @@ type mockAdj struct {
 	nextAdj       gameState
 	nextErr       error
 	resolveErr    map[godip.Province]error
+	resolutions   map[godip.Province]error
+	validateErr   error
+	options       godip.Options
 	setOrders     map[godip.Province]adjOrder
 	dumpData      []byte
 	dumpErr       error
+	civilDisorder map[godip.Nation][]godip.Province
 }
 
 func newMockAdj() *mockAdj {
@@ -35,13 +40,19 @@ func newMockAdj() *mockAdj {
 	}
 }
 
-func (m *mockAdj) Phase() gamePhase                            { return m.phase }
-func (m *mockAdj) Orders() map[godip.Province]adjOrder         { return m.orders }
-func (m *mockAdj) Units() map[godip.Province]godip.Unit        { return m.units }
-func (m *mockAdj) Dislodgeds() map[godip.Province]godip.Unit   { return m.dislodgeds }
-func (m *mockAdj) SoloWinner() godip.Nation                    { return m.winner }
-func (m *mockAdj) Dump() ([]byte, error)                       { return m.dumpData, m.dumpErr }
-func (m *mockAdj) Next() (gameState, error)                    { return m.nextAdj, m.nextErr }
+func (m *mockAdj) Phase() gamePhase                          { return m.phase }
+func (m *mockAdj) Orders() map[godip.Province]adjOrder       { return m.orders }
+func (m *mockAdj) Units() map[godip.Province]godip.Unit      { return m.units }
+func (m *mockAdj) Dislodgeds() map[godip.Province]godip.Unit { return m.dislodgeds }
+func (m *mockAdj) SoloWinner() godip.Nation                  { return m.winner }
+func (m *mockAdj) Dump() ([]byte, error)                     { return m.dumpData, m.dumpErr }
+func (m *mockAdj) Next() (gameState, error)                  { return m.nextAdj, m.nextErr }
+func (m *mockAdj) Resolutions() map[godip.Province]error     { return m.resolutions }
+func (m *mockAdj) Options(godip.Nation) godip.Options        { return m.options }
+func (m *mockAdj) Validate(adjOrder) (godip.Nation, error)   { return "", m.validateErr }
+func (m *mockAdj) CivilDisorder(n godip.Nation) ([]godip.Province, error) {
+	return m.civilDisorder[n], nil
+}
 func (m *mockAdj) SupplyCenters() map[godip.Province]godip.Nation {
 	if m.supplyCenters == nil {
 		return make(map[godip.Province]godip.Nation)
@@ -54,6 +65,10 @@ func (m *mockAdj) SetOrder(p godip.Province, o adjOrder) {
 	m.orders[p] = o
 }
 
+func (m *mockAdj) RemoveOrder(p godip.Province) {
+	delete(m.orders, p)
+}
+
 func (m *mockAdj) Resolve(p godip.Province) error {
 	if m.resolveErr != nil {
 		return m.resolveErr[p]
@@ -137,7 +152,9 @@ func TestNew_UnknownVariant(t *testing.T) {
 func TestSubmitOrder_Stages(t *testing.T) {
 	is := is.New(t)
 	adj := newMockAdj()
-	parser := &mockParser{prov: "Vie", order: &stubOrder{t: "A Vie-Bud"}}
+	adj.phase = &mockPhase{typ: godip.Movement, year: 1901, season: godip.Spring}
+	adj.units["Vie"] = godip.Unit{Type: godip.Army, Nation: "Austria"}
+	parser := &mockParser{prov: "Vie", order: &stubOrder{t: "Move"}}
 	g := &game{adj: adj, parser: parser}
 
 	err := g.SubmitOrder("Austria", "A Vie-Bud")
@@ -156,6 +173,45 @@ func TestSubmitOrder_ParseError(t *testing.T) {
 	is.Equal(len(adj.setOrders), 0)
 }
 
+func TestSubmitOrder_RejectsUnownedUnit(t *testing.T) {
+	is := is.New(t)
+	adj := newMockAdj()
+	adj.phase = &mockPhase{typ: godip.Movement, year: 1901, season: godip.Spring}
+	adj.units["vie"] = godip.Unit{Type: godip.Army, Nation: "Austria"}
+	g := &game{adj: adj, parser: &mockParser{prov: "vie", order: &stubOrder{t: "Move"}}}
+
+	err := g.SubmitOrder("England", "A Vie-Bud")
+	is.True(errors.Is(err, ErrNotOwned))
+	is.Equal(len(adj.setOrders), 0)
+}
+
+func TestSubmitOrder_RejectsInvalidOrder(t *testing.T) {
+	is := is.New(t)
+	adj := newMockAdj()
+	adj.phase = &mockPhase{typ: godip.Movement, year: 1901, season: godip.Spring}
+	adj.units["vie"] = godip.Unit{Type: godip.Army, Nation: "Austria"}
+	adj.validateErr = godip.ErrIllegalMove
+	g := &game{adj: adj, parser: &mockParser{prov: "vie", order: &stubOrder{t: "Move"}}}
+
+	err := g.SubmitOrder("Austria", "A Vie-Lon")
+	is.True(errors.Is(err, ErrIllegalOrder))
+	is.Equal(len(adj.setOrders), 0)
+}
+
+func TestSubmitOrder_RetreatChecksDislodgedOwner(t *testing.T) {
+	is := is.New(t)
+	adj := newMockAdj()
+	adj.phase = &mockPhase{typ: godip.Retreat, year: 1901, season: godip.Spring}
+	// Germany's attacker now occupies bur; the dislodged French army is ordered.
+	adj.units["bur"] = godip.Unit{Type: godip.Army, Nation: "Germany"}
+	adj.dislodgeds = map[godip.Province]godip.Unit{"bur": {Type: godip.Army, Nation: "France"}}
+	g := &game{adj: adj, parser: &mockParser{prov: "bur", order: &stubOrder{t: "Move"}}}
+
+	is.NoErr(g.SubmitOrder("France", "A Bur-Pic"))
+	err := g.SubmitOrder("Germany", "A Bur-Pic")
+	is.True(errors.Is(err, ErrNotOwned))
+}
+
 func TestResolve_ReturnsSummary(t *testing.T) {
 	is := is.New(t)
 	order := &stubOrder{t: "A Vie-Bud"}
@@ -210,7 +266,7 @@ func TestAdvance_CallsNext(t *testing.T) {
 	adj.nextAdj = nextAdj
 
 	g := &game{adj: adj, parser: &mockParser{}}
-	err := g.Advance()
+	_, err := g.Advance()
 
 	is.NoErr(err)
 	is.Equal(g.adj, gameState(nextAdj))
@@ -227,12 +283,32 @@ func TestAdvance_FillsNMR(t *testing.T) {
 	adj.nextAdj = nextAdj
 
 	g := &game{adj: adj, parser: &mockParser{}}
-	err := g.Advance()
+	nmr, err := g.Advance()
 
 	is.NoErr(err)
 	// Vienna had no order, so a default order must have been staged.
 	_, filled := adj.setOrders["Vie"]
 	is.Equal(filled, true)
+	is.Equal(nmr, map[string][]string{"Austria": {"Vie Hold"}})
+}
+
+// TestAdvance_ReportsNMRWhileSkipping covers the orders given in an empty
+// phase after Resolve has already advanced the game.
+func TestAdvance_ReportsNMRWhileSkipping(t *testing.T) {
+	is := is.New(t)
+	retreatAdj := newMockAdj()
+	retreatAdj.phase = &mockPhase{typ: godip.Retreat, year: 1901, season: godip.Spring}
+	retreatAdj.units["Vie"] = godip.Unit{Type: godip.Army, Nation: "Austria"}
+	fallAdj := newMockAdj()
+	fallAdj.phase = &mockPhase{typ: godip.Movement, year: 1901, season: godip.Fall}
+	retreatAdj.nextAdj = fallAdj
+
+	g := &game{adj: retreatAdj, parser: &mockParser{}, advanced: true}
+	nmr, err := g.Advance()
+
+	is.NoErr(err)
+	is.Equal(g.adj, gameState(fallAdj))
+	is.Equal(nmr, map[string][]string{"Austria": {"Vie Hold"}})
 }
 
 func TestAdvance_SkipsEmptyRetreat(t *testing.T) {
@@ -253,7 +329,7 @@ func TestAdvance_SkipsEmptyRetreat(t *testing.T) {
 	retreatAdj.nextAdj = fallAdj
 
 	g := &game{adj: adj, parser: &mockParser{}}
-	err := g.Advance()
+	_, err := g.Advance()
 
 	is.NoErr(err)
 	// Should have skipped the empty retreat and landed on Fall movement.
@@ -267,7 +343,7 @@ func TestAdvance_PropagatesNextError(t *testing.T) {
 	adj.nextErr = errors.New("godip internal error")
 
 	g := &game{adj: adj, parser: &mockParser{}}
-	err := g.Advance()
+	_, err := g.Advance()
 
 	is.Err(err)
 }
@@ -331,7 +407,7 @@ func TestAdvance_SkipNextError(t *testing.T) {
 	adj.nextAdj = retreatAdj
 
 	g := &game{adj: adj, parser: &mockParser{}}
-	err := g.Advance()
+	_, err := g.Advance()
 
 	is.Err(err)
 }
@@ -346,7 +422,7 @@ func TestAdvance_NilPhase(t *testing.T) {
 	adj.nextAdj = nextAdj
 
 	g := &game{adj: adj, parser: &mockParser{}}
-	err := g.Advance()
+	_, err := g.Advance()
 
 	is.NoErr(err)
 	is.Equal(g.adj, gameState(nextAdj))
@@ -380,7 +456,7 @@ func TestAdvance_SkipsEmptyAdjustment(t *testing.T) {
 	adjustAdj.nextAdj = springAdj
 
 	g := &game{adj: adj, parser: &mockParser{}}
-	err := g.Advance()
+	_, err := g.Advance()
 
 	is.NoErr(err)
 	is.Equal(g.adj, gameState(springAdj))
@@ -466,7 +542,7 @@ func TestIsEmptyPhase_AdjustmentAllBalanced(t *testing.T) {
 
 func TestLoad_CreatesEngine(t *testing.T) {
 	is := is.New(t)
-	eng, err := Load([]byte(`{}`))
+	eng, err := Load([]byte(`{"year":1901,"season":"Spring","phase_type":"Movement"}`))
 	is.NoErr(err)
 	is.NotNil(eng)
 }
@@ -476,7 +552,7 @@ func TestLoad_PropagatesLoaderError(t *testing.T) {
 	failLoader := func(_ []byte) (gameState, error) {
 		return nil, errors.New("deserialise failed")
 	}
-	_, err := loadFromSnapshot([]byte(`{}`), failLoader)
+	_, err := loadFromSnapshot([]byte(`{}`), failLoader, DefaultVariant, &mockParser{})
 	is.Err(err)
 }
 
@@ -515,7 +591,7 @@ func TestFillNMR_NilDefaultOrder(t *testing.T) {
 		},
 	}
 
-	fillNMR(adj)
+	fillNMR(adj, NMRHold)
 
 	if len(adj.setOrders) != 0 {
 		t.Errorf("expected no orders set when DefaultOrder returns nil, got %d", len(adj.setOrders))
@@ -530,7 +606,7 @@ func TestFillNMR_FillsDislodgedUnitsInRetreat(t *testing.T) {
 		"Vie": {Type: godip.Army, Nation: "Austria"},
 	}
 
-	fillNMR(adj)
+	fillNMR(adj, NMRHold)
 
 	// A default order (disband) must have been staged for the dislodged unit.
 	_, filled := adj.setOrders["Vie"]
@@ -548,7 +624,7 @@ func TestFillNMR_SkipsDislodgedWithExistingOrder(t *testing.T) {
 	existingOrder := &stubOrder{t: "A Vie R Bud"}
 	adj.orders["Vie"] = existingOrder
 
-	fillNMR(adj)
+	fillNMR(adj, NMRHold)
 
 	// The existing order must not be overwritten.
 	is.Equal(adj.setOrders["Vie"], nil)
@@ -566,7 +642,7 @@ func TestFillNMR_NilDefaultOrderForDislodged(t *testing.T) {
 		"Vie": {Type: godip.Army, Nation: "Austria"},
 	}
 
-	fillNMR(adj)
+	fillNMR(adj, NMRHold)
 
 	if len(adj.setOrders) != 0 {
 		t.Errorf("expected no orders set when DefaultOrder returns nil, got %d", len(adj.setOrders))
@@ -580,7 +656,7 @@ func TestFillNMR_NonRetreatPhaseIgnoresDislodgeds(t *testing.T) {
 		"Vie": {Type: godip.Army, Nation: "Austria"},
 	}
 
-	fillNMR(adj)
+	fillNMR(adj, NMRHold)
 
 	// Movement phase: dislodgeds must not get orders via fillNMR.
 	if _, set := adj.setOrders["Vie"]; set {
@@ -588,6 +664,119 @@ func TestFillNMR_NonRetreatPhaseIgnoresDislodgeds(t *testing.T) {
 	}
 }
 
+func TestFillNMR_RecordsDefaultOrders(t *testing.T) {
+	is := is.New(t)
+	adj := newMockAdj()
+	adj.phase = &mockPhase{typ: godip.Movement, year: 1901, season: godip.Spring}
+	adj.units["vie"] = godip.Unit{Type: godip.Army, Nation: "Austria"}
+	adj.units["bud"] = godip.Unit{Type: godip.Army, Nation: "Austria"}
+	adj.orders["bud"] = &stubOrder{t: "Move"}
+
+	log := fillNMR(adj, NMRHold)
+
+	is.Equal(log.byNation(), map[string][]string{"Austria": {"Vie Hold"}})
+}
+
+func TestFillNMR_DisbandRetreatsPolicy(t *testing.T) {
+	is := is.New(t)
+	newAdj := func() *mockAdj {
+		adj := newMockAdj()
+		// godip has no default retreat order.
+		adj.phase = &mockPhase{typ: godip.Retreat, defaultOrderFn: func(godip.Province) adjOrder { return nil }}
+		adj.dislodgeds = map[godip.Province]godip.Unit{"vie": {Type: godip.Army, Nation: "Austria"}}
+		return adj
+	}
+
+	adj := newAdj()
+	is.Equal(len(fillNMR(adj, NMRHold)), 0)
+	is.Equal(len(adj.setOrders), 0)
+
+	adj = newAdj()
+	log := fillNMR(adj, NMRDisbandRetreats)
+	is.Equal(adj.setOrders["vie"].Type(), godip.Disband)
+	is.Equal(log.byNation(), map[string][]string{"Austria": {"A Vie disband"}})
+}
+
+func TestFillNMR_CivilDisorderPolicy(t *testing.T) {
+	is := is.New(t)
+	adj := newMockAdj()
+	adj.phase = &mockPhase{typ: godip.Adjustment, defaultOrderFn: func(godip.Province) adjOrder { return nil }}
+	adj.supplyCenters = map[godip.Province]godip.Nation{"vie": "Austria"}
+	adj.units["vie"] = godip.Unit{Type: godip.Army, Nation: "Austria"}
+	adj.units["gal"] = godip.Unit{Type: godip.Army, Nation: "Austria"}
+	adj.units["tri"] = godip.Unit{Type: godip.Fleet, Nation: "Austria"}
+	adj.units["boh"] = godip.Unit{Type: godip.Army, Nation: "Austria"}
+	// Austria owes three disbands and has ordered one of them.
+	adj.orders["boh"] = &stubOrder{t: "Disband"}
+	adj.civilDisorder = map[godip.Nation][]godip.Province{"Austria": {"boh", "gal", "tri", "vie"}}
+
+	is.Equal(len(fillNMR(adj, NMRDisbandRetreats)), 0)
+
+	log := fillNMR(adj, NMRCivilDisorder)
+	is.Equal(log.byNation(), map[string][]string{"Austria": {"A Gal disband", "F Tri disband"}})
+	_, vieDisbanded := adj.setOrders["vie"]
+	is.False(vieDisbanded)
+}
+
+func TestFillNMR_LeavesUnorderedBuilds(t *testing.T) {
+	is := is.New(t)
+	adj := newMockAdj()
+	adj.phase = &mockPhase{typ: godip.Adjustment, defaultOrderFn: func(godip.Province) adjOrder { return nil }}
+	adj.supplyCenters = map[godip.Province]godip.Nation{"vie": "Austria", "bud": "Austria", "tri": "Austria"}
+	adj.units["ser"] = godip.Unit{Type: godip.Army, Nation: "Austria"}
+	adj.orders["vie"] = &stubOrder{t: "Build"}
+
+	is.Equal(len(fillNMR(adj, NMRCivilDisorder)), 0)
+	is.Equal(len(adj.setOrders), 0)
+}
+
+func TestParseNMRPolicy(t *testing.T) {
+	is := is.New(t)
+	p, err := ParseNMRPolicy("")
+	is.NoErr(err)
+	is.Equal(p, DefaultNMRPolicy)
+	p, err = ParseNMRPolicy("Civil-Disorder")
+	is.NoErr(err)
+	is.Equal(p, NMRCivilDisorder)
+	p, err = ParseNMRPolicy("waive-builds")
+	is.NoErr(err)
+	is.Equal(p, NMRCivilDisorder)
+	_, err = ParseNMRPolicy("anarchy")
+	is.Err(err)
+	is.Equal(NMRPolicies(), []string{"hold", "disband-retreats", "civil-disorder"})
+}
+
+func TestOrderProvince(t *testing.T) {
+	is := is.New(t)
+	for text, want := range map[string]string{
+		"A Vie-Bud":            "vie",
+		"a vie h":              "vie",
+		"F Stp/sc-Bot":         "stp",
+		"A Par S A Mar-Bur":    "par",
+		"A Lon-Nwy via convoy": "lon",
+		"build F Stp/nc":       "stp",
+		"remove A Par":         "par",
+		"remove Par":           "par",
+		"F Kie disband":        "kie",
+		"Waive":                "",
+		"":                     "",
+	} {
+		is.Equal(OrderProvince(text), want)
+	}
+}
+
+func TestSubmitOrder_ReplacesOrderForSameUnit(t *testing.T) {
+	is := is.New(t)
+	adj := newMockAdj()
+	g := &game{adj: adj, parser: &mockParser{prov: "vie", order: &stubOrder{t: "Move"}}}
+
+	is.NoErr(g.SubmitOrder("Austria", "A Vie-Bud"))
+	is.NoErr(g.SubmitOrder("Austria", "A Vie-Tyr"))
+
+	is.Equal(len(adj.orders), 1)
+	is.Equal(g.staged["vie"].text, "A Vie-Tyr")
+}
+
 func TestDislodgeds_ReturnsProvinceToNationMap(t *testing.T) {
 	is := is.New(t)
 	adj := newMockAdj()
@@ -702,7 +891,7 @@ func TestAdvance_AfterResolve_ReturnsNil(t *testing.T) {
 	is.NoErr(err)
 	is.Equal(g.advanced, true)
 
-	err = g.Advance()
+	_, err = g.Advance()
 	is.NoErr(err)
 	is.Equal(g.advanced, false)
 	is.Equal(g.adj, gameState(nextAdj))
@@ -726,7 +915,7 @@ func TestAdvance_AfterResolve_SkipsEmptyPhase(t *testing.T) {
 	_, err := g.Resolve()
 	is.NoErr(err)
 
-	err = g.Advance()
+	_, err = g.Advance()
 	is.NoErr(err)
 	is.Equal(g.adj, gameState(fallAdj))
 }
@@ -745,7 +934,7 @@ func TestAdvance_AfterResolve_PropagatesNextError(t *testing.T) {
 	_, err := g.Resolve()
 	is.NoErr(err)
 
-	err = g.Advance()
+	_, err = g.Advance()
 	is.Err(err)
 }
 
@@ -780,3 +969,112 @@ func TestMoveSucceeded_UnitArrivedAtDestination_ReturnsTrue(t *testing.T) {
 		t.Error("expected true when unit arrived at destination")
 	}
 }
+
+// ---- classifyResolution -----------------------------------------------------
+
+func TestClassifyResolution(t *testing.T) {
+	cases := []struct {
+		err     error
+		outcome Outcome
+		by      string
+	}{
+		{nil, OutcomeSucceeded, ""},
+		{godip.ErrBounce{Province: "mun"}, OutcomeBounced, "mun"},
+		{godip.ErrSupportBroken{Province: "pie"}, OutcomeSupportCut, "pie"},
+		{godip.ErrConvoyDislodged{Province: "nth"}, OutcomeConvoyDisrupted, "nth"},
+		{godip.ErrMissingConvoyPath, OutcomeConvoyDisrupted, ""},
+		{godip.ErrInvalidSupporteeOrder, OutcomeVoid, ""},
+		{godip.ErrIllegalMove, OutcomeVoid, ""},
+		{errors.New("something else"), OutcomeFailed, ""},
+	}
+	for _, c := range cases {
+		outcome, by := classifyResolution(c.err)
+		if outcome != c.outcome || by != c.by {
+			t.Errorf("classifyResolution(%v) = %q, %q; want %q, %q", c.err, outcome, by, c.outcome, c.by)
+		}
+	}
+}
+
+func TestResolutionReason(t *testing.T) {
+	is := is.New(t)
+	is.Equal(resolutionReason(godip.ErrIllegalMove), "the unit cannot move there")
+	is.Equal(resolutionReason(godip.ErrInvalidSupporteeOrder), "the supported unit was ordered to do something else")
+	is.Equal(resolutionReason(godip.ErrDoubleBuild{}), "a unit is already being built there")
+	is.Equal(resolutionReason(errors.New("something else")), "something else")
+	for err, reason := range validationErrors {
+		if reason == "" || strings.HasPrefix(reason, "Err") {
+			t.Errorf("validation error %v has no plain reason: %q", err, reason)
+		}
+	}
+}
+
+func TestResolve_UsesRecordedResolution(t *testing.T) {
+	is := is.New(t)
+	adj := newMockAdj()
+	adj.phase = &mockPhase{typ: godip.Movement, year: 1901, season: godip.Spring}
+	adj.units["vie"] = godip.Unit{Type: godip.Army, Nation: "Austria"}
+	nextAdj := newMockAdj()
+	nextAdj.phase = &mockPhase{typ: godip.Retreat, year: 1901, season: godip.Spring}
+	nextAdj.resolutions = map[godip.Province]error{"vie": godip.ErrBounce{Province: "gal"}}
+	adj.nextAdj = nextAdj
+
+	g := &game{adj: adj, parser: &mockParser{prov: "vie", order: orders.Move("vie", "bud")}}
+	is.NoErr(g.SubmitOrder("Austria", "A Vie-Bud"))
+	result, err := g.Resolve()
+
+	is.NoErr(err)
+	is.Equal(len(result.Orders), 1)
+	is.Equal(result.Orders[0].Outcome, OutcomeBounced)
+	is.Equal(result.Orders[0].By, "gal")
+	is.Equal(result.Orders[0].Nation, "Austria")
+	is.Equal(result.Orders[0].Text, "A Vie-Bud")
+	is.Equal(result.Orders[0].Success, false)
+}
+
+// ---- ValidOrders ------------------------------------------------------------
+
+func TestValidOrders_FormatsOptionTree(t *testing.T) {
+	is := is.New(t)
+	adj := newMockAdj()
+	adj.phase = &mockPhase{typ: godip.Adjustment, year: 1901, season: godip.Fall}
+	adj.units["mos"] = godip.Unit{Type: godip.Army, Nation: "Russia"}
+	adj.options = godip.Options{
+		"stp/nc": godip.Options{
+			godip.Build: godip.Options{
+				godip.FilteredOptionValue{Filter: "MAX:Build:0", Value: godip.Fleet}: godip.Options{
+					godip.SrcProvince("stp/nc"): nil,
+				},
+			},
+		},
+		"mos": godip.Options{
+			godip.Disband: godip.Options{
+				godip.FilteredOptionValue{Filter: "MAX:Disband:0", Value: godip.SrcProvince("mos")}: nil,
+			},
+		},
+	}
+	g := &game{adj: adj, parser: &mockParser{}}
+
+	is.Equal(g.ValidOrders("Russia"), []string{"A Mos disband", "build F Stp/nc"})
+}
+
+func TestValidOrders_NilPhase(t *testing.T) {
+	is := is.New(t)
+	g := &game{adj: newMockAdj(), parser: &mockParser{}}
+	is.Equal(len(g.ValidOrders("Russia")), 0)
+}
+
+func TestClone_UnsupportedState(t *testing.T) {
+	is := is.New(t)
+	g := &game{adj: newMockAdj(), parser: &mockParser{}}
+	_, err := g.Clone()
+	is.Err(err)
+	_, _, err = g.Preview(nil)
+	is.Err(err)
+}
+
+func TestClone_UnknownVariant(t *testing.T) {
+	is := is.New(t)
+	g := &game{adj: newMockAdj(), parser: &mockParser{}, variant: "atlantis"}
+	_, err := g.Clone()
+	is.Err(err)
+}
@@ -2,7 +2,7 @@ package engine
 
 // Integration tests that exercise the real *state.State code paths via
 // stateWrapper and phaseWrapper. These tests call New("classical") or
-// classicalLoader directly to reach the godip-backed implementations.
+// variantLoader directly to reach the godip-backed implementations.
 
 import (
 	"encoding/json"
@@ -166,11 +166,9 @@ func TestClassicalOrderParser_TooFewTokens(t *testing.T) {
 
 func TestClassicalLoader_EmptySnapshot(t *testing.T) {
 	is := is.New(t)
-	gs, err := classicalLoader([]byte(`{}`))
-	is.NoErr(err)
-	is.NotNil(gs)
-	// Should default to Spring 1901 Movement.
-	is.Equal(gs.Phase().Year(), 1901)
+	// An empty snapshot has no phase; it must not silently start a new game.
+	_, err := variantLoader(classical.ClassicalVariant)([]byte(`{}`))
+	is.True(errors.Is(err, ErrInvalidSnapshot))
 }
 
 func TestClassicalLoader_FullSnapshot(t *testing.T) {
@@ -180,7 +178,7 @@ func TestClassicalLoader_FullSnapshot(t *testing.T) {
 	data, err := w.Dump()
 	is.NoErr(err)
 
-	gs, err := classicalLoader(data)
+	gs, err := variantLoader(classical.ClassicalVariant)(data)
 	is.NoErr(err)
 	ph := gs.Phase()
 	is.Equal(ph.Year(), 1901)
@@ -191,19 +189,46 @@ func TestClassicalLoader_FullSnapshot(t *testing.T) {
 
 func TestClassicalLoader_BadJSON(t *testing.T) {
 	is := is.New(t)
-	// Invalid JSON should fall back to a fresh start (no error).
-	gs, err := classicalLoader([]byte(`not json`))
-	is.NoErr(err)
-	is.NotNil(gs)
+	_, err := variantLoader(classical.ClassicalVariant)([]byte(`not json`))
+	is.True(errors.Is(err, ErrInvalidSnapshot))
 }
 
-func TestLookupVariantStart_Classical(t *testing.T) {
+func TestClassicalLoader_RejectsSnapshotsNotOnTheMap(t *testing.T) {
+	tests := map[string]string{
+		"unknown unit province":      `"units":{"gondor":{"Type":"Army","Nation":"Austria"}}`,
+		"unknown unit nation":        `"units":{"vie":{"Type":"Army","Nation":"Mordor"}}`,
+		"unknown unit type":          `"units":{"vie":{"Type":"Zeppelin","Nation":"Austria"}}`,
+		"unknown dislodged":          `"dislodgeds":{"gondor":{"Type":"Army","Nation":"Austria"}}`,
+		"non-SC supply centre":       `"supply_centers":{"tyr":"Austria"}`,
+		"unknown supply centre":      `"supply_centers":{"gondor":"Austria"}`,
+		"unknown SC owner":           `"supply_centers":{"vie":"Mordor"}`,
+		"order for unknown province": `"orders":{"gondor":"A Gondor H"}`,
+	}
+	for name, field := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			snap := `{"year":1901,"season":"Spring","phase_type":"Movement",` + field + `}`
+			_, err := variantLoader(classical.ClassicalVariant)([]byte(snap))
+			is.True(errors.Is(err, ErrInvalidSnapshot))
+		})
+	}
+	for name, snap := range map[string]string{
+		"unknown phase type": `{"year":1901,"season":"Spring","phase_type":"Siege"}`,
+		"unknown season":     `{"year":1901,"season":"Summer","phase_type":"Movement"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			_, err := variantLoader(classical.ClassicalVariant)([]byte(snap))
+			is.True(errors.Is(err, ErrInvalidSnapshot))
+		})
+	}
+}
+
+func TestLookupVariant_Classical(t *testing.T) {
 	is := is.New(t)
-	start, err := lookupVariantStart("classical")
-	is.NoErr(err)
-	gs, err := start()
+	v, err := lookupVariant("Classical")
 	is.NoErr(err)
-	is.NotNil(gs)
+	is.Equal(v.Name, classical.ClassicalVariant.Name)
 }
 
 func TestStateWrapper_Phase_NilPhase(t *testing.T) {
@@ -242,18 +267,10 @@ func TestPhaseWrapper_DefaultOrder_NonMovementPhase(t *testing.T) {
 	}
 }
 
-func TestClassicalLoaderWith_StartError(t *testing.T) {
-	is := is.New(t)
-	startErr := errors.New("start failed")
-	failStart := func() (*state.State, error) { return nil, startErr }
-	_, err := classicalLoaderWith([]byte(`{}`), failStart)
-	is.Err(err)
-}
-
-func TestClassicalStartWith_Error(t *testing.T) {
+func TestVariantStartWith_Error(t *testing.T) {
 	is := is.New(t)
 	startErr := errors.New("start failed")
-	_, err := classicalStartWith(func() (*state.State, error) { return nil, startErr })
+	_, err := variantStartWith(classical.ClassicalVariant, func() (*state.State, error) { return nil, startErr })
 	is.Err(err)
 }
 
@@ -317,7 +334,7 @@ func TestBuildStateFromSnapshot_SetUnitsError(t *testing.T) {
 			"spa/nc": {Type: godip.Army, Nation: godip.France},
 		},
 	}
-	_, err := buildStateFromSnapshot(st, snap)
+	_, err := buildStateFromSnapshot(st, snap, classical.ClassicalVariant)
 	is.Err(err)
 }
 
@@ -332,6 +349,784 @@ func TestBuildStateFromSnapshot_SetDislodgedsError(t *testing.T) {
 			"spa/nc": {Type: godip.Army, Nation: godip.France},
 		},
 	}
-	_, err := buildStateFromSnapshot(st, snap)
+	_, err := buildStateFromSnapshot(st, snap, classical.ClassicalVariant)
 	is.Err(err)
 }
+
+// resultFor returns the OrderResult for prov, failing the test if absent.
+func resultFor(t *testing.T, r ResolutionResult, prov string) OrderResult {
+	t.Helper()
+	for _, o := range r.Orders {
+		if o.Province == prov {
+			return o
+		}
+	}
+	t.Fatalf("no result for %s in %+v", prov, r.Orders)
+	return OrderResult{}
+}
+
+func TestResolve_BouncedMove_Outcome(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("France", "A Par-Bur"))
+	is.NoErr(e.SubmitOrder("Germany", "A Mun-Bur"))
+
+	result, err := e.Resolve()
+	is.NoErr(err)
+
+	par := resultFor(t, result, "par")
+	is.Equal(par.Outcome, OutcomeBounced)
+	is.Equal(par.Nation, "France")
+	is.Equal(par.Text, "A Par-Bur")
+	is.Equal(par.By, "mun")
+}
+
+func TestResolve_UnmatchedSupport_Void(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	// Marseilles supports a move that Paris never makes.
+	is.NoErr(e.SubmitOrder("France", "A Mar S A Par-Bur"))
+	is.NoErr(e.SubmitOrder("France", "A Par H"))
+
+	result, err := e.Resolve()
+	is.NoErr(err)
+
+	mar := resultFor(t, result, "mar")
+	is.Equal(mar.Outcome, OutcomeVoid)
+	is.Equal(mar.Success, false)
+	is.Equal(mar.Reason, "the supported unit was ordered to do something else")
+}
+
+func TestResolve_SupportCut_Outcome(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("Italy", "A Ven-Pie"))
+	_, err = e.Resolve()
+	is.NoErr(err)
+	_, err = e.Advance()
+	is.NoErr(err)
+
+	// Fall 1901: Piedmont attacks Marseilles, cutting its support.
+	is.NoErr(e.SubmitOrder("France", "A Par-Bur"))
+	is.NoErr(e.SubmitOrder("France", "A Mar S A Par-Bur"))
+	is.NoErr(e.SubmitOrder("Italy", "A Pie-Mar"))
+	result, err := e.Resolve()
+	is.NoErr(err)
+
+	mar := resultFor(t, result, "mar")
+	is.Equal(mar.Outcome, OutcomeSupportCut)
+	is.Equal(mar.By, "pie")
+	is.Equal(mar.Success, false)
+}
+
+func TestResolve_DislodgedHold_Outcome(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("Germany", "A Mun-Bur"))
+	_, err = e.Resolve()
+	is.NoErr(err)
+	_, err = e.Advance()
+	is.NoErr(err)
+
+	// Fall 1901: a supported attack from Paris dislodges the army in Burgundy.
+	is.NoErr(e.SubmitOrder("France", "A Par-Bur"))
+	is.NoErr(e.SubmitOrder("France", "A Mar S A Par-Bur"))
+	is.NoErr(e.SubmitOrder("Germany", "A Bur H"))
+	result, err := e.Resolve()
+	is.NoErr(err)
+
+	bur := resultFor(t, result, "bur")
+	is.Equal(bur.Outcome, OutcomeDislodged)
+	is.Equal(bur.By, "par")
+	is.Equal(bur.Nation, "Germany")
+	is.Equal(resultFor(t, result, "par").Outcome, OutcomeSucceeded)
+}
+
+func TestSubmitOrder_RejectsOtherNationsUnit(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+
+	err = e.SubmitOrder("England", "A Vie-Bud")
+	is.Err(err)
+	is.True(errors.Is(err, ErrNotOwned))
+	var oe *OrderError
+	is.True(errors.As(err, &oe))
+	is.Equal(oe.Owner, "Austria")
+
+	// Austria's own order for Vienna must still be accepted.
+	is.NoErr(e.SubmitOrder("Austria", "A Vie-Bud"))
+}
+
+func TestSubmitOrder_RejectsEmptyProvince(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	err = e.SubmitOrder("Austria", "A Gal-Ukr")
+	is.True(errors.Is(err, ErrEmptyProvince))
+}
+
+func TestSubmitOrder_RejectsWrongPhase(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	err = e.SubmitOrder("Austria", "build A Vie")
+	is.True(errors.Is(err, ErrWrongPhase))
+}
+
+func TestSubmitOrder_RejectsIllegalMove(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	err = e.SubmitOrder("England", "F Lon-Par")
+	is.True(errors.Is(err, ErrIllegalOrder))
+	var oe *OrderError
+	is.True(errors.As(err, &oe))
+	is.NotNil(oe.Cause)
+}
+
+func TestSubmitOrder_AcceptsCoastalFleet(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("Russia", "F Stp/sc-Bot"))
+}
+
+func TestValidOrders_SpringMovement(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+
+	valid := e.ValidOrders("Russia")
+	has := make(map[string]bool)
+	for _, o := range valid {
+		has[o] = true
+	}
+	for _, want := range []string{"A Mos H", "A Mos-Ukr", "F Stp/sc-Bot", "F Sev-Bla", "A War S A Mos-Ukr"} {
+		if !has[want] {
+			t.Errorf("expected %q in valid orders, got %v", want, valid)
+		}
+	}
+	is.Equal(has["A Vie-Bud"], false)
+
+	// Every listed order must be accepted by SubmitOrder.
+	for _, o := range valid {
+		fresh, _ := New("classical")
+		if err := fresh.SubmitOrder("Russia", o); err != nil {
+			t.Errorf("valid order %q rejected: %v", o, err)
+		}
+	}
+}
+
+func TestValidOrders_RetreatPhase(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("Germany", "A Mun-Bur"))
+	_, err = e.Resolve()
+	is.NoErr(err)
+	_, err = e.Advance()
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("France", "A Par-Bur"))
+	is.NoErr(e.SubmitOrder("France", "A Mar S A Par-Bur"))
+	_, err = e.Resolve()
+	is.NoErr(err)
+	_, err = e.Advance()
+	is.NoErr(err)
+
+	valid := e.ValidOrders("Germany")
+	has := make(map[string]bool)
+	for _, o := range valid {
+		has[o] = true
+	}
+	is.True(has["A Bur disband"])
+	is.True(has["A Bur-Ruh"])
+	// Paris is where the attacker came from, so it is not a retreat option.
+	is.Equal(has["A Bur-Par"], false)
+}
+
+func TestValidOrders_AdjustmentPhase(t *testing.T) {
+	is := is.New(t)
+	// Austria has a unit more than its supply centres; Russia has two empty
+	// home centres, one of them coastal.
+	snap := `{"year":1901,"season":"Fall","phase_type":"Adjustment",
+		"units":{"vie":{"Type":"Army","Nation":"Austria"},"bud":{"Type":"Army","Nation":"Austria"},
+			"bur":{"Type":"Army","Nation":"Austria"},
+			"mos":{"Type":"Army","Nation":"Russia"},"sev":{"Type":"Fleet","Nation":"Russia"}},
+		"supply_centers":{"vie":"Austria","bud":"Austria",
+			"mos":"Russia","sev":"Russia","war":"Russia","stp":"Russia"}}`
+	e, err := Load([]byte(snap))
+	is.NoErr(err)
+
+	for nation, want := range map[string][]string{
+		"Austria": {"A Bur disband", "A Vie disband"},
+		"Russia":  {"build A War", "build F Stp/nc", "build F Stp/sc", "build A Stp"},
+	} {
+		valid := e.ValidOrders(nation)
+		has := make(map[string]bool)
+		for _, o := range valid {
+			has[o] = true
+		}
+		for _, o := range want {
+			if !has[o] {
+				t.Errorf("expected %q in %s valid orders, got %v", o, nation, valid)
+			}
+		}
+		// Every listed order must be accepted by SubmitOrder.
+		for _, o := range valid {
+			fresh, err := Load([]byte(snap))
+			is.NoErr(err)
+			if err := fresh.SubmitOrder(nation, o); err != nil {
+				t.Errorf("valid order %q rejected: %v", o, err)
+			}
+		}
+	}
+}
+
+func TestLookupVariant_ClassicalInfo(t *testing.T) {
+	is := is.New(t)
+	info, err := LookupVariant("classical")
+	is.NoErr(err)
+	is.Equal(info.DisplayName, "Classical")
+	is.Equal(len(info.Nations), 7)
+	is.Equal(info.SoloSCCount, 18)
+	is.Equal(info.SupplyCenters, 34)
+	is.Equal(info.HomeCenters["England"], []string{"edi", "lon", "lvp"})
+	is.Equal(info.Nations, []string{"Austria", "England", "France", "Germany", "Italy", "Turkey", "Russia"})
+	is.Equal(info.NationAbbrevs["Austria"], "Aus")
+	units, err := info.StartUnits()
+	is.NoErr(err)
+	is.Equal(units["edi"], UnitInfo{Type: "Fleet", Nation: "England"})
+	is.Equal(info.ProvinceLongNames["vie"], "Vienna")
+}
+
+// TestLookupVariant_DefaultsSoloSCCount covers a variant that sets no solo
+// count: a majority of the board's supply centres wins.
+func TestLookupVariant_DefaultsSoloSCCount(t *testing.T) {
+	is := is.New(t)
+	v := twoPowerVariant()
+	v.SoloSCCount = nil
+	v.BlankStart = nil
+	RegisterVariant("nosolo", v)
+	defer func() {
+		variantRegistry.Lock()
+		delete(variantRegistry.byName, "nosolo")
+		variantRegistry.Unlock()
+	}()
+
+	info, err := LookupVariant("nosolo")
+	is.NoErr(err)
+	is.Equal(info.SoloSCCount, 18)
+}
+
+func TestNationAbbrevs(t *testing.T) {
+	is := is.New(t)
+	is.Equal(nationAbbrevs([]string{"England", "France", "Ita"}), map[string]string{"England": "Eng", "France": "Fra", "Ita": "Ita"})
+	is.Equal(nationAbbrevs([]string{"Austria", "Australia", "Aus"}), map[string]string{"Austria": "Austri", "Australia": "Austra", "Aus": "Aus"})
+	is.Equal(nationAbbrevs([]string{"Saxony", "Savoy", "Sardinia"}), map[string]string{"Saxony": "Sax", "Savoy": "Sav", "Sardinia": "Sar"})
+}
+
+func TestLookupVariant_Unknown(t *testing.T) {
+	is := is.New(t)
+	_, err := LookupVariant("gondor")
+	is.Err(err)
+}
+
+// twoPowerVariant is a classical-map variant for two players, used to
+// exercise the registry and the graph-based order parser.
+func twoPowerVariant() common.Variant {
+	v := classical.ClassicalVariant
+	v.Name = "Two Power"
+	v.Nations = []godip.Nation{godip.England, godip.France}
+	v.Start = func() (*state.State, error) {
+		st, err := classical.Start()
+		if err != nil {
+			return nil, err
+		}
+		for p, u := range st.Units() {
+			if u.Nation != godip.England && u.Nation != godip.France {
+				st.RemoveUnit(p)
+			}
+		}
+		for p, n := range st.SupplyCenters() {
+			if n != godip.England && n != godip.France {
+				st.SetSC(p, "")
+			}
+		}
+		return st, nil
+	}
+	v.SoloSCCount = func(*state.State) int { return 10 }
+	return v
+}
+
+func TestRegisterVariant_NewGameAndReload(t *testing.T) {
+	is := is.New(t)
+	RegisterVariant("twopower", twoPowerVariant())
+	defer func() {
+		variantRegistry.Lock()
+		delete(variantRegistry.byName, "twopower")
+		variantRegistry.Unlock()
+	}()
+	is.True(containsString(Variants(), "twopower"))
+
+	info, err := LookupVariant("TwoPower")
+	is.NoErr(err)
+	is.Equal(info.Nations, []string{"England", "France"})
+	is.Equal(info.SoloSCCount, 10)
+
+	e, err := New("twopower")
+	is.NoErr(err)
+	is.Equal(e.Variant(), "twopower")
+	is.NoErr(e.SubmitOrder("England", "F Lon-Nth"))
+	is.NoErr(e.SubmitOrder("England", "A Lvp-Yor"))
+	is.NoErr(e.SubmitOrder("France", "F Bre H"))
+	is.Err(e.SubmitOrder("France", "A Par-Gondor"))
+
+	data, err := e.Dump()
+	is.NoErr(err)
+	var snap stateSnapshot
+	is.NoErr(json.Unmarshal(data, &snap))
+	is.Equal(snap.Variant, "Two Power")
+
+	reloaded, err := Load(data)
+	is.NoErr(err)
+	is.Equal(reloaded.Phase(), e.Phase())
+	is.Equal(len(reloaded.Units()), 6)
+	is.NoErr(reloaded.SubmitOrder("England", "F Lon-Nth"))
+}
+
+func TestVariantOrderParser_Parse(t *testing.T) {
+	p := newVariantOrderParser(classical.ClassicalVariant.Graph())
+	tests := []struct {
+		text string
+		prov godip.Province
+		typ  godip.OrderType
+	}{
+		{"A Vie-Bud", "vie", godip.Move},
+		{"A Lon - Nwy via convoy", "lon", godip.Move},
+		{"F Nth C A Lon-Nwy", "nth", godip.Convoy},
+		{"A Mar S A Par", "mar", godip.Support},
+		{"A Mar S A Par-Bur", "mar", godip.Support},
+		{"A Vie H", "vie", godip.Hold},
+		{"build F Stp/nc", "stp/nc", godip.Build},
+		{"A Bur disband", "bur", godip.Disband},
+		{"remove A Bur", "bur", godip.Disband},
+	}
+	for _, tc := range tests {
+		t.Run(tc.text, func(t *testing.T) {
+			is := is.New(t)
+			prov, order, err := p.Parse("", tc.text)
+			is.NoErr(err)
+			is.Equal(prov, tc.prov)
+			is.Equal(order.Type(), tc.typ)
+		})
+	}
+	for _, bad := range []string{"A Vie-Gondor", "Vie", "march on Vienna"} {
+		if _, _, err := p.Parse("", bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestLoad_SnapshotWithoutVariantIsClassical(t *testing.T) {
+	is := is.New(t)
+	e, err := Load([]byte(`{"year":1902,"season":"Fall","phase_type":"Movement","units":{"vie":{"Type":"Army","Nation":"Austria"}}}`))
+	is.NoErr(err)
+	is.Equal(e.Variant(), "classical")
+	is.Equal(e.Phase(), "Fall 1902 Movement")
+}
+
+func TestLoad_UnknownVariant(t *testing.T) {
+	is := is.New(t)
+	_, err := Load([]byte(`{"variant":"Gondor","year":1901,"season":"Spring","phase_type":"Movement"}`))
+	is.Err(err)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// dislodgeBurgundyWithStandoff plays to Fall 1901 Retreat with the German army
+// in Burgundy dislodged from Paris and a standoff in Belgium.
+func dislodgeBurgundyWithStandoff(t *testing.T) Engine {
+	t.Helper()
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("Germany", "A Mun-Bur"))
+	is.NoErr(e.SubmitOrder("Germany", "F Kie-Hol"))
+	is.NoErr(e.SubmitOrder("France", "A Mar-Gas"))
+	is.NoErr(e.SubmitOrder("France", "F Bre-Pic"))
+	_, err = e.Resolve()
+	is.NoErr(err)
+	_, err = e.Advance()
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("France", "A Par-Bur"))
+	is.NoErr(e.SubmitOrder("France", "A Gas S A Par-Bur"))
+	is.NoErr(e.SubmitOrder("France", "F Pic-Bel"))
+	is.NoErr(e.SubmitOrder("Germany", "F Hol-Bel"))
+	_, err = e.Resolve()
+	is.NoErr(err)
+	_, err = e.Advance()
+	is.NoErr(err)
+	is.Equal(e.Phase(), "Fall 1901 Retreat")
+	return e
+}
+
+func TestSnapshot_RetreatKeepsBouncesAndDislodgers(t *testing.T) {
+	is := is.New(t)
+	e := dislodgeBurgundyWithStandoff(t)
+	data, err := e.Dump()
+	is.NoErr(err)
+
+	var snap stateSnapshot
+	is.NoErr(json.Unmarshal(data, &snap))
+	is.Equal(snap.Schema, snapshotSchema)
+	is.Equal(snap.Dislodgers["par"], godip.Province("bur"))
+	is.True(len(snap.Bounces["bel"]) > 0)
+
+	reloaded, err := Load(data)
+	is.NoErr(err)
+	has := make(map[string]bool)
+	for _, o := range reloaded.ValidOrders("Germany") {
+		has[o] = true
+	}
+	is.True(has["A Bur-Ruh"])
+	is.Equal(has["A Bur-Bel"], false) // standoff province
+	is.Equal(has["A Bur-Par"], false) // attacker's origin
+	is.Err(reloaded.SubmitOrder("Germany", "A Bur-Bel"))
+}
+
+func TestSnapshot_RoundTripsStagedOrders(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("France", "A Par-Bur"))
+	is.NoErr(e.SubmitOrder("France", "A Mar S A Par-Bur"))
+	is.NoErr(e.SubmitOrder("France", "F Bre H"))
+	is.NoErr(e.SubmitOrder("England", "F Lon-Nth"))
+	is.NoErr(e.SubmitOrder("Italy", "A Ven S A Rom"))
+	is.NoErr(e.SubmitOrder("Germany", "A Mun-Bur"))
+
+	data, err := e.Dump()
+	is.NoErr(err)
+	var snap stateSnapshot
+	is.NoErr(json.Unmarshal(data, &snap))
+	is.Equal(snap.Orders["mar"], "A Mar S A Par-Bur")
+	is.Equal(snap.Orders["ven"], "A Ven S A Rom")
+	is.Equal(snap.Orders["lon"], "F Lon-Nth")
+
+	reloaded, err := Load(data)
+	is.NoErr(err)
+	again, err := reloaded.Dump()
+	is.NoErr(err)
+	var snap2 stateSnapshot
+	is.NoErr(json.Unmarshal(again, &snap2))
+	is.Equal(snap2.Orders, snap.Orders)
+
+	result, err := reloaded.Resolve()
+	is.NoErr(err)
+	is.Equal(resultFor(t, result, "par").Outcome, OutcomeSucceeded)
+	is.Equal(resultFor(t, result, "mun").Outcome, OutcomeBounced)
+}
+
+func TestSnapshot_RoundTripsResolutions(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("France", "A Par-Bur"))
+	is.NoErr(e.SubmitOrder("Germany", "A Mun-Bur"))
+	_, err = e.Resolve()
+	is.NoErr(err)
+
+	data, err := e.Dump()
+	is.NoErr(err)
+	var snap stateSnapshot
+	is.NoErr(json.Unmarshal(data, &snap))
+	is.Equal(snap.Resolutions["par"], "ErrBounce:mun")
+
+	reloaded, err := Load(data)
+	is.NoErr(err)
+	again, err := reloaded.Dump()
+	is.NoErr(err)
+	var snap2 stateSnapshot
+	is.NoErr(json.Unmarshal(again, &snap2))
+	is.Equal(snap2.Resolutions, snap.Resolutions)
+}
+
+func TestLoad_SchemaNewerThanSupported(t *testing.T) {
+	is := is.New(t)
+	_, err := Load([]byte(`{"schema":99,"year":1901,"season":"Spring","phase_type":"Movement"}`))
+	is.True(errors.Is(err, ErrInvalidSnapshot))
+}
+
+func TestDecodeResolution(t *testing.T) {
+	is := is.New(t)
+	is.NoErr(decodeResolution(""))
+	is.Equal(decodeResolution("ErrIllegalMove"), godip.ErrIllegalMove)
+	is.Equal(decodeResolution("ErrBounce:bur"), godip.ErrBounce{Province: "bur"})
+	is.Equal(decodeResolution("ErrSupportBroken:tyr"), godip.ErrSupportBroken{Province: "tyr"})
+	out, by := classifyResolution(decodeResolution("ErrConvoyDislodged:nth"))
+	is.Equal(out, OutcomeConvoyDisrupted)
+	is.Equal(by, "nth")
+	is.Equal(decodeResolution("ErrSomethingNew").Error(), "ErrSomethingNew")
+}
+
+func TestClone_IsIndependent(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("France", "A Par-Bur"))
+
+	clone, err := e.Clone()
+	is.NoErr(err)
+	is.Equal(clone.Variant(), "classical")
+	is.NoErr(clone.SubmitOrder("Germany", "A Mun-Bur"))
+	result, err := clone.Resolve()
+	is.NoErr(err)
+	par := resultFor(t, result, "par")
+	is.Equal(par.Outcome, OutcomeBounced)
+	is.Equal(par.Text, "A Par-Bur")
+	is.Equal(clone.Phase(), "Spring 1901 Retreat")
+
+	// The original is still in Spring with only France's order staged.
+	is.Equal(e.Phase(), "Spring 1901 Movement")
+	result, err = e.Resolve()
+	is.NoErr(err)
+	is.Equal(resultFor(t, result, "par").Outcome, OutcomeSucceeded)
+}
+
+func TestPreview_AdjudicatesHypotheticalOrders(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	// A real order the preview must not see.
+	is.NoErr(e.SubmitOrder("Germany", "A Mun-Bur"))
+
+	result, after, err := e.Preview([]string{"A Par-Bur", "A Mar S A Par-Bur", "A Mun-Ruh"})
+	is.NoErr(err)
+	par := resultFor(t, result, "par")
+	is.Equal(par.Nation, "France")
+	is.Equal(par.Outcome, OutcomeSucceeded)
+	is.Equal(resultFor(t, result, "mun").Nation, "Germany")
+	is.Equal(after.Units()["bur"].Nation, "France")
+	is.Equal(after.Units()["ruh"].Nation, "Germany")
+
+	// The real game is untouched.
+	is.Equal(e.Phase(), "Spring 1901 Movement")
+	_, moved := e.Units()["bur"]
+	is.False(moved)
+	result, err = e.Resolve()
+	is.NoErr(err)
+	is.Equal(resultFor(t, result, "mun").Outcome, OutcomeSucceeded)
+}
+
+func TestPreview_RejectsInvalidOrder(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	_, _, err = e.Preview([]string{"A Par-Mos"})
+	is.True(errors.Is(err, ErrIllegalOrder))
+	_, _, err = e.Preview([]string{"A Bur-Par"})
+	is.True(errors.Is(err, ErrEmptyProvince))
+}
+
+func TestResolve_CivilDisorderRemovesFarthestUnits(t *testing.T) {
+	is := is.New(t)
+	// Austria holds two supply centres but has four units.
+	snap := `{"year":1901,"season":"Fall","phase_type":"Adjustment",
+		"units":{"vie":{"Type":"Army","Nation":"Austria"},"bud":{"Type":"Army","Nation":"Austria"},
+			"tri":{"Type":"Fleet","Nation":"Austria"},"gal":{"Type":"Army","Nation":"Austria"}},
+		"supply_centers":{"vie":"Austria","bud":"Austria"}}`
+	e, err := Load([]byte(snap))
+	is.NoErr(err)
+	e.SetNMRPolicy(NMRCivilDisorder)
+
+	result, err := e.Resolve()
+	is.NoErr(err)
+	// Galicia is farthest from home; between the units at home, fleets go first.
+	is.Equal(result.NMR, map[string][]string{"Austria": {"A Gal disband", "F Tri disband"}})
+	units := e.Units()
+	is.Equal(len(units), 2)
+	is.Equal(units["vie"].Nation, "Austria")
+	is.Equal(units["bud"].Nation, "Austria")
+}
+
+func TestResolve_HoldPolicyRecordsHolds(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	for _, o := range []string{"A Vie H", "A Bud H", "F Tri H"} {
+		is.NoErr(e.SubmitOrder("Austria", o))
+	}
+	result, err := e.Resolve()
+	is.NoErr(err)
+	_, austria := result.NMR["Austria"]
+	is.False(austria)
+	is.Equal(result.NMR["England"], []string{"A Lvp H", "F Edi H", "F Lon H"})
+}
+
+func TestNormalizeOrder_Classical(t *testing.T) {
+	e, err := New("classical")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		"A Vie-Bud":                 "A Vie-Bud",
+		"army vienna -> budapest":   "A Vie-Bud",
+		"vienna to budapest":        "A Vie-Bud",
+		"Vie → Bud":                 "A Vie-Bud",
+		"A Viena - Budapset":        "A Vie-Bud",
+		"Lon hold":                  "F Lon H",
+		"london holds":              "F Lon H",
+		"A Par supports Mar to Bur": "A Par S A Mar-Bur",
+		"par s mar - bur":           "A Par S A Mar-Bur",
+		"A Ven supports Rome":       "A Ven S A Rom",
+		"A Ven S A Rom hold":        "A Ven S A Rom",
+		"F StP(sc) - Bot":           "F Stp/sc-Bot",
+		"fleet st. petersburg south coast -> gulf of bothnia": "F Stp/sc-Bot",
+		"F Lon C A Yor - Bel":                       "F Lon C A Yor-Bel",
+		"fleet london convoys yorkshire to belgium": "F Lon C A Yor-Bel",
+		"A Lvp - Edi via convoy":                    "A Lvp-Edi via convoy",
+		"F Bre -> Mid-Atlantic":                     "F Bre-Mid",
+		"F Bre to mao":                              "F Bre-Mid",
+		"Bre English Channel":                       "F Bre-Eng",
+		"F Edi-Nwg":                                 "F Edi-Nrg",
+		"F Mar - Spa (sc)":                          "F Mar-Spa/sc",
+		"F Mar-Spa/sc":                              "F Mar-Spa/sc",
+		"build F Stp nc":                            "build F Stp/nc",
+		"disband Kiel":                              "F Kie disband",
+	}
+	for in, want := range tests {
+		t.Run(in, func(t *testing.T) {
+			is := is.New(t)
+			got, err := e.NormalizeOrder("", in)
+			is.NoErr(err)
+			is.Equal(got, want)
+		})
+	}
+}
+
+func TestNormalizeOrder_Rejects(t *testing.T) {
+	e, err := New("classical")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range []string{"", "Vie", "A Gondor-Bud", "build Vie", "F Stp/ec-Bot", "A Vie-Bud via", "A Vie-Bud please"} {
+		t.Run(in, func(t *testing.T) {
+			is := is.New(t)
+			_, err := e.NormalizeOrder("", in)
+			is.Err(err)
+		})
+	}
+}
+
+func TestSubmitOrder_AcceptsLooseGrammar(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("France", "army paris -> burgundy"))
+	is.NoErr(e.SubmitOrder("France", "Marseilles supports Paris to Burgundy"))
+
+	result, err := e.Resolve()
+	is.NoErr(err)
+	par := resultFor(t, result, "par")
+	is.Equal(par.Text, "A Par-Bur")
+	is.Equal(par.Outcome, OutcomeSucceeded)
+	is.Equal(resultFor(t, result, "mar").Text, "A Mar S A Par-Bur")
+}
+
+func TestClearOrders_WithdrawsOnlyThatNationsOrders(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("France", "A Par-Bur"))
+	is.NoErr(e.SubmitOrder("Germany", "A Mun-Ruh"))
+
+	e.ClearOrders("France")
+	is.NoErr(e.SubmitOrder("France", "A Par-Pic"))
+
+	result, err := e.Resolve()
+	is.NoErr(err)
+	par := resultFor(t, result, "par")
+	is.Equal(par.Text, "A Par-Pic")
+	is.True(par.Success)
+	is.Equal(resultFor(t, result, "mun").Text, "A Mun-Ruh")
+}
+
+func TestSubmitOrder_LaterOrderForUnitWins(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	is.NoErr(e.SubmitOrder("Austria", "A Vie-Bud"))
+	is.NoErr(e.SubmitOrder("Austria", "A Vie-Tyr"))
+
+	result, err := e.Resolve()
+	is.NoErr(err)
+	vie := resultFor(t, result, "vie")
+	is.Equal(vie.Text, "A Vie-Tyr")
+	is.True(vie.Success)
+}
+
+func TestAdjacent_Classical(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	is.True(e.Adjacent("Army", "lvp", "edi"))
+	is.True(e.Adjacent("Army", "Vie", "Bud"))
+	is.True(!e.Adjacent("Army", "lon", "nwy"))
+	is.True(!e.Adjacent("Army", "lon", "nth"))
+	is.True(e.Adjacent("Fleet", "lon", "nth"))
+	is.True(e.Adjacent("Fleet", "stp/sc", "bot"))
+	is.True(!e.Adjacent("Fleet", "stp/nc", "bot"))
+	is.True(e.Adjacent("Fleet", "mid", "spa"))
+	is.True(!e.Adjacent("Fleet", "vie", "bud"))
+}
+
+func TestSupplyCenterOwners_Classical(t *testing.T) {
+	is := is.New(t)
+	e, err := New("classical")
+	is.NoErr(err)
+	owners := e.SupplyCenterOwners()
+	is.Equal(len(owners), 22)
+	is.Equal(owners["vie"], "Austria")
+	is.Equal(owners["lon"], "England")
+	is.Equal(owners["bel"], "")
+}
+
+func TestNormalizeProvince_Classical(t *testing.T) {
+	e, err := New("classical")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for in, want := range map[string]string{
+		"vie":           "vie",
+		"Vienna":        "vie",
+		"viena":         "vie",
+		"st petersburg": "stp",
+		"StP/nc":        "stp",
+		"nwg":           "nrg",
+	} {
+		t.Run(in, func(t *testing.T) {
+			is := is.New(t)
+			got, err := e.NormalizeProvince(in)
+			is.NoErr(err)
+			is.Equal(got, want)
+		})
+	}
+	for _, in := range []string{"", "Gondor", "vienna budapest", "A Vie"} {
+		_, err := e.NormalizeProvince(in)
+		if err == nil {
+			t.Errorf("NormalizeProvince(%q): expected an error", in)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zond/godip"
+	"github.com/zond/godip/orders"
+)
+
+// NMRPolicy decides which orders the engine gives on behalf of a nation that
+// sent none (No Moves Received). Each policy includes everything the one
+// before it does:
+//
+//	hold             — unordered units hold in Movement phases.
+//	disband-retreats — also disband unordered dislodged units in Retreat.
+//	civil-disorder   — also remove the units a nation owes in Adjustment,
+//	                   farthest from its home supply centres first (the
+//	                   official civil-disorder rule).
+//
+// godip disbands unretreated units and removes owed units itself when a
+// phase ends; the later policies make those orders explicit so they are
+// reported in ResolutionResult.NMR. Builds a nation does not order are
+// always waived.
+type NMRPolicy string
+
+const (
+	NMRHold            NMRPolicy = "hold"
+	NMRDisbandRetreats NMRPolicy = "disband-retreats"
+	NMRCivilDisorder   NMRPolicy = "civil-disorder"
+)
+
+// DefaultNMRPolicy is the policy used when none is chosen.
+const DefaultNMRPolicy = NMRHold
+
+// nmrPolicies lists the policies from least to most inclusive.
+var nmrPolicies = []NMRPolicy{NMRHold, NMRDisbandRetreats, NMRCivilDisorder}
+
+// NMRPolicies returns the names of all NMR policies, least inclusive first.
+func NMRPolicies() []string {
+	names := make([]string, len(nmrPolicies))
+	for i, p := range nmrPolicies {
+		names[i] = string(p)
+	}
+	return names
+}
+
+// ParseNMRPolicy returns the policy called name, matched case-insensitively.
+// An empty name is DefaultNMRPolicy.
+func ParseNMRPolicy(name string) (NMRPolicy, error) {
+	if name == "" {
+		return DefaultNMRPolicy, nil
+	}
+	// Games were once created with a waive-builds policy, which did no more
+	// than civil-disorder.
+	if strings.EqualFold(name, "waive-builds") {
+		return NMRCivilDisorder, nil
+	}
+	for _, p := range nmrPolicies {
+		if strings.EqualFold(string(p), name) {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("engine: unknown NMR policy %q", name)
+}
+
+// includes reports whether p does at least what other does.
+func (p NMRPolicy) includes(other NMRPolicy) bool {
+	rank := func(q NMRPolicy) int {
+		for i, r := range nmrPolicies {
+			if r == q {
+				return i
+			}
+		}
+		return 0 // unknown and empty policies behave as hold
+	}
+	return rank(p) >= rank(other)
+}
+
+// SetNMRPolicy sets the policy used to fill missing orders.
+func (g *game) SetNMRPolicy(p NMRPolicy) {
+	g.nmr = p
+}
+
+// nmrLog collects the orders given on each nation's behalf.
+type nmrLog map[godip.Nation][]string
+
+func (l nmrLog) add(n godip.Nation, text string) {
+	l[n] = append(l[n], text)
+}
+
+// merge adds the orders in other to l.
+func (l nmrLog) merge(other nmrLog) {
+	for n, texts := range other {
+		l[n] = append(l[n], texts...)
+	}
+}
+
+// byNation returns the log keyed by nation name with each list sorted, or nil
+// if it is empty.
+func (l nmrLog) byNation() map[string][]string {
+	if len(l) == 0 {
+		return nil
+	}
+	out := make(map[string][]string, len(l))
+	for n, texts := range l {
+		sorted := append([]string(nil), texts...)
+		sort.Strings(sorted)
+		out[string(n)] = sorted
+	}
+	return out
+}
+
+// nmrText renders an order given under NMR. Orders that are not real godip
+// orders (test stubs) fall back to the province and order type.
+func nmrText(prov godip.Province, o adjOrder, units, board map[godip.Province]godip.Unit) string {
+	if adj, ok := o.(godip.Adjudicator); ok {
+		if text := canonicalOrder(adj, units, board); text != "" {
+			return text
+		}
+	}
+	return fmt.Sprintf("%s %s", provName(prov), o.Type())
+}
+
+// fillAdjustments applies the Adjustment part of policy: civil-disorder
+// removals for nations with more units than supply centres.
+func fillAdjustments(adj gameState, policy NMRPolicy, log nmrLog) {
+	if !policy.includes(NMRCivilDisorder) {
+		return
+	}
+	scs := map[godip.Nation]int{}
+	for _, n := range adj.SupplyCenters() {
+		scs[n]++
+	}
+	units := map[godip.Nation]int{}
+	for _, u := range adj.Units() {
+		units[u.Nation]++
+	}
+	disbands := map[godip.Nation]int{}
+	staged := adj.Orders()
+	for prov, o := range staged {
+		if o.Type() != godip.Disband {
+			continue
+		}
+		if u, ok := unitAt(adj.Units(), prov); ok {
+			disbands[u.Nation]++
+		}
+	}
+	nations := make(map[godip.Nation]bool)
+	for n := range scs {
+		nations[n] = true
+	}
+	for n := range units {
+		nations[n] = true
+	}
+	for n := range nations {
+		if n == "" || n == godip.Neutral {
+			continue
+		}
+		owed := units[n] - scs[n] - disbands[n]
+		if owed <= 0 {
+			continue
+		}
+		order, err := adj.CivilDisorder(n)
+		if err != nil {
+			continue
+		}
+		for _, prov := range order {
+			if owed == 0 {
+				break
+			}
+			if _, ordered := staged[prov]; ordered {
+				continue
+			}
+			disband := disbandOrder(prov)
+			adj.SetOrder(prov, disband)
+			log.add(n, nmrText(prov, disband, adj.Units(), adj.Units()))
+			owed--
+		}
+	}
+}
+
+// disbandOrder returns a godip disband order for prov.
+func disbandOrder(prov godip.Province) adjOrder {
+	return orders.Disband(prov, time.Now())
+}
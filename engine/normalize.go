@@ -0,0 +1,454 @@
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/zond/godip"
+	"github.com/zond/godip/variants/classical"
+	"github.com/zond/godip/variants/common"
+)
+
+// orderNormalizer rewrites the loose order text players type — full names,
+// misspellings, arrows, words and coast notations, with or without unit
+// types — into the canonical form the order parsers accept, e.g.
+// "army vienna -> budapest" becomes "A Vie-Bud" and "A Par supports Mar to
+// Bur" becomes "A Par S A Mar-Bur".
+type orderNormalizer struct {
+	// names maps lower-case abbreviations, long names and aliases to
+	// provinces (never coasts).
+	names map[string]godip.Province
+	// provinces holds every province of the graph, coasts included.
+	provinces map[godip.Province]bool
+	// hyphenated lists multi-word names written with hyphens, such as
+	// "mid-atlantic", so they are not mistaken for moves.
+	hyphenated []string
+}
+
+// classicalAliases are names players commonly use for classical provinces
+// that are neither the abbreviation nor godip's long name.
+var classicalAliases = map[string]godip.Province{
+	"mao": "mid", "mid atlantic ocean": "mid", "mid atlantic": "mid",
+	"nao": "nat", "north atlantic ocean": "nat",
+	"lyo": "gol", "gulf of lyons": "gol", "lyon": "gol", "lyons": "gol",
+	"eastern mediterranean": "eas", "east mediterranean": "eas", "emed": "eas",
+	"western mediterranean": "wes", "west med": "wes", "wmed": "wes",
+	"skagerrak": "ska", "helgoland": "hel", "heligoland": "hel",
+	"petersburg": "stp", "st petersburg": "stp", "saint petersburg": "stp",
+	"channel": "eng", "english": "eng", "norwegian": "nrg", "nwg": "nrg", "tyrrhenian": "tys",
+	"adriatic": "adr", "aegean": "aeg", "ionian": "ion", "baltic": "bal",
+	"barents": "bar", "irish": "iri", "bothnia": "bot", "gob": "bot",
+	"constantinople": "con", "istanbul": "con", "smyrna": "smy",
+}
+
+func newOrderNormalizer(v common.Variant) *orderNormalizer {
+	n := &orderNormalizer{
+		names:     make(map[string]godip.Province),
+		provinces: make(map[godip.Province]bool),
+	}
+	graph := v.Graph()
+	for _, p := range graph.Provinces() {
+		n.provinces[p] = true
+		if p.Super() == p {
+			n.names[strings.ToLower(string(p))] = p
+		}
+	}
+	for p, long := range v.ProvinceLongNames {
+		if p.Super() != p {
+			continue
+		}
+		name := cleanName(long)
+		n.names[strings.Replace(name, "-", " ", -1)] = p
+		if strings.Contains(name, "-") {
+			n.hyphenated = append(n.hyphenated, name)
+		}
+	}
+	if v.Name == classical.ClassicalVariant.Name {
+		for alias, p := range classicalAliases {
+			if n.provinces[p] {
+				if _, taken := n.names[alias]; !taken {
+					n.names[alias] = p
+				}
+			}
+		}
+	}
+	return n
+}
+
+var parenthetical = regexp.MustCompile(`\s*\([^)]*\)`)
+
+// cleanName lower-cases a long name and drops punctuation and parenthetical
+// notes, e.g. "St. Petersburg" becomes "st petersburg".
+func cleanName(long string) string {
+	name := strings.ToLower(parenthetical.ReplaceAllString(long, ""))
+	name = strings.NewReplacer(".", "", ",", "", "'", "").Replace(name)
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Keywords recognised between provinces, mapped to their canonical token.
+const (
+	kwArmy    = "A"
+	kwFleet   = "F"
+	kwHold    = "H"
+	kwSupport = "S"
+	kwConvoy  = "C"
+	kwMove    = "-"
+	kwVia     = "via"
+	kwBuild   = "build"
+	kwDisband = "disband"
+)
+
+var orderKeywords = map[string]string{
+	"a": kwArmy, "army": kwArmy,
+	"f": kwFleet, "fleet": kwFleet,
+	"h": kwHold, "hold": kwHold, "holds": kwHold, "stand": kwHold, "stands": kwHold,
+	"s": kwSupport, "sup": kwSupport, "supp": kwSupport, "support": kwSupport, "supports": kwSupport,
+	"c": kwConvoy, "convoy": kwConvoy, "convoys": kwConvoy,
+	"-": kwMove, "to": kwMove, "m": kwMove, "move": kwMove, "moves": kwMove, "into": kwMove,
+	"attack": kwMove, "attacks": kwMove, "r": kwMove, "retreat": kwMove, "retreats": kwMove,
+	"via": kwVia, "by": kwVia,
+	"build": kwBuild, "builds": kwBuild,
+	"d": kwDisband, "disband": kwDisband, "disbands": kwDisband, "remove": kwDisband, "removes": kwDisband,
+}
+
+var coastWords = map[string]string{"nc": "nc", "sc": "sc", "ec": "ec", "wc": "wc"}
+
+var (
+	arrows       = strings.NewReplacer("→", "-", "->", "-", "=>", "-", "—", "-", "–", "-", ">", "-")
+	coastParens  = regexp.MustCompile(`\s*\(\s*(n|s|e|w)c\s*\)`)
+	coastWritten = regexp.MustCompile(`\s*\(?\s*(north|south|east|west)(ern)?\s+coast\s*\)?`)
+	coastSlash   = regexp.MustCompile(`\s*/\s*`)
+)
+
+// tokens splits text into lower-case words, with moves as "-" and coasts
+// attached to their province as "stp/nc".
+func (n *orderNormalizer) tokens(text string) []string {
+	s := strings.ToLower(strings.TrimSpace(text))
+	s = strings.NewReplacer(".", " ", ",", " ", ":", " ").Replace(s)
+	for _, name := range n.hyphenated {
+		s = strings.Replace(s, name, strings.Replace(name, "-", " ", -1), -1)
+	}
+	s = arrows.Replace(s)
+	s = coastParens.ReplaceAllString(s, "/${1}c")
+	s = coastWritten.ReplaceAllStringFunc(s, func(m string) string {
+		return "/" + strings.TrimSpace(strings.Trim(m, " ()"))[:1] + "c"
+	})
+	s = coastSlash.ReplaceAllString(s, "/")
+	s = strings.Replace(s, "-", " - ", -1)
+	return strings.Fields(s)
+}
+
+// normalize returns the canonical text for orderText. units are the units
+// on the board and ordered the units being ordered (dislodged units during
+// Retreat); both are used to fill in unit types the player left out.
+func (n *orderNormalizer) normalize(orderText string, ordered, units map[godip.Province]godip.Unit) (string, error) {
+	p := &orderScanner{n: n, toks: n.tokens(orderText)}
+	if len(p.toks) == 0 {
+		return "", fmt.Errorf("empty order")
+	}
+	lead := p.keyword()
+	if lead == kwBuild || lead == kwDisband {
+		p.next()
+	}
+	unit := p.unitType()
+	src, err := p.province()
+	if err != nil {
+		return "", err
+	}
+	var text string
+	switch lead {
+	case kwBuild:
+		if unit == "" {
+			return "", fmt.Errorf("build needs a unit type, e.g. \"build A %s\"", provName(src))
+		}
+		text = fmt.Sprintf("build %s %s", unit, provName(src))
+	case kwDisband:
+		src, unit = boardUnit(ordered, src, unit)
+		text = fmt.Sprintf("%s %s disband", unit, provName(src))
+	default:
+		src, unit = boardUnit(ordered, src, unit)
+		text, err = p.action(src, unit, units)
+		if err != nil {
+			return "", err
+		}
+	}
+	if !p.done() {
+		return "", fmt.Errorf("unexpected %q", strings.Join(p.toks[p.i:], " "))
+	}
+	return text, nil
+}
+
+// NormalizeOrder returns the canonical form of orderText, filling in unit
+// types from the board. Games without a normaliser return the text as is.
+func (g *game) NormalizeOrder(_, orderText string) (string, error) {
+	if g.norm == nil {
+		return orderText, nil
+	}
+	units := g.adj.Units()
+	ordered := units
+	if phase := g.adj.Phase(); phase != nil && phase.Type() == godip.Retreat {
+		ordered = g.adj.Dislodgeds()
+	}
+	text, err := g.norm.normalize(orderText, ordered, units)
+	if err != nil {
+		return "", fmt.Errorf("engine: parse order: invalid order %q: %w", orderText, err)
+	}
+	return text, nil
+}
+
+// NormalizeProvince returns the lower-case province, without coast, that
+// name refers to. Games without a normaliser return name in lower case.
+func (g *game) NormalizeProvince(name string) (string, error) {
+	if g.norm == nil {
+		return strings.ToLower(strings.TrimSpace(name)), nil
+	}
+	p := &orderScanner{n: g.norm, toks: g.norm.tokens(name)}
+	prov, err := p.province()
+	if err == nil && !p.done() {
+		err = fmt.Errorf("unexpected %q", strings.Join(p.toks[p.i:], " "))
+	}
+	if err != nil {
+		return "", fmt.Errorf("engine: invalid province %q: %w", name, err)
+	}
+	return string(prov.Super()), nil
+}
+
+// orderScanner walks the tokens of one order.
+type orderScanner struct {
+	n    *orderNormalizer
+	toks []string
+	i    int
+}
+
+func (p *orderScanner) done() bool { return p.i >= len(p.toks) }
+func (p *orderScanner) next()      { p.i++ }
+
+// keyword returns the canonical keyword at the cursor, or "".
+func (p *orderScanner) keyword() string {
+	if p.done() {
+		return ""
+	}
+	return orderKeywords[p.toks[p.i]]
+}
+
+// unitType consumes an optional unit type and returns "A", "F" or "".
+func (p *orderScanner) unitType() string {
+	if kw := p.keyword(); kw == kwArmy || kw == kwFleet {
+		p.next()
+		return kw
+	}
+	return ""
+}
+
+// action parses what follows the ordered unit: hold, disband, a move (with
+// or without a separator), a support or a convoy.
+func (p *orderScanner) action(src godip.Province, unit string, units map[godip.Province]godip.Unit) (string, error) {
+	head := fmt.Sprintf("%s %s", unit, provName(src))
+	switch p.keyword() {
+	case "":
+		if p.done() {
+			return "", fmt.Errorf("no action for %s; add hold, a move, a support or a convoy", provName(src))
+		}
+		return p.move(head)
+	case kwHold:
+		p.next()
+		return head + " H", nil
+	case kwDisband:
+		p.next()
+		return head + " disband", nil
+	case kwMove:
+		p.next()
+		return p.move(head)
+	case kwSupport:
+		p.next()
+		target, targetUnit, err := p.unitAt(units)
+		if err != nil {
+			return "", err
+		}
+		switch p.keyword() {
+		case kwMove:
+			p.next()
+			dst, err := p.province()
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s S %s %s-%s", head, targetUnit, provName(target), provName(dst)), nil
+		case kwHold:
+			p.next()
+		}
+		return fmt.Sprintf("%s S %s %s", head, targetUnit, provName(target)), nil
+	case kwConvoy:
+		p.next()
+		from, _, err := p.unitAt(units)
+		if err != nil {
+			return "", err
+		}
+		if p.keyword() == kwMove {
+			p.next()
+		}
+		dst, err := p.province()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s C A %s-%s", head, provName(from), provName(dst)), nil
+	}
+	return "", fmt.Errorf("unexpected %q after %s", p.toks[p.i], provName(src))
+}
+
+// move parses a destination and an optional "via convoy".
+func (p *orderScanner) move(head string) (string, error) {
+	dst, err := p.province()
+	if err != nil {
+		return "", err
+	}
+	text := fmt.Sprintf("%s-%s", head, provName(dst))
+	if p.keyword() == kwVia {
+		p.next()
+		if p.keyword() != kwConvoy {
+			return "", fmt.Errorf("expected \"convoy\" after \"via\"")
+		}
+		p.next()
+		text += " via convoy"
+	}
+	return text, nil
+}
+
+// unitAt parses an optional unit type and a province, taking the type from
+// the board when there is a unit there.
+func (p *orderScanner) unitAt(units map[godip.Province]godip.Unit) (godip.Province, string, error) {
+	unit := p.unitType()
+	prov, err := p.province()
+	if err != nil {
+		return "", "", err
+	}
+	prov, unit = boardUnit(units, prov, unit)
+	return prov, unit, nil
+}
+
+// province consumes the province at the cursor, trying the longest run of
+// words first and exact names before misspellings.
+func (p *orderScanner) province() (godip.Province, error) {
+	if p.done() {
+		return "", fmt.Errorf("expected a province")
+	}
+	for _, fuzzy := range []bool{false, true} {
+		for size := min(4, len(p.toks)-p.i); size > 0; size-- {
+			words := append([]string(nil), p.toks[p.i:p.i+size]...)
+			if containsKeyword(words) {
+				continue
+			}
+			var coast string
+			last := words[size-1]
+			if j := strings.Index(last, "/"); j >= 0 {
+				words[size-1], coast = last[:j], last[j+1:]
+			}
+			prov, ok := p.n.lookup(strings.Join(words, " "), fuzzy)
+			if !ok {
+				continue
+			}
+			p.i += size
+			if coast == "" && !p.done() && coastWords[p.toks[p.i]] != "" {
+				coast = coastWords[p.toks[p.i]]
+				p.next()
+			}
+			if coast == "" {
+				return prov, nil
+			}
+			withCoast := godip.Province(string(prov) + "/" + coast)
+			if !p.n.provinces[withCoast] {
+				return "", fmt.Errorf("%s has no %s coast", provName(prov), coast)
+			}
+			return withCoast, nil
+		}
+	}
+	return "", fmt.Errorf("unknown province %q", p.toks[p.i])
+}
+
+func containsKeyword(words []string) bool {
+	for _, w := range words {
+		if _, ok := orderKeywords[w]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup resolves name exactly or, if fuzzy is set, to the single closest
+// name within a small edit distance.
+func (n *orderNormalizer) lookup(name string, fuzzy bool) (godip.Province, bool) {
+	if prov, ok := n.names[name]; ok {
+		return prov, true
+	}
+	if !fuzzy || len(name) < 4 {
+		return "", false
+	}
+	limit := 1
+	if len(name) > 6 {
+		limit = 2
+	}
+	best, bestDist, tie := godip.Province(""), limit+1, false
+	for candidate, prov := range n.names {
+		d := editDistance(name, candidate)
+		switch {
+		case d < bestDist:
+			best, bestDist, tie = prov, d, false
+		case d == bestDist && prov != best:
+			tie = true
+		}
+	}
+	if bestDist > limit || tie {
+		return "", false
+	}
+	return best, true
+}
+
+// editDistance is the optimal string alignment distance between a and b:
+// insertions, deletions, substitutions and adjacent transpositions.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// boardUnit returns the province key and unit letter of the unit at prov
+// when there is one, keeping a coast and unit type the player named;
+// otherwise it returns prov and the player's unit letter, defaulting to an
+// army.
+func boardUnit(units map[godip.Province]godip.Unit, prov godip.Province, given string) (godip.Province, string) {
+	for p, u := range units {
+		if p.Super() != prov.Super() {
+			continue
+		}
+		if prov == prov.Super() {
+			prov = p
+		}
+		if given == "" {
+			given = unitLetter(u.Type)
+		}
+		return prov, given
+	}
+	if given == "" {
+		given = kwArmy
+	}
+	return prov, given
+}
@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zond/godip"
+)
+
+// ValidOrders returns every legal order nation can give in the current phase,
+// as sorted canonical order text accepted by SubmitOrder — for example
+// "A Vie-Bud", "F Nth C A Lon-Nwy", "A Mar S A Par-Bur", "build F Stp/nc" or
+// "A Bur disband". The list is built from godip's option tree, so it covers
+// moves, supports, convoys, retreats, builds and disbands including coasts.
+func (g *game) ValidOrders(nation string) []string {
+	phase := g.adj.Phase()
+	if phase == nil {
+		return nil
+	}
+	units := g.adj.Units()
+	if phase.Type() == godip.Retreat {
+		units = g.adj.Dislodgeds()
+	}
+	seen := make(map[string]bool)
+	for _, byType := range g.adj.Options(godip.Nation(nation)) {
+		for typ, tree := range byType {
+			orderType, ok := typ.(godip.OrderType)
+			if !ok {
+				continue
+			}
+			for _, path := range optionPaths(tree) {
+				if text := formatOption(orderType, path, units, g.adj.Units()); text != "" {
+					seen[text] = true
+				}
+			}
+		}
+	}
+	result := make([]string, 0, len(seen))
+	for text := range seen {
+		result = append(result, text)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// optionPaths flattens a godip option tree into its root-to-leaf paths,
+// unwrapping filtered values (used by godip for build and disband limits).
+func optionPaths(opts godip.Options) [][]godip.OptionValue {
+	var paths [][]godip.OptionValue
+	for key, child := range opts {
+		if f, ok := key.(godip.FilteredOptionValue); ok {
+			key = f.Value
+		}
+		if len(child) == 0 {
+			paths = append(paths, []godip.OptionValue{key})
+			continue
+		}
+		for _, rest := range optionPaths(child) {
+			paths = append(paths, append([]godip.OptionValue{key}, rest...))
+		}
+	}
+	return paths
+}
+
+// formatOption renders one option path as canonical order text. units holds
+// the ordered units (dislodged units during Retreat); board holds the units
+// on the board, used to name the unit being supported. Returns "" for paths
+// it does not recognise.
+func formatOption(typ godip.OrderType, path []godip.OptionValue, units, board map[godip.Province]godip.Unit) string {
+	provs := make([]godip.Province, 0, len(path))
+	var unitType godip.UnitType
+	for _, v := range path {
+		switch p := v.(type) {
+		case godip.SrcProvince:
+			provs = append(provs, godip.Province(p))
+		case godip.Province:
+			provs = append(provs, p)
+		case godip.UnitType:
+			unitType = p
+		}
+	}
+	if len(provs) == 0 {
+		return ""
+	}
+	src := provs[0]
+	if typ == godip.Build {
+		if unitType == "" {
+			return ""
+		}
+		return fmt.Sprintf("build %s %s", unitLetter(unitType), provName(src))
+	}
+	u, ok := unitAt(units, src)
+	if !ok {
+		return ""
+	}
+	letter := unitLetter(u.Type)
+	switch {
+	case typ == godip.Hold && len(provs) == 1:
+		return fmt.Sprintf("%s %s H", letter, provName(src))
+	case typ == godip.Disband && len(provs) == 1:
+		return fmt.Sprintf("%s %s disband", letter, provName(src))
+	case typ == godip.Move && len(provs) == 2:
+		return fmt.Sprintf("%s %s-%s", letter, provName(src), provName(provs[1]))
+	case typ == godip.MoveViaConvoy && len(provs) == 2:
+		return fmt.Sprintf("%s %s-%s via convoy", letter, provName(src), provName(provs[1]))
+	case typ == godip.Convoy && len(provs) == 3:
+		return fmt.Sprintf("%s %s C A %s-%s", letter, provName(src), provName(provs[1]), provName(provs[2]))
+	case typ == godip.Support && len(provs) == 3:
+		supported, ok := unitAt(board, provs[1])
+		if !ok {
+			return ""
+		}
+		if provs[1].Super() == provs[2].Super() {
+			return fmt.Sprintf("%s %s S %s %s", letter, provName(src), unitLetter(supported.Type), provName(provs[1]))
+		}
+		return fmt.Sprintf("%s %s S %s %s-%s", letter, provName(src), unitLetter(supported.Type), provName(provs[1]), provName(provs[2]))
+	}
+	return ""
+}
+
+// unitLetter returns the one-letter abbreviation for a unit type.
+func unitLetter(t godip.UnitType) string {
+	if t == godip.Fleet {
+		return "F"
+	}
+	return "A"
+}
+
+// provName capitalises a province abbreviation for display, e.g. "stp/nc"
+// becomes "Stp/nc".
+func provName(p godip.Province) string {
+	s := string(p)
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,180 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zond/godip"
+)
+
+// Outcome classifies how a single order fared during adjudication.
+type Outcome string
+
+const (
+	// OutcomeSucceeded means the order was carried out as written.
+	OutcomeSucceeded Outcome = "succeeded"
+	// OutcomeBounced means a move (or retreat) was blocked by a standoff.
+	OutcomeBounced Outcome = "bounced"
+	// OutcomeSupportCut means a support order was cut by an attack.
+	OutcomeSupportCut Outcome = "support cut"
+	// OutcomeDislodged means the ordered unit was dislodged by an attacker.
+	OutcomeDislodged Outcome = "dislodged"
+	// OutcomeConvoyDisrupted means a convoy failed or the convoyed army had no path.
+	OutcomeConvoyDisrupted Outcome = "convoy disrupted"
+	// OutcomeVoid means the order was illegal or did not match the supported
+	// unit's order, so it was discarded.
+	OutcomeVoid Outcome = "void"
+	// OutcomeFailed is used when godip reports a failure we do not classify.
+	OutcomeFailed Outcome = "failed"
+)
+
+// classifyResolution maps a godip resolution error to an Outcome and, where
+// godip names one, the province responsible for the failure.
+func classifyResolution(err error) (Outcome, string) {
+	if err == nil {
+		return OutcomeSucceeded, ""
+	}
+	var bounce godip.ErrBounce
+	if errors.As(err, &bounce) {
+		return OutcomeBounced, string(bounce.Province)
+	}
+	var cut godip.ErrSupportBroken
+	if errors.As(err, &cut) {
+		return OutcomeSupportCut, string(cut.Province)
+	}
+	var convoy godip.ErrConvoyDislodged
+	if errors.As(err, &convoy) {
+		return OutcomeConvoyDisrupted, string(convoy.Province)
+	}
+	switch err {
+	case godip.ErrMissingConvoyPath, godip.ErrConvoyParadox:
+		return OutcomeConvoyDisrupted, ""
+	case godip.ErrInvalidSupporteeOrder:
+		return OutcomeVoid, ""
+	}
+	if isValidationError(err) {
+		return OutcomeVoid, ""
+	}
+	return OutcomeFailed, ""
+}
+
+// validationErrors are the godip errors raised when an order is sanitised
+// away before adjudication, each with the reason players are shown.
+var validationErrors = map[error]string{
+	godip.ErrInvalidSource:                   "the unit's province is not on the map",
+	godip.ErrInvalidDestination:              "the destination is not on the map",
+	godip.ErrInvalidTarget:                   "a province named is not on the map",
+	godip.ErrInvalidPhase:                    "that order is not allowed in this phase",
+	godip.ErrMissingUnit:                     "there is no unit there",
+	godip.ErrIllegalDestination:              "the unit cannot reach its destination",
+	godip.ErrIllegalMove:                     "the unit cannot move there",
+	godip.ErrIllegalSupportPosition:          "the supporting unit cannot reach the province it supports",
+	godip.ErrIllegalSupportDestination:       "the supporting unit cannot reach the province it supports",
+	godip.ErrIllegalSupportDestinationNation: "that province cannot be supported into",
+	godip.ErrMissingSupportUnit:              "there is no unit to support",
+	godip.ErrIllegalSupportMove:              "the supported unit cannot reach its destination",
+	godip.ErrIllegalConvoyUnit:               "only a fleet can convoy",
+	godip.ErrIllegalConvoyPath:               "the fleet cannot convoy from a coast",
+	godip.ErrIllegalConvoyMove:               "there is no sea route for the convoy",
+	godip.ErrMissingConvoyee:                 "there is no army to convoy",
+	godip.ErrIllegalConvoyer:                 "only a fleet can convoy",
+	godip.ErrIllegalConvoyee:                 "only an army can be convoyed",
+	godip.ErrIllegalBuild:                    "the build is not allowed",
+	godip.ErrIllegalDisband:                  "the disband is not allowed",
+	godip.ErrOccupiedSupplyCenter:            "the supply centre is occupied",
+	godip.ErrMissingSupplyCenter:             "units can only be built in a supply centre",
+	godip.ErrMissingSurplus:                  "the nation has no builds left",
+	godip.ErrIllegalUnitType:                 "that unit type cannot be built there",
+	godip.ErrMissingDeficit:                  "the nation has no units to remove",
+	godip.ErrOccupiedDestination:             "the destination is occupied",
+	godip.ErrIllegalRetreat:                  "the unit cannot retreat there",
+	godip.ErrHostileSupplyCenter:             "units can only be built in an owned home supply centre",
+}
+
+func isValidationError(err error) bool {
+	if _, ok := validationReason(err); ok {
+		return true
+	}
+	var double godip.ErrDoubleBuild
+	return errors.As(err, &double)
+}
+
+// validationReason returns the reason validationErrors gives for err. It
+// compares rather than indexes, since errors such as godip.ErrDoubleBuild
+// cannot be map keys.
+func validationReason(err error) (string, bool) {
+	for v, reason := range validationErrors {
+		if err == v {
+			return reason, true
+		}
+	}
+	return "", false
+}
+
+// resolutionReason returns why godip's resolution err made an order fail,
+// in words players can read, falling back to godip's error name.
+func resolutionReason(err error) string {
+	if reason, ok := validationReason(err); ok {
+		return reason
+	}
+	var double godip.ErrDoubleBuild
+	if errors.As(err, &double) {
+		return "a unit is already being built there"
+	}
+	switch err {
+	case godip.ErrInvalidSupporteeOrder:
+		return "the supported unit was ordered to do something else"
+	case godip.ErrMissingConvoyPath:
+		return "there is no convoy route"
+	case godip.ErrConvoyParadox:
+		return "the convoy is part of a paradox"
+	}
+	return err.Error()
+}
+
+// dislodgerOf returns the source province of a successful move into prov,
+// or "" if no such move was adjudicated. Only meaningful in Movement phases.
+func dislodgerOf(prov godip.Province, applied map[godip.Province]adjOrder, resolutions map[godip.Province]error) godip.Province {
+	for src, ord := range applied {
+		order, ok := ord.(godip.Order)
+		if !ok || order.Type() != godip.Move || src.Super() == prov.Super() {
+			continue
+		}
+		targets := order.Targets()
+		if len(targets) < 2 || targets[1].Super() != prov.Super() {
+			continue
+		}
+		if err, resolved := resolutions[src]; resolved && err == nil {
+			return src
+		}
+	}
+	return ""
+}
+
+// orderText returns the text a player submitted for prov, falling back to
+// godip's description of the order for orders staged by other means.
+func (g *game) orderText(prov godip.Province, ord adjOrder) string {
+	if s, ok := g.staged[prov]; ok && s.text != "" {
+		return s.text
+	}
+	if str, ok := ord.(fmt.Stringer); ok {
+		return str.String()
+	}
+	return string(ord.Type())
+}
+
+// orderNation returns the nation that submitted the order at prov, falling
+// back to the owner of the unit (or dislodged unit) found there before
+// adjudication.
+func (g *game) orderNation(prov godip.Province, units, dislodgeds map[godip.Province]godip.Unit) string {
+	if s, ok := g.staged[prov]; ok && s.nation != "" {
+		return string(s.nation)
+	}
+	if u, ok := dislodgeds[prov]; ok {
+		return string(u.Nation)
+	}
+	if u, ok := units[prov]; ok {
+		return string(u.Nation)
+	}
+	return ""
+}
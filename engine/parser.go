@@ -2,8 +2,12 @@ package engine
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/zond/godip"
+	"github.com/zond/godip/orders"
 	"github.com/zond/godip/variants/classical"
 )
 
@@ -25,3 +29,137 @@ func (classicalOrderParser) Parse(_ godip.Nation, orderText string) (godip.Provi
 	}
 	return prov, order, nil
 }
+
+// OrderProvince returns the lower-case province, without coast, of the unit
+// that canonical order text such as "A Vie-Bud", "build F Stp/nc" or
+// "remove A Par" is for. It returns "" for text that names no unit, such
+// as "Waive". A nation has at most one order per unit, so the result is a
+// key for its staged orders.
+func OrderProvince(orderText string) string {
+	fields := strings.Fields(orderText)
+	if len(fields) > 0 && (strings.EqualFold(fields[0], "build") || strings.EqualFold(fields[0], "remove")) {
+		fields = fields[1:]
+	}
+	if len(fields) > 1 && (strings.EqualFold(fields[0], "A") || strings.EqualFold(fields[0], "F")) {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	prov := strings.SplitN(fields[0], "-", 2)[0]
+	prov = strings.SplitN(prov, "/", 2)[0]
+	if prov == "" || strings.EqualFold(prov, "waive") {
+		return ""
+	}
+	return strings.ToLower(prov)
+}
+
+// variantOrderParser parses the same order grammar as classical.DATCOrder
+// ("A Vie-Bud", "F Nth C A Lon-Nwy", "build A Par", ...) but resolves
+// province names against a variant's own graph.
+type variantOrderParser struct {
+	provinces map[string]godip.Province
+}
+
+func newVariantOrderParser(g godip.Graph) *variantOrderParser {
+	return &variantOrderParser{provinces: provincesOf(g)}
+}
+
+// variantOrderRules are tried in order; the first matching pattern wins.
+var variantOrderRules = []struct {
+	re    *regexp.Regexp
+	build func(p *variantOrderParser, m []string) (godip.Province, godip.Adjudicator, error)
+}{
+	{regexp.MustCompile(`(?i)^(A|F)\s+(\S+)\s*-\s*(\S+)(\s+via\s+convoy)?$`), func(p *variantOrderParser, m []string) (godip.Province, godip.Adjudicator, error) {
+		provs, err := p.lookup(m[2], m[3])
+		if err != nil {
+			return "", nil, err
+		}
+		if m[4] != "" {
+			return provs[0], orders.Move(provs[0], provs[1]).ViaConvoy(), nil
+		}
+		return provs[0], orders.Move(provs[0], provs[1]), nil
+	}},
+	{regexp.MustCompile(`(?i)^remove\s+((A|F)\s+)?(\S+)$`), func(p *variantOrderParser, m []string) (godip.Province, godip.Adjudicator, error) {
+		provs, err := p.lookup(m[3])
+		if err != nil {
+			return "", nil, err
+		}
+		return provs[0], orders.Disband(provs[0], time.Now()), nil
+	}},
+	{regexp.MustCompile(`(?i)^(A|F)\s+(\S+)\s+disband$`), func(p *variantOrderParser, m []string) (godip.Province, godip.Adjudicator, error) {
+		provs, err := p.lookup(m[2])
+		if err != nil {
+			return "", nil, err
+		}
+		return provs[0], orders.Disband(provs[0], time.Now()), nil
+	}},
+	{regexp.MustCompile(`(?i)^(A|F)\s+(\S+)\s+S(UPP\S*)?\s+(A|F)\s+([^-\s]+)$`), func(p *variantOrderParser, m []string) (godip.Province, godip.Adjudicator, error) {
+		provs, err := p.lookup(m[2], m[5])
+		if err != nil {
+			return "", nil, err
+		}
+		return provs[0], orders.SupportHold(provs[0], provs[1]), nil
+	}},
+	{regexp.MustCompile(`(?i)^(A|F)\s+(\S+)\s+C(ONV\S*)?\s+(A|F)\s+(\S+)\s*-\s*(\S+)$`), func(p *variantOrderParser, m []string) (godip.Province, godip.Adjudicator, error) {
+		provs, err := p.lookup(m[2], m[5], m[6])
+		if err != nil {
+			return "", nil, err
+		}
+		return provs[0], orders.Convoy(provs[0], provs[1], provs[2]), nil
+	}},
+	{regexp.MustCompile(`(?i)^(A|F)\s+(\S+)\s+S(UPP\S*)?\s+((A|F)\s+)?(\S+)\s*-\s*(\S+)$`), func(p *variantOrderParser, m []string) (godip.Province, godip.Adjudicator, error) {
+		provs, err := p.lookup(m[2], m[6], m[7])
+		if err != nil {
+			return "", nil, err
+		}
+		return provs[0], orders.SupportMove(provs[0], provs[1], provs[2]), nil
+	}},
+	{regexp.MustCompile(`(?i)^(A|F)\s+(\S+)\s+H(OLD)?$`), func(p *variantOrderParser, m []string) (godip.Province, godip.Adjudicator, error) {
+		provs, err := p.lookup(m[2])
+		if err != nil {
+			return "", nil, err
+		}
+		return provs[0], orders.Hold(provs[0]), nil
+	}},
+	{regexp.MustCompile(`(?i)^build\s+(A|F)\s+(\S+)$`), func(p *variantOrderParser, m []string) (godip.Province, godip.Adjudicator, error) {
+		provs, err := p.lookup(m[2])
+		if err != nil {
+			return "", nil, err
+		}
+		unitType := godip.Army
+		if strings.EqualFold(m[1], "F") {
+			unitType = godip.Fleet
+		}
+		return provs[0], orders.Build(provs[0], unitType, time.Now()), nil
+	}},
+}
+
+func (p *variantOrderParser) Parse(_ godip.Nation, orderText string) (godip.Province, adjOrder, error) {
+	text := strings.TrimSpace(orderText)
+	for _, rule := range variantOrderRules {
+		m := rule.re.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		prov, order, err := rule.build(p, m)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid order %q: %w", orderText, err)
+		}
+		return prov, order, nil
+	}
+	return "", nil, fmt.Errorf("invalid order %q: unknown order text", orderText)
+}
+
+// lookup resolves each name to a province of the variant's graph.
+func (p *variantOrderParser) lookup(names ...string) ([]godip.Province, error) {
+	provs := make([]godip.Province, 0, len(names))
+	for _, n := range names {
+		prov, ok := p.provinces[strings.ToLower(n)]
+		if !ok {
+			return nil, fmt.Errorf("unknown province %q", n)
+		}
+		provs = append(provs, prov)
+	}
+	return provs, nil
+}
@@ -4,71 +4,80 @@ import "github.com/zond/godip"
 
 // Advance fills NMR orders for any unit without a staged order, advances the
 // game to the next phase via godip Next(), and auto-skips empty retreat or
-// adjustment phases (phases where no unit requires an order).
+// adjustment phases (phases where no unit requires an order). It returns the
+// orders it gave on each nation's behalf, as ResolutionResult.NMR reports
+// them.
 //
 // When Resolve() has already called Next() (g.advanced == true), Advance()
 // clears the flag and only handles empty-phase skipping.
-func (g *game) Advance() error {
+func (g *game) Advance() (map[string][]string, error) {
+	log := nmrLog{}
 	if g.advanced {
-		g.advanced = false
 		// State was already advanced by Resolve(); only skip empty phases.
-		for isEmptyPhase(g.adj) {
-			fillNMR(g.adj)
-			next, err := g.adj.Next()
-			if err != nil {
-				return err
-			}
-			g.adj = next
+		g.advanced = false
+	} else {
+		log.merge(fillNMR(g.adj, g.nmr))
+		next, err := g.adj.Next()
+		if err != nil {
+			return nil, err
 		}
-		return nil
-	}
-
-	fillNMR(g.adj)
-
-	next, err := g.adj.Next()
-	if err != nil {
-		return err
+		g.adj = next
 	}
-	g.adj = next
 
 	// Skip empty retreat phases (no dislodged units) and empty adjustment
 	// phases (no builds/disbands available) by advancing again.
 	for isEmptyPhase(g.adj) {
-		fillNMR(g.adj)
-		next, err = g.adj.Next()
+		log.merge(fillNMR(g.adj, g.nmr))
+		next, err := g.adj.Next()
 		if err != nil {
-			return err
+			return nil, err
 		}
 		g.adj = next
 	}
-	return nil
+	return log.byNation(), nil
 }
 
 // fillNMR stages a default order for every unit that has not yet received an
 // order this phase. During the Retreat phase it also fills default (disband)
-// orders for dislodged units that have no retreat order.
-func fillNMR(adj gameState) {
+// orders for dislodged units that have no retreat order. policy adds the
+// explicit retreat disbands and civil-disorder removals described on
+// NMRPolicy. The orders given are returned by nation.
+func fillNMR(adj gameState, policy NMRPolicy) nmrLog {
+	log := nmrLog{}
 	phase := adj.Phase()
 	if phase == nil {
-		return
+		return log
 	}
 	orders := adj.Orders()
-	for prov := range adj.Units() {
+	units := adj.Units()
+	for prov, u := range units {
 		if _, hasOrder := orders[prov]; !hasOrder {
 			if def := phase.DefaultOrder(prov); def != nil {
 				adj.SetOrder(prov, def)
+				log.add(u.Nation, nmrText(prov, def, units, units))
 			}
 		}
 	}
-	if phase.Type() == godip.Retreat {
-		for prov := range adj.Dislodgeds() {
-			if _, hasOrder := orders[prov]; !hasOrder {
-				if def := phase.DefaultOrder(prov); def != nil {
-					adj.SetOrder(prov, def)
-				}
+	switch phase.Type() {
+	case godip.Retreat:
+		dislodgeds := adj.Dislodgeds()
+		for prov, u := range dislodgeds {
+			if _, hasOrder := orders[prov]; hasOrder {
+				continue
+			}
+			def := phase.DefaultOrder(prov)
+			if def == nil && policy.includes(NMRDisbandRetreats) {
+				def = disbandOrder(prov)
+			}
+			if def != nil {
+				adj.SetOrder(prov, def)
+				log.add(u.Nation, nmrText(prov, def, dislodgeds, units))
 			}
 		}
+	case godip.Adjustment:
+		fillAdjustments(adj, policy, log)
 	}
+	return log
 }
 
 // isEmptyPhase reports whether the current phase requires no player input:
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zond/godip"
+)
+
+// Clone returns an independent copy of the game. The copy is restored from a
+// snapshot of the current state, so it carries the same position, staged
+// orders and adjudication bookkeeping as a reloaded game would.
+func (g *game) Clone() (Engine, error) {
+	return g.cloneWith(true)
+}
+
+// Preview adjudicates orders on a copy of the current position. Each order is
+// first tried for the nation of the unit it names; ErrNotOwned reports that
+// owner, so the order is retried as that nation.
+func (g *game) Preview(orders []string) (ResolutionResult, Engine, error) {
+	eng, err := g.cloneWith(false)
+	if err != nil {
+		return ResolutionResult{}, nil, err
+	}
+	sandbox := eng.(*game)
+	for _, text := range orders {
+		if err := sandbox.submitAsOwner(text); err != nil {
+			return ResolutionResult{}, nil, err
+		}
+	}
+	result, err := sandbox.Resolve()
+	if err != nil {
+		return ResolutionResult{}, nil, fmt.Errorf("engine: preview: %w", err)
+	}
+	return result, sandbox, nil
+}
+
+// cloneWith copies the game through a snapshot round trip, keeping staged
+// orders only if keepOrders is set.
+func (g *game) cloneWith(keepOrders bool) (Engine, error) {
+	name := g.variant
+	if name == "" {
+		name = DefaultVariant
+	}
+	v, err := lookupVariant(name)
+	if err != nil {
+		return nil, fmt.Errorf("engine: clone: %w", err)
+	}
+	w, ok := g.adj.(*stateWrapper)
+	if !ok {
+		return nil, fmt.Errorf("engine: clone: unsupported game state %T", g.adj)
+	}
+	snap, err := w.snapshot()
+	if err != nil {
+		return nil, fmt.Errorf("engine: clone: %w", err)
+	}
+	if !keepOrders {
+		snap.Orders = nil
+	}
+	gs, err := buildStateFromSnapshot(v.Blank(w.st.Phase()), snap, v)
+	if err != nil {
+		return nil, fmt.Errorf("engine: clone: %w", err)
+	}
+	clone := &game{adj: gs, parser: g.parser, norm: g.norm, variant: g.variant, nmr: g.nmr, advanced: g.advanced}
+	if keepOrders && len(g.staged) > 0 {
+		clone.staged = make(map[godip.Province]stagedOrder, len(g.staged))
+		for p, o := range g.staged {
+			clone.staged[p] = o
+		}
+	}
+	return clone, nil
+}
+
+// submitAsOwner stages text for whichever nation owns the ordered unit.
+func (g *game) submitAsOwner(text string) error {
+	err := g.SubmitOrder("", text)
+	var oe *OrderError
+	if errors.As(err, &oe) && oe.Reason == ErrNotOwned && oe.Owner != "" {
+		err = g.SubmitOrder(oe.Owner, text)
+	}
+	return err
+}
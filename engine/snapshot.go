@@ -0,0 +1,290 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zond/godip"
+	"github.com/zond/godip/state"
+	"github.com/zond/godip/variants/common"
+)
+
+// ErrInvalidSnapshot is returned by Load for snapshots that cannot be
+// restored faithfully: malformed JSON, a missing phase, an unsupported schema,
+// or provinces and nations that are not part of the variant.
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// snapshotSchema is the current stateSnapshot format version.
+//
+//	1 (or absent): phase, units, supply centres and dislodgeds only.
+//	2: adds variant, dislodgers, bounces, staged orders, resolutions and
+//	   force-disbands — everything (*state.State).Load accepts.
+const snapshotSchema = 2
+
+// stateSnapshot is the JSON format used to persist and restore game state.
+type stateSnapshot struct {
+	// Schema is the format version; 0 means a version 1 snapshot written
+	// before the field existed.
+	Schema int `json:"schema,omitempty"`
+	// Variant is the variant's name; empty in snapshots written before
+	// variants were recorded, which load as classical.
+	Variant       string                          `json:"variant,omitempty"`
+	Year          int                             `json:"year"`
+	Season        godip.Season                    `json:"season"`
+	PhaseType     godip.PhaseType                 `json:"phase_type"`
+	Units         map[godip.Province]godip.Unit   `json:"units"`
+	SupplyCenters map[godip.Province]godip.Nation `json:"supply_centers"`
+	Dislodgeds    map[godip.Province]godip.Unit   `json:"dislodgeds"`
+	// Dislodgers maps each attacker's province to the province it
+	// dislodged a unit from; retreats may not go back toward the attacker.
+	Dislodgers map[godip.Province]godip.Province `json:"dislodgers,omitempty"`
+	// Bounces maps each province left empty by a standoff to the provinces
+	// the bounced units came from; retreats may not enter them.
+	Bounces map[godip.Province]map[godip.Province]bool `json:"bounces,omitempty"`
+	// Orders holds staged orders as canonical order text.
+	Orders map[godip.Province]string `json:"orders,omitempty"`
+	// Resolutions holds the last adjudication's errors as godip error text;
+	// an empty string records a successful order.
+	Resolutions   map[godip.Province]string `json:"resolutions,omitempty"`
+	ForceDisbands map[godip.Province]bool   `json:"force_disbands,omitempty"`
+}
+
+// validate checks snap against v before anything is loaded: the phase must
+// be one the variant has, and every unit, supply centre, dislodged unit and
+// staged order must name a province of the variant's graph and, where it has
+// one, an owner among the variant's nations.
+func (snap *stateSnapshot) validate(v common.Variant) error {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidSnapshot, fmt.Sprintf(format, args...))
+	}
+	if snap.Schema > snapshotSchema {
+		return invalid("schema %d is newer than supported schema %d", snap.Schema, snapshotSchema)
+	}
+	if snap.Year == 0 {
+		return invalid("no phase year")
+	}
+	if !containsPhaseType(v.PhaseTypes, snap.PhaseType) {
+		return invalid("unknown phase type %q", snap.PhaseType)
+	}
+	if !containsSeason(v.Seasons, snap.Season) {
+		return invalid("unknown season %q", snap.Season)
+	}
+	graph := v.Graph()
+	nations := make(map[godip.Nation]bool, len(v.Nations))
+	for _, n := range v.Nations {
+		nations[n] = true
+	}
+	checkUnits := func(kind string, units map[godip.Province]godip.Unit) error {
+		for p, u := range units {
+			if !graph.Has(p) {
+				return invalid("%s in unknown province %q", kind, p)
+			}
+			if !nations[u.Nation] {
+				return invalid("%s in %s belongs to unknown nation %q", kind, p, u.Nation)
+			}
+			if u.Type != godip.Army && u.Type != godip.Fleet {
+				return invalid("%s in %s has unknown type %q", kind, p, u.Type)
+			}
+		}
+		return nil
+	}
+	if err := checkUnits("unit", snap.Units); err != nil {
+		return err
+	}
+	if err := checkUnits("dislodged unit", snap.Dislodgeds); err != nil {
+		return err
+	}
+	for p, n := range snap.SupplyCenters {
+		if graph.SC(p) == nil {
+			return invalid("supply centre %q is not a supply centre", p)
+		}
+		if n != "" && !nations[n] {
+			return invalid("supply centre %s owned by unknown nation %q", p, n)
+		}
+	}
+	for p := range snap.Orders {
+		if !graph.Has(p) {
+			return invalid("order for unknown province %q", p)
+		}
+	}
+	return nil
+}
+
+func containsPhaseType(list []godip.PhaseType, t godip.PhaseType) bool {
+	for _, v := range list {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSeason(list []godip.Season, s godip.Season) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshot captures everything needed to restore the wrapped state exactly.
+func (w *stateWrapper) snapshot() (*stateSnapshot, error) {
+	st := w.st
+	ph := st.Phase()
+	units, scs, dislodgeds, dislodgers, bounces, _ := st.Dump()
+	resolutions := w.Resolutions()
+	snap := &stateSnapshot{
+		Schema:        snapshotSchema,
+		Variant:       w.variant.Name,
+		Year:          ph.Year(),
+		Season:        ph.Season(),
+		PhaseType:     ph.Type(),
+		Units:         units,
+		SupplyCenters: scs,
+		Dislodgeds:    dislodgeds,
+		Dislodgers:    dislodgers,
+		Bounces:       bounces,
+		ForceDisbands: st.ForceDisbands(),
+	}
+	if len(resolutions) > 0 {
+		snap.Resolutions = make(map[godip.Province]string, len(resolutions))
+		for p, err := range resolutions {
+			snap.Resolutions[p] = ""
+			if err != nil {
+				snap.Resolutions[p] = err.Error()
+			}
+		}
+	}
+	orderedUnits := units
+	if ph.Type() == godip.Retreat {
+		orderedUnits = dislodgeds
+	}
+	for p, o := range st.Orders() {
+		text := canonicalOrder(o, orderedUnits, units)
+		if text == "" {
+			return nil, fmt.Errorf("engine: cannot serialise %v order for %s", o.Type(), p)
+		}
+		if snap.Orders == nil {
+			snap.Orders = make(map[godip.Province]string)
+		}
+		snap.Orders[p] = text
+	}
+	return snap, nil
+}
+
+// restoreState loads the schema 2 bookkeeping from snap into st, which must
+// already hold snap's units, supply centres and dislodgeds. Staged orders are
+// parsed with p. godip offers no way to set resolutions on a state that has
+// not been adjudicated, so they are returned for the wrapper to report.
+func restoreState(st *state.State, snap *stateSnapshot, p orderParser) (map[godip.Province]error, error) {
+	staged := make(map[godip.Province]godip.Adjudicator, len(snap.Orders))
+	for prov, text := range snap.Orders {
+		_, ord, err := p.Parse("", text)
+		if err != nil {
+			return nil, fmt.Errorf("engine: restore order for %s: %w", prov, err)
+		}
+		adj, ok := ord.(godip.Adjudicator)
+		if !ok {
+			return nil, fmt.Errorf("engine: restore order for %s: %q is not adjudicable", prov, text)
+		}
+		staged[prov] = adj
+	}
+	dislodgers := snap.Dislodgers
+	if dislodgers == nil {
+		dislodgers = make(map[godip.Province]godip.Province)
+	}
+	bounces := snap.Bounces
+	if bounces == nil {
+		bounces = make(map[godip.Province]map[godip.Province]bool)
+	}
+	st.Load(st.Units(), st.SupplyCenters(), st.Dislodgeds(), dislodgers, bounces, staged)
+	for prov, forced := range snap.ForceDisbands {
+		if forced {
+			st.ForceDisband(prov)
+		}
+	}
+	var resolutions map[godip.Province]error
+	if len(snap.Resolutions) > 0 {
+		resolutions = make(map[godip.Province]error, len(snap.Resolutions))
+		for prov, text := range snap.Resolutions {
+			resolutions[prov] = decodeResolution(text)
+		}
+	}
+	return resolutions, nil
+}
+
+// canonicalOrder renders a godip order as text the order parsers accept.
+// units holds the ordered units (dislodged units during Retreat) and board
+// the units on the board. Returns "" for orders it cannot render.
+func canonicalOrder(o godip.Adjudicator, units, board map[godip.Province]godip.Unit) string {
+	targets := o.Targets()
+	if len(targets) == 0 {
+		return ""
+	}
+	typ := o.Type()
+	var path []godip.OptionValue
+	switch typ {
+	case godip.Build:
+		// godip does not expose the unit type of a build except via String,
+		// which reads "<province> Build <unit type>".
+		fields := strings.Fields(fmt.Sprint(o))
+		if len(fields) == 0 {
+			return ""
+		}
+		path = append(path, godip.UnitType(fields[len(fields)-1]))
+	case godip.Support:
+		if len(targets) == 2 {
+			// A support-hold names the supported unit as its destination.
+			targets = append(targets, targets[1])
+		}
+	case godip.Move:
+		if o.Flags()[godip.ViaConvoy] {
+			typ = godip.MoveViaConvoy
+		}
+	}
+	path = append(path, godip.SrcProvince(targets[0]))
+	for _, t := range targets[1:] {
+		path = append(path, t)
+	}
+	return formatOption(typ, path, units, board)
+}
+
+// resolutionErrors are godip's sentinel resolution errors, keyed by text, so
+// decodeResolution returns the same values classifyResolution compares with.
+var resolutionErrors = func() map[string]error {
+	m := map[string]error{
+		godip.ErrMissingConvoyPath.Error():     godip.ErrMissingConvoyPath,
+		godip.ErrConvoyParadox.Error():         godip.ErrConvoyParadox,
+		godip.ErrInvalidSupporteeOrder.Error(): godip.ErrInvalidSupporteeOrder,
+	}
+	for err := range validationErrors {
+		m[err.Error()] = err
+	}
+	return m
+}()
+
+// decodeResolution turns resolution text written by snapshot back into an
+// error: nil for success, godip's sentinel or typed error where recognised,
+// and a plain error carrying the text otherwise.
+func decodeResolution(text string) error {
+	if text == "" {
+		return nil
+	}
+	if err, ok := resolutionErrors[text]; ok {
+		return err
+	}
+	if name, prov, ok := strings.Cut(text, ":"); ok {
+		p := godip.Province(prov)
+		switch name {
+		case "ErrBounce":
+			return godip.ErrBounce{Province: p}
+		case "ErrSupportBroken":
+			return godip.ErrSupportBroken{Province: p}
+		case "ErrConvoyDislodged":
+			return godip.ErrConvoyDislodged{Province: p}
+		}
+	}
+	return errors.New(text)
+}
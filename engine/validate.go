@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zond/godip"
+)
+
+// Reasons an order can be rejected when it is staged. OrderError wraps one of
+// these so callers can test with errors.Is.
+var (
+	// ErrNotOwned means the unit (or supply centre, for builds) belongs to
+	// another nation.
+	ErrNotOwned = errors.New("unit belongs to another nation")
+	// ErrEmptyProvince means there is no unit in the ordered province.
+	ErrEmptyProvince = errors.New("no unit in province")
+	// ErrWrongPhase means the order type cannot be given in the current phase.
+	ErrWrongPhase = errors.New("order type not allowed in this phase")
+	// ErrIllegalOrder means godip judged the order illegal for the position,
+	// e.g. a move to a non-adjacent province.
+	ErrIllegalOrder = errors.New("illegal order")
+)
+
+// OrderError reports why SubmitOrder rejected an order.
+type OrderError struct {
+	Nation   string
+	Province string
+	// Owner is the nation that owns the ordered unit, set for ErrNotOwned.
+	Owner string
+	// Reason is one of ErrNotOwned, ErrEmptyProvince, ErrWrongPhase or
+	// ErrIllegalOrder.
+	Reason error
+	// Cause is godip's validation error for ErrIllegalOrder.
+	Cause error
+}
+
+func (e *OrderError) Error() string {
+	switch {
+	case e.Reason == ErrNotOwned && e.Owner != "":
+		return fmt.Sprintf("order for %s rejected: unit belongs to %s, not %s", e.Province, e.Owner, e.Nation)
+	case e.Cause != nil:
+		return fmt.Sprintf("order for %s rejected: %v: %v", e.Province, e.Reason, e.Cause)
+	default:
+		return fmt.Sprintf("order for %s rejected: %v", e.Province, e.Reason)
+	}
+}
+
+// Unwrap exposes Reason so errors.Is(err, ErrNotOwned) works.
+func (e *OrderError) Unwrap() error { return e.Reason }
+
+// phaseOrderTypes lists the order types a player may give in each phase.
+var phaseOrderTypes = map[godip.PhaseType]map[godip.OrderType]bool{
+	godip.Movement:   {godip.Move: true, godip.Hold: true, godip.Support: true, godip.Convoy: true},
+	godip.Retreat:    {godip.Move: true, godip.Disband: true},
+	godip.Adjustment: {godip.Build: true, godip.Disband: true},
+}
+
+// checkOrder verifies that nation may give order for prov in the current
+// phase: the order type must suit the phase, the province must hold one of
+// nation's units (or be one of its supply centres, for builds), and godip's
+// own validation — the same check Next() uses to void orders — must pass.
+func (g *game) checkOrder(nation godip.Nation, prov godip.Province, order adjOrder) error {
+	phase := g.adj.Phase()
+	if phase == nil {
+		return nil
+	}
+	reject := func(reason error) *OrderError {
+		return &OrderError{Nation: string(nation), Province: string(prov), Reason: reason}
+	}
+	if !phaseOrderTypes[phase.Type()][order.Type()] {
+		return reject(ErrWrongPhase)
+	}
+
+	var owner godip.Nation
+	switch {
+	case order.Type() == godip.Build:
+		owner = g.adj.SupplyCenters()[prov.Super()]
+	case phase.Type() == godip.Retreat:
+		u, ok := unitAt(g.adj.Dislodgeds(), prov)
+		if !ok {
+			return reject(ErrEmptyProvince)
+		}
+		owner = u.Nation
+	default:
+		u, ok := unitAt(g.adj.Units(), prov)
+		if !ok {
+			return reject(ErrEmptyProvince)
+		}
+		owner = u.Nation
+	}
+	if owner != nation {
+		e := reject(ErrNotOwned)
+		e.Owner = string(owner)
+		return e
+	}
+
+	if _, err := g.adj.Validate(order); err != nil {
+		e := reject(ErrIllegalOrder)
+		e.Cause = err
+		return e
+	}
+	return nil
+}
+
+// unitAt finds the unit in prov, matching any coast of the province.
+func unitAt(units map[godip.Province]godip.Unit, prov godip.Province) (godip.Unit, bool) {
+	if u, ok := units[prov]; ok {
+		return u, true
+	}
+	for p, u := range units {
+		if p.Super() == prov.Super() {
+			return u, true
+		}
+	}
+	return godip.Unit{}, false
+}
@@ -0,0 +1,206 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/zond/godip"
+	"github.com/zond/godip/variants/classical"
+	"github.com/zond/godip/variants/common"
+)
+
+// DefaultVariant is the variant used when none is named, e.g. for snapshots
+// written before the variant was recorded.
+const DefaultVariant = "classical"
+
+// variantRegistry holds every variant a game can be started with, keyed by
+// lower-case name.
+var variantRegistry = struct {
+	sync.RWMutex
+	byName map[string]common.Variant
+}{
+	byName: map[string]common.Variant{
+		DefaultVariant: classical.ClassicalVariant,
+	},
+}
+
+// RegisterVariant makes v available to New and Load under name (matched
+// case-insensitively). Registering an existing name replaces it.
+func RegisterVariant(name string, v common.Variant) {
+	variantRegistry.Lock()
+	defer variantRegistry.Unlock()
+	variantRegistry.byName[strings.ToLower(name)] = v
+}
+
+// Variants returns the names of all registered variants, sorted.
+func Variants() []string {
+	variantRegistry.RLock()
+	defer variantRegistry.RUnlock()
+	names := make([]string, 0, len(variantRegistry.byName))
+	for name := range variantRegistry.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupVariant returns the variant registered as name, falling back to a
+// variant whose own name matches (snapshots record the variant's own name).
+func lookupVariant(name string) (common.Variant, error) {
+	variantRegistry.RLock()
+	defer variantRegistry.RUnlock()
+	if v, ok := variantRegistry.byName[strings.ToLower(name)]; ok {
+		return v, nil
+	}
+	for _, v := range variantRegistry.byName {
+		if strings.EqualFold(v.Name, name) {
+			return v, nil
+		}
+	}
+	return common.Variant{}, fmt.Errorf("engine: unknown variant %q", name)
+}
+
+// VariantInfo describes a registered variant for display: who plays it, how
+// it is won, and where its map and rules live.
+type VariantInfo struct {
+	// Name is the registry key, e.g. "classical".
+	Name string
+	// DisplayName is the variant's own name, e.g. "Classical".
+	DisplayName string
+	// Nations lists the playable nations, in the variant's own order.
+	Nations []string
+	// NationAbbrevs maps each nation to its abbreviation: the first three
+	// letters of its name, or as many more as tell it apart from the others.
+	NationAbbrevs map[string]string
+	// SoloSCCount is the number of supply centres needed for a solo victory:
+	// the variant's own count, or a majority of the board's supply centres
+	// when it sets none.
+	SoloSCCount int
+	// SupplyCenters is the total number of supply centres on the board.
+	SupplyCenters int
+	// HomeCenters maps each nation to its sorted home supply centres.
+	HomeCenters map[string][]string
+	// ProvinceLongNames maps province abbreviations to full names.
+	ProvinceLongNames map[string]string
+	Description       string
+	Rules             string
+	// StartUnits returns the starting units keyed by province. It sets up
+	// the variant's opening position, so it is only called when needed.
+	StartUnits func() (map[string]UnitInfo, error)
+	// SVGMap returns the variant's map graphics, or nil if it has none.
+	SVGMap func() ([]byte, error)
+}
+
+// LookupVariant returns display information for the registered variant
+// called name. It reads the variant's graph only; starting units are set up
+// on demand by StartUnits.
+func LookupVariant(name string) (VariantInfo, error) {
+	v, err := lookupVariant(name)
+	if err != nil {
+		return VariantInfo{}, err
+	}
+	g := v.Graph()
+	info := VariantInfo{
+		Name:              strings.ToLower(name),
+		DisplayName:       v.Name,
+		SupplyCenters:     len(g.AllSCs()),
+		HomeCenters:       make(map[string][]string),
+		ProvinceLongNames: make(map[string]string),
+		Description:       v.Description,
+		Rules:             v.Rules,
+		StartUnits:        func() (map[string]UnitInfo, error) { return startUnits(v) },
+		SVGMap:            v.SVGMap,
+	}
+	if v.SoloSCCount != nil && v.BlankStart != nil {
+		st, err := v.BlankStart()
+		if err != nil {
+			return VariantInfo{}, fmt.Errorf("engine: start %s: %w", name, err)
+		}
+		info.SoloSCCount = v.SoloSCCount(st)
+	}
+	if info.SoloSCCount == 0 {
+		info.SoloSCCount = info.SupplyCenters/2 + 1
+	}
+	for _, n := range v.Nations {
+		info.Nations = append(info.Nations, string(n))
+		var homes []string
+		for _, p := range g.SCs(n) {
+			homes = append(homes, string(p))
+		}
+		sort.Strings(homes)
+		info.HomeCenters[string(n)] = homes
+	}
+	for prov, long := range v.ProvinceLongNames {
+		info.ProvinceLongNames[string(prov)] = long
+	}
+	info.NationAbbrevs = nationAbbrevs(info.Nations)
+	return info, nil
+}
+
+// nationAbbrevs returns the abbreviation of each of nations: the shortest
+// prefix of its name, at least three letters long, that no other nation's
+// name starts with (case-insensitively), or the whole name if there is none.
+func nationAbbrevs(nations []string) map[string]string {
+	abbrevs := make(map[string]string, len(nations))
+	for _, name := range nations {
+		runes := []rune(name)
+		n := 3
+		for ; n < len(runes); n++ {
+			if !prefixShared(string(runes[:n]), name, nations) {
+				break
+			}
+		}
+		if n > len(runes) {
+			n = len(runes)
+		}
+		abbrevs[name] = string(runes[:n])
+	}
+	return abbrevs
+}
+
+// prefixShared reports whether a nation in nations other than name starts
+// with prefix, ignoring case.
+func prefixShared(prefix, name string, nations []string) bool {
+	for _, other := range nations {
+		if other == name {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(other), strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// startUnits returns v's starting units keyed by province.
+func startUnits(v common.Variant) (map[string]UnitInfo, error) {
+	st, err := v.Start()
+	if err != nil {
+		return nil, fmt.Errorf("engine: start %s: %w", v.Name, err)
+	}
+	units := make(map[string]UnitInfo)
+	for prov, u := range st.Units() {
+		units[string(prov)] = UnitInfo{Type: string(u.Type), Nation: string(u.Nation)}
+	}
+	return units, nil
+}
+
+// parserFor returns the order parser for v. Classical keeps its DATC parser;
+// other variants parse the same grammar against their own province graph.
+func parserFor(v common.Variant) orderParser {
+	if v.Name == classical.ClassicalVariant.Name {
+		return &classicalOrderParser{}
+	}
+	return newVariantOrderParser(v.Graph())
+}
+
+// provincesOf returns the set of provinces in g, keyed by lower-case name.
+func provincesOf(g godip.Graph) map[string]godip.Province {
+	provs := make(map[string]godip.Province)
+	for _, p := range g.Provinces() {
+		provs[strings.ToLower(string(p))] = p
+	}
+	return provs
+}
@@ -0,0 +1,141 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore is a Store that keeps each stream in its own JSONL file under a
+// directory, one Envelope per line. Append syncs the file before it returns,
+// so an event it accepted survives a crash. Methods are safe for concurrent
+// use; a directory must not be shared by two processes.
+type FileStore struct {
+	dir  string
+	mu   sync.Mutex
+	last map[string]int // stream → sequence number of its last event, once read
+}
+
+// NewFileStore returns a FileStore backed by dir, creating the directory if
+// necessary.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("events: create store dir: %w", err)
+	}
+	return &FileStore{dir: dir, last: make(map[string]int)}, nil
+}
+
+// filePath escapes stream so that any stream name is a single file name.
+func (s *FileStore) filePath(stream string) string {
+	return filepath.Join(s.dir, url.QueryEscape(stream)+".jsonl")
+}
+
+// Append writes the event as a new line at the end of stream's file.
+func (s *FileStore) Append(stream string, eventType EventType, payload json.RawMessage) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	last, ok := s.last[stream]
+	if !ok {
+		envs, err := s.read(stream)
+		if err != nil {
+			return 0, err
+		}
+		if len(envs) > 0 {
+			last = envs[len(envs)-1].Seq
+		}
+	}
+
+	env := newEnvelope(eventType, payload, last+1)
+	line, err := json.Marshal(env)
+	if err != nil {
+		return 0, fmt.Errorf("events: encode event: %w", err)
+	}
+	line = append(line, '\n')
+
+	f, err := os.OpenFile(s.filePath(stream), os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("events: open store file: %w", err)
+	}
+	defer f.Close()
+	// A crash part way through an earlier append leaves a line without its
+	// newline; end it so this event starts on a line of its own.
+	if torn, err := unterminated(f); err != nil {
+		return 0, err
+	} else if torn {
+		line = append([]byte("\n"), line...)
+	}
+	if _, err := f.Write(line); err != nil {
+		return 0, fmt.Errorf("events: write store file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return 0, fmt.Errorf("events: sync store file: %w", err)
+	}
+	s.last[stream] = env.Seq
+	return env.Seq, nil
+}
+
+// Read returns the events in stream numbered seq or later. Lines that are
+// not Envelopes, such as one torn by a crash, are skipped.
+func (s *FileStore) Read(stream string, seq int) ([]Envelope, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	envs, err := s.read(stream)
+	if err != nil {
+		return nil, err
+	}
+	for i, env := range envs {
+		if env.Seq >= seq {
+			return envs[i:], nil
+		}
+	}
+	return nil, nil
+}
+
+// read returns every event in stream's file. If the file does not exist,
+// nil is returned with no error.
+func (s *FileStore) read(stream string) ([]Envelope, error) {
+	f, err := os.Open(s.filePath(stream))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("events: open store file: %w", err)
+	}
+	defer f.Close()
+	var envs []Envelope
+	sc := bufio.NewScanner(f)
+	// Snapshot payloads run to tens of kilobytes.
+	sc.Buffer(nil, 16<<20)
+	for sc.Scan() {
+		var env Envelope
+		if err := json.Unmarshal(sc.Bytes(), &env); err != nil || env.Type == "" {
+			continue
+		}
+		envs = append(envs, env)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("events: read store file: %w", err)
+	}
+	return envs, nil
+}
+
+// unterminated reports whether f is not empty and does not end in a newline.
+func unterminated(f *os.File) (bool, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return false, fmt.Errorf("events: stat store file: %w", err)
+	}
+	if info.Size() == 0 {
+		return false, nil
+	}
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil && err != io.EOF {
+		return false, fmt.Errorf("events: read store file: %w", err)
+	}
+	return last[0] != '\n', nil
+}
@@ -3,6 +3,8 @@ package events
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // Channel is the platform-agnostic interface for posting and reading messages
@@ -20,69 +22,153 @@ type Channel interface {
 	PostImage(channelID string, data []byte) error
 }
 
-// Write serialises payload as a JSON Envelope and posts it to channelID.
+// Write serialises payload as a signed JSON Envelope and posts it to
+// channelID, numbered after the last event in the channel's history.
 func Write(ch Channel, channelID string, eventType EventType, payload any) error {
 	raw, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("events: marshal payload: %w", err)
 	}
-	env := Envelope{Type: eventType, Payload: json.RawMessage(raw)}
-	// Envelope contains only string and json.RawMessage fields; Marshal cannot fail.
-	data, _ := json.Marshal(env)
-	return ch.Post(channelID, string(data))
+	_, err = NewChannelStore(ch).Append(ChannelStream(channelID), eventType, raw)
+	return err
 }
 
-// WriteDM serialises payload as a JSON Envelope and sends it to userID's DM thread.
+// WriteDM serialises payload as a signed JSON Envelope and sends it to
+// userID's DM thread, numbered after the last event in the thread.
 func WriteDM(ch Channel, userID string, eventType EventType, payload any) error {
 	raw, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("events: marshal payload: %w", err)
 	}
-	env := Envelope{Type: eventType, Payload: json.RawMessage(raw)}
+	_, err = NewChannelStore(ch).Append(DMStream(userID), eventType, raw)
+	return err
+}
+
+// Scan reads the channel history and returns every message that is a
+// validly signed Envelope, in chronological order, upcast to the current
+// schema version of its type. Messages that are not Envelopes (plain chat
+// text, etc.) are silently skipped; Envelopes that are unsigned, tampered
+// with, out of sequence or cannot be upcast are skipped and returned in
+// rejected.
+func Scan(ch Channel, channelID string) (envs []Envelope, rejected []Rejected, err error) {
+	return NewChannelStore(ch).Verify(ChannelStream(channelID))
+}
+
+// ScanDM reads the user's DM thread and returns every message that is a
+// validly signed Envelope, in chronological order, as Scan does.
+func ScanDM(ch Channel, userID string) (envs []Envelope, rejected []Rejected, err error) {
+	return NewChannelStore(ch).Verify(DMStream(userID))
+}
+
+// ChannelStore is the Store that keeps events in the chat history itself:
+// the events of ChannelStream(id) are posted to channel id and those of
+// DMStream(id) sent to user id, each as a signed JSON Envelope among the
+// players' messages. Since anyone in the chat can post, Read skips envelopes
+// that fail verification; they can only be told from forgeries once SetKey
+// has set a secret key.
+type ChannelStore struct {
+	ch Channel
+}
+
+// NewChannelStore returns a ChannelStore that keeps events in ch.
+func NewChannelStore(ch Channel) *ChannelStore {
+	return &ChannelStore{ch: ch}
+}
+
+// Append signs the event, numbers it after the last valid event in the
+// stream's history and posts it.
+func (s *ChannelStore) Append(stream string, eventType EventType, payload json.RawMessage) (int, error) {
+	dm, id, err := parseStream(stream)
+	if err != nil {
+		return 0, err
+	}
+	messages, err := s.history(dm, id)
+	if err != nil {
+		return 0, err
+	}
+	env := seal(stream, messages, eventType, payload)
+	// Envelope contains only string, int and json.RawMessage fields; Marshal
+	// cannot fail.
 	data, _ := json.Marshal(env)
-	return ch.SendDM(userID, string(data))
+	if dm {
+		err = s.ch.SendDM(id, string(data))
+	} else {
+		err = s.ch.Post(id, string(data))
+	}
+	if err != nil {
+		return 0, err
+	}
+	return env.Seq, nil
 }
 
-// Scan reads the channel history and returns every message that can be parsed
-// as a valid Envelope, in chronological order. Messages that are not valid
-// Envelopes (plain chat text, etc.) are silently skipped.
-func Scan(ch Channel, channelID string) ([]Envelope, error) {
-	messages, err := ch.History(channelID)
+// Read returns the valid events in stream numbered seq or later, upcast as
+// Verify returns them.
+func (s *ChannelStore) Read(stream string, seq int) ([]Envelope, error) {
+	envs, _, err := s.Verify(stream)
 	if err != nil {
-		return nil, fmt.Errorf("events: scan history: %w", err)
+		return nil, err
 	}
-	var envs []Envelope
-	for _, msg := range messages {
-		var env Envelope
-		if err := json.Unmarshal([]byte(msg), &env); err != nil {
-			continue
-		}
-		if env.Type == "" {
-			continue
+	for i, env := range envs {
+		if env.Seq >= seq {
+			return envs[i:], nil
 		}
-		envs = append(envs, env)
 	}
-	return envs, nil
+	return nil, nil
 }
 
-// ScanDM reads the user's DM thread and returns every message that can be
-// parsed as a valid Envelope, in chronological order. Non-event messages are
-// silently skipped.
-func ScanDM(ch Channel, userID string) ([]Envelope, error) {
-	messages, err := ch.DMHistory(userID)
+// Verify returns the valid events in stream, upcast to their current
+// schema versions, and the envelopes in its history that failed
+// verification or could not be upcast.
+func (s *ChannelStore) Verify(stream string) ([]Envelope, []Rejected, error) {
+	dm, id, err := parseStream(stream)
 	if err != nil {
-		return nil, fmt.Errorf("events: scan DM history: %w", err)
+		return nil, nil, err
 	}
-	var envs []Envelope
-	for _, msg := range messages {
-		var env Envelope
-		if err := json.Unmarshal([]byte(msg), &env); err != nil {
-			continue
-		}
-		if env.Type == "" {
-			continue
+	messages, err := s.history(dm, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	envs, at, rejected := verify(stream, messages)
+	envs, failed := upcastAll(envs, at)
+	rejected = append(rejected, failed...)
+	sort.Slice(rejected, func(i, j int) bool { return rejected[i].Index < rejected[j].Index })
+	return envs, rejected, nil
+}
+
+func (s *ChannelStore) history(dm bool, id string) ([]string, error) {
+	if dm {
+		messages, err := s.ch.DMHistory(id)
+		if err != nil {
+			return nil, fmt.Errorf("events: scan DM history: %w", err)
 		}
-		envs = append(envs, env)
+		return messages, nil
+	}
+	messages, err := s.ch.History(id)
+	if err != nil {
+		return nil, fmt.Errorf("events: scan history: %w", err)
+	}
+	return messages, nil
+}
+
+// parseStream splits a stream named by ChannelStream or DMStream into
+// whether it is a DM thread and the channel or user ID.
+func parseStream(stream string) (dm bool, id string, err error) {
+	switch kind, id, _ := strings.Cut(stream, ":"); kind {
+	case "channel":
+		return false, id, nil
+	case "dm":
+		return true, id, nil
+	}
+	return false, "", fmt.Errorf("events: unknown stream %q", stream)
+}
+
+// seal returns the signed envelope for an event of eventType with payload
+// raw, to follow the events in messages, the history of stream.
+func seal(stream string, messages []string, eventType EventType, raw []byte) Envelope {
+	env := newEnvelope(eventType, raw, 1)
+	if envs, _, _ := verify(stream, messages); len(envs) > 0 {
+		env.Seq = envs[len(envs)-1].Seq + 1
 	}
-	return envs, nil
+	env.Sig = signature(stream, env)
+	return env
 }
@@ -94,7 +94,7 @@ func TestScan_ParsesEvents(t *testing.T) {
 	_ = events.Write(ch, "chan1", events.TypeGameCreated, events.GameCreated{Variant: "classical"})
 	_ = events.Write(ch, "chan1", events.TypePlayerJoined, events.PlayerJoined{UserID: "u1", Nation: "England"})
 
-	envs, err := events.Scan(ch, "chan1")
+	envs, _, err := events.Scan(ch, "chan1")
 	is.NoErr(err)
 	is.Equal(len(envs), 2)
 	is.Equal(envs[0].Type, events.TypeGameCreated)
@@ -111,7 +111,7 @@ func TestScan_SkipsNonEventMessages(t *testing.T) {
 	}}
 	_ = events.Write(ch, "chan1", events.TypeGameCreated, events.GameCreated{Variant: "classical"})
 
-	envs, err := events.Scan(ch, "chan1")
+	envs, _, err := events.Scan(ch, "chan1")
 	is.NoErr(err)
 	is.Equal(len(envs), 1)
 	is.Equal(envs[0].Type, events.TypeGameCreated)
@@ -122,7 +122,7 @@ func TestScan_PropagatesHistoryError(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{historyErr: errors.New("history unavailable")}
 
-	_, err := events.Scan(ch, "chan1")
+	_, _, err := events.Scan(ch, "chan1")
 	is.Err(err)
 }
 
@@ -130,7 +130,7 @@ func TestScan_PropagatesHistoryError(t *testing.T) {
 func TestScan_EmptyChannel(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
-	envs, err := events.Scan(ch, "chan1")
+	envs, _, err := events.Scan(ch, "chan1")
 	is.NoErr(err)
 	is.Equal(len(envs), 0)
 }
@@ -172,7 +172,7 @@ func TestRoundTrip_AllEventTypes(t *testing.T) {
 		is.NoErr(events.Write(ch, "chan1", p.typ, p.payload))
 	}
 
-	envs, err := events.Scan(ch, "chan1")
+	envs, _, err := events.Scan(ch, "chan1")
 	is.NoErr(err)
 	is.Equal(len(envs), len(payloads))
 	for i, p := range payloads {
@@ -230,7 +230,7 @@ func TestScanDM_ParsesEvents(t *testing.T) {
 	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England"})
 	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "France"})
 
-	envs, err := events.ScanDM(ch, "u1")
+	envs, _, err := events.ScanDM(ch, "u1")
 	is.NoErr(err)
 	is.Equal(len(envs), 2)
 	is.Equal(envs[0].Type, events.TypeOrderSubmitted)
@@ -245,7 +245,7 @@ func TestScanDM_SkipsNonEventMessages(t *testing.T) {
 	}}
 	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England"})
 
-	envs, err := events.ScanDM(ch, "u1")
+	envs, _, err := events.ScanDM(ch, "u1")
 	is.NoErr(err)
 	is.Equal(len(envs), 1)
 }
@@ -254,7 +254,7 @@ func TestScanDM_SkipsNonEventMessages(t *testing.T) {
 func TestScanDM_PropagatesDMHistoryError(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{dmHistErr: errors.New("dm history unavailable")}
-	_, err := events.ScanDM(ch, "u1")
+	_, _, err := events.ScanDM(ch, "u1")
 	is.Err(err)
 }
 
@@ -262,7 +262,7 @@ func TestScanDM_PropagatesDMHistoryError(t *testing.T) {
 func TestScanDM_EmptyDMThread(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
-	envs, err := events.ScanDM(ch, "u1")
+	envs, _, err := events.ScanDM(ch, "u1")
 	is.NoErr(err)
 	is.Equal(len(envs), 0)
 }
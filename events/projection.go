@@ -0,0 +1,365 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Game is the state of one game folded from its events by Apply. It is the
+// one place each event type is interpreted; the bot and the session both
+// read their state from it.
+type Game struct {
+	Created bool
+	Started bool
+	Ended   bool
+	GMID    string
+	// Variant is the variant named by GameCreated; empty for games created
+	// before variants were recorded.
+	Variant string
+	// NMRPolicy is the policy named by GameCreated; empty means the engine
+	// default.
+	NMRPolicy     string
+	DeadlineHours int
+	Players       map[string]string // userID → nation, leaving out booted players
+	Nations       map[string]string // nation → userID
+	// Phase is the phase named by the last PhaseResolved event, or empty
+	// before the first resolution.
+	Phase string
+	// Snapshot is the engine state carried by the last well-formed
+	// GameStarted or PhaseResolved event, or nil before the game starts.
+	Snapshot json.RawMessage
+	// Pending are the OrderSubmitted events after Snapshot, in order.
+	Pending []OrderSubmitted
+	// Snapshots are the GameStarted and PhaseResolved events, oldest first,
+	// including those whose payload does not decode.
+	Snapshots []Snapshot
+	// History is every PhaseResolved event, oldest first.
+	History []PhaseResolved
+	// Submissions maps a phase, as OrderSubmitted names it, to the
+	// OrderSubmitted events for it in the players' private streams, in the
+	// order each player sent them. ReadDM reads them.
+	Submissions map[string][]OrderSubmitted
+	// Deadline is when the current phase resolves, or zero if no deadline
+	// was set for it.
+	Deadline time.Time
+	// Paused reports whether the GM paused the current phase's deadline.
+	Paused    bool
+	Submitted map[string]bool // nation → true if its orders for the phase are final
+	// NMR maps each nation given orders in the phase last resolved to the
+	// orders it was given.
+	NMR          map[string][]string
+	DrawProposed bool
+	DrawVotes    map[string]bool // nation → true if voted yes
+	// Result and Winner are set from GameEnded.
+	Result string
+	Winner string
+
+	applied int // events applied so far
+}
+
+// Snapshot is a GameStarted or PhaseResolved event in a channel log and the
+// orders submitted in the channel after it.
+type Snapshot struct {
+	// Seq is the event's position in the channel log, counting from zero.
+	Seq   int
+	Type  EventType
+	Phase string
+	State json.RawMessage
+	// Err is set when the payload does not decode.
+	Err error
+	// Pending are the OrderSubmitted events between this snapshot event and
+	// the next, in order.
+	Pending []OrderSubmitted
+}
+
+// NewGame returns the state of a game with no events.
+func NewGame() *Game {
+	return &Game{
+		Players:     make(map[string]string),
+		Nations:     make(map[string]string),
+		Submissions: make(map[string][]OrderSubmitted),
+		Submitted:   make(map[string]bool),
+		NMR:         make(map[string][]string),
+		DrawVotes:   make(map[string]bool),
+	}
+}
+
+// Project folds envs, in order, into a new Game.
+func Project(envs []Envelope) *Game {
+	g := NewGame()
+	for _, env := range envs {
+		g.Apply(env)
+	}
+	return g
+}
+
+// ReadGame returns the state of the game in channelID, folded from its
+// events in st. Events that Events leaves out are returned in rejected.
+func ReadGame(st Store, channelID string) (g *Game, rejected []Rejected, err error) {
+	envs, rejected, err := Events(st, channelID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return Project(envs), rejected, nil
+}
+
+// Apply folds env, the next event of the game's channel log, into g. Events
+// whose payload does not decode are ignored.
+func (g *Game) Apply(env Envelope) {
+	defer func() { g.applied++ }()
+	switch env.Type {
+	case TypeGameCreated:
+		var gc GameCreated
+		if json.Unmarshal(env.Payload, &gc) != nil {
+			return
+		}
+		g.Created = true
+		g.GMID = gc.GMUserID
+		g.Variant = gc.Variant
+		g.NMRPolicy = gc.NMRPolicy
+		g.DeadlineHours = gc.DeadlineHours
+
+	case TypePlayerJoined:
+		var pj PlayerJoined
+		if json.Unmarshal(env.Payload, &pj) != nil {
+			return
+		}
+		g.Players[pj.UserID] = pj.Nation
+		g.Nations[pj.Nation] = pj.UserID
+
+	case TypeGameStarted:
+		g.Started = true
+		var gs GameStarted
+		if err := json.Unmarshal(env.Payload, &gs); err != nil {
+			g.badSnapshot(env.Type, err)
+			return
+		}
+		g.snapshot(env.Type, "", gs.InitialState)
+
+	case TypePhaseResolved:
+		var pr PhaseResolved
+		if err := json.Unmarshal(env.Payload, &pr); err != nil {
+			g.badSnapshot(env.Type, err)
+			return
+		}
+		g.Phase = pr.Phase
+		g.History = append(g.History, pr)
+		g.snapshot(env.Type, pr.Phase, pr.StateSnapshot)
+
+	case TypePhaseSkipped:
+		// The next snapshot carries the phase the game moved on to.
+
+	case TypeOrderSubmitted:
+		var os OrderSubmitted
+		if json.Unmarshal(env.Payload, &os) != nil {
+			return
+		}
+		if g.Snapshot != nil {
+			g.Pending = append(g.Pending, os)
+		}
+		if n := len(g.Snapshots); n > 0 {
+			g.Snapshots[n-1].Pending = append(g.Snapshots[n-1].Pending, os)
+		}
+
+	case TypeDeadlineSet:
+		var ds DeadlineSet
+		if json.Unmarshal(env.Payload, &ds) != nil {
+			return
+		}
+		g.Deadline = ds.DeadlineAt
+
+	case TypeGamePaused:
+		g.Paused = true
+
+	case TypeGameResumed:
+		g.Paused = false
+
+	case TypeSubmissionFinalised:
+		var sf SubmissionFinalised
+		if json.Unmarshal(env.Payload, &sf) != nil {
+			return
+		}
+		g.Submitted[sf.Nation] = true
+
+	case TypeSubmissionWithdrawn:
+		var sw SubmissionWithdrawn
+		if json.Unmarshal(env.Payload, &sw) != nil {
+			return
+		}
+		delete(g.Submitted, sw.Nation)
+
+	case TypeNMRRecorded:
+		var nmr NMRRecorded
+		if json.Unmarshal(env.Payload, &nmr) != nil {
+			return
+		}
+		g.NMR[nmr.Nation] = append(g.NMR[nmr.Nation], nmr.AutoOrders...)
+
+	case TypeDrawProposed:
+		var dp DrawProposed
+		if json.Unmarshal(env.Payload, &dp) != nil {
+			return
+		}
+		g.DrawProposed = true
+		g.DrawVotes[dp.ProposerNation] = true
+
+	case TypeDrawVoted:
+		var dv DrawVoted
+		if json.Unmarshal(env.Payload, &dv) != nil {
+			return
+		}
+		if dv.Accept {
+			g.DrawVotes[dv.Nation] = true
+		} else {
+			delete(g.DrawVotes, dv.Nation)
+		}
+
+	case TypeGameEnded:
+		g.Ended = true
+		g.DrawProposed = false
+		g.DrawVotes = make(map[string]bool)
+		var ge GameEnded
+		if json.Unmarshal(env.Payload, &ge) != nil {
+			return
+		}
+		g.Result = ge.Result
+		g.Winner = ge.Winner
+
+	case TypePlayerBooted:
+		var pb PlayerBooted
+		if json.Unmarshal(env.Payload, &pb) != nil {
+			return
+		}
+		if uid, ok := g.Nations[pb.Nation]; ok {
+			delete(g.Players, uid)
+			delete(g.Nations, pb.Nation)
+		}
+
+	case TypePlayerReplaced:
+		var pr PlayerReplaced
+		if json.Unmarshal(env.Payload, &pr) != nil {
+			return
+		}
+		if uid, ok := g.Nations[pr.Nation]; ok {
+			delete(g.Players, uid)
+		}
+		g.Players[pr.NewUserID] = pr.Nation
+		g.Nations[pr.Nation] = pr.NewUserID
+	}
+}
+
+// ApplyDM folds env, an event from a player's private stream, into g.
+// Events whose payload does not decode are ignored.
+func (g *Game) ApplyDM(env Envelope) {
+	switch env.Type {
+	case TypeOrderSubmitted:
+		var os OrderSubmitted
+		if json.Unmarshal(env.Payload, &os) != nil {
+			return
+		}
+		g.Submissions[os.Phase] = append(g.Submissions[os.Phase], os)
+	}
+}
+
+// SubmittedOrders returns the OrderSubmitted events for phase of the
+// nations whose submission for the current phase is final, in the order
+// Submissions holds them. These are the orders to stage again when a
+// session is reloaded. A Movement submission carries the nation's whole
+// set of orders, so only each nation's latest one is returned; Retreat and
+// Adjustment orders are sent one per event and are all returned.
+func (g *Game) SubmittedOrders(phase string) []OrderSubmitted {
+	latest := make(map[string]int) // nation → index of its last event
+	for i, os := range g.Submissions[phase] {
+		latest[os.Nation] = i
+	}
+	movement := strings.HasSuffix(phase, "Movement")
+	var orders []OrderSubmitted
+	for i, os := range g.Submissions[phase] {
+		if !g.Submitted[os.Nation] || (movement && latest[os.Nation] != i) {
+			continue
+		}
+		orders = append(orders, os)
+	}
+	return orders
+}
+
+// HasSubmitted reports whether nation sent an OrderSubmitted event for
+// phase.
+func (g *Game) HasSubmitted(phase, nation string) bool {
+	for _, os := range g.Submissions[phase] {
+		if os.Nation == nation {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadDM folds the private streams of userIDs in st into g with ApplyDM.
+func (g *Game) ReadDM(st Store, userIDs ...string) error {
+	for _, userID := range userIDs {
+		envs, _, err := DMEvents(st, userID)
+		if err != nil {
+			return err
+		}
+		for _, env := range envs {
+			g.ApplyDM(env)
+		}
+	}
+	return nil
+}
+
+// snapshot starts a new phase from state: orders submitted before it, the
+// previous phase's deadline, pause and submissions, and NMR orders for the
+// phase before are dropped.
+func (g *Game) snapshot(typ EventType, phase string, state json.RawMessage) {
+	g.Snapshots = append(g.Snapshots, Snapshot{Seq: g.applied, Type: typ, Phase: phase, State: state})
+	g.Snapshot = state
+	g.Pending = nil
+	g.Deadline = time.Time{}
+	g.Paused = false
+	g.Submitted = make(map[string]bool)
+	g.NMR = make(map[string][]string)
+}
+
+// badSnapshot records a snapshot event whose payload does not decode. The
+// game stays in the phase it was in.
+func (g *Game) badSnapshot(typ EventType, err error) {
+	g.Snapshots = append(g.Snapshots, Snapshot{
+		Seq:  g.applied,
+		Type: typ,
+		Err:  fmt.Errorf("events: decode %s: %w", typ, err),
+	})
+}
+
+// Restore calls load to restore the engine from g's snapshot, then stages
+// its pending orders.
+//
+// Returns an error if g has no snapshot, if load fails, or if an order
+// cannot be staged.
+func (g *Game) Restore(load Loader) (EngineState, error) {
+	if g.Snapshot == nil {
+		return nil, fmt.Errorf("events: no snapshot found")
+	}
+	eng, err := load(g.Snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("events: load snapshot: %w", err)
+	}
+	if err := stage(eng, g.Pending); err != nil {
+		return nil, err
+	}
+	return eng, nil
+}
+
+// stage submits every order in pending to eng.
+func stage(eng EngineState, pending []OrderSubmitted) error {
+	for _, os := range pending {
+		for _, order := range os.Orders {
+			if err := eng.SubmitOrder(os.Nation, order); err != nil {
+				return fmt.Errorf("events: replay order %q for %s: %w", order, os.Nation, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package events_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/burrbd/dip/events"
+	"github.com/cheekybits/is"
+)
+
+// envelope builds an envelope of eventType carrying payload.
+func envelope(eventType events.EventType, payload any) events.Envelope {
+	raw, _ := json.Marshal(payload)
+	return events.Envelope{Type: eventType, Payload: raw}
+}
+
+func TestProject_GameLifecycle(t *testing.T) {
+	is := is.New(t)
+	g := events.Project([]events.Envelope{
+		envelope(events.TypeGameCreated, events.GameCreated{Variant: "classical", DeadlineHours: 12, GMUserID: "gm1", NMRPolicy: "civil-disorder"}),
+		envelope(events.TypePlayerJoined, events.PlayerJoined{UserID: "u1", Nation: "England"}),
+		envelope(events.TypePlayerJoined, events.PlayerJoined{UserID: "u2", Nation: "France"}),
+		envelope(events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{"s":0}`)}),
+		envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Orders: []string{"F Lon-Nth"}}),
+		envelope(events.TypePhaseResolved, events.PhaseResolved{Phase: "Spring 1901 Movement", StateSnapshot: json.RawMessage(`{"s":1}`)}),
+		envelope(events.TypeNMRRecorded, events.NMRRecorded{Nation: "France", AutoOrders: []string{"A Par H"}}),
+		envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "France", Orders: []string{"A Par-Bur"}}),
+	})
+
+	is.True(g.Created)
+	is.True(g.Started)
+	is.True(!g.Ended)
+	is.Equal(g.GMID, "gm1")
+	is.Equal(g.Variant, "classical")
+	is.Equal(g.NMRPolicy, "civil-disorder")
+	is.Equal(g.DeadlineHours, 12)
+	is.Equal(g.Players, map[string]string{"u1": "England", "u2": "France"})
+	is.Equal(g.Nations, map[string]string{"England": "u1", "France": "u2"})
+	is.Equal(g.Phase, "Spring 1901 Movement")
+	is.Equal(string(g.Snapshot), `{"s":1}`)
+	is.Equal(len(g.Pending), 1)
+	is.Equal(g.Pending[0].Orders, []string{"A Par-Bur"})
+	is.Equal(g.NMR, map[string][]string{"France": {"A Par H"}})
+}
+
+func TestProject_BootAndReplace(t *testing.T) {
+	is := is.New(t)
+	g := events.Project([]events.Envelope{
+		envelope(events.TypePlayerJoined, events.PlayerJoined{UserID: "u1", Nation: "England"}),
+		envelope(events.TypePlayerJoined, events.PlayerJoined{UserID: "u2", Nation: "France"}),
+		envelope(events.TypePlayerJoined, events.PlayerJoined{UserID: "u3", Nation: "Germany"}),
+		envelope(events.TypePlayerBooted, events.PlayerBooted{Nation: "England"}),
+		envelope(events.TypePlayerReplaced, events.PlayerReplaced{Nation: "France", NewUserID: "u9"}),
+		envelope(events.TypePlayerBooted, events.PlayerBooted{Nation: "Italy"}),
+	})
+
+	is.Equal(g.Players, map[string]string{"u9": "France", "u3": "Germany"})
+	is.Equal(g.Nations, map[string]string{"France": "u9", "Germany": "u3"})
+}
+
+func TestProject_Draw(t *testing.T) {
+	is := is.New(t)
+	g := events.Project([]events.Envelope{
+		envelope(events.TypeDrawProposed, events.DrawProposed{ProposerNation: "England"}),
+		envelope(events.TypeDrawVoted, events.DrawVoted{Nation: "France", Accept: true}),
+		envelope(events.TypeDrawVoted, events.DrawVoted{Nation: "Germany", Accept: true}),
+		envelope(events.TypeDrawVoted, events.DrawVoted{Nation: "Germany", Accept: false}),
+	})
+	is.True(g.DrawProposed)
+	is.Equal(g.DrawVotes, map[string]bool{"England": true, "France": true})
+
+	g.Apply(envelope(events.TypeGameEnded, events.GameEnded{Result: "draw"}))
+
+	is.True(g.Ended)
+	is.True(!g.DrawProposed)
+	is.Equal(len(g.DrawVotes), 0)
+	is.Equal(g.Result, "draw")
+}
+
+func TestProject_DeadlinePauseAndSubmissions(t *testing.T) {
+	is := is.New(t)
+	deadline := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	g := events.Project([]events.Envelope{
+		envelope(events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{"s":0}`)}),
+		envelope(events.TypeDeadlineSet, events.DeadlineSet{DeadlineAt: deadline.Add(-time.Hour)}),
+		envelope(events.TypeDeadlineSet, events.DeadlineSet{DeadlineAt: deadline}),
+		envelope(events.TypeSubmissionFinalised, events.SubmissionFinalised{Nation: "England"}),
+		envelope(events.TypeSubmissionFinalised, events.SubmissionFinalised{Nation: "France"}),
+		envelope(events.TypeSubmissionWithdrawn, events.SubmissionWithdrawn{Nation: "France"}),
+		envelope(events.TypeGamePaused, events.GamePaused{}),
+	})
+
+	is.True(g.Deadline.Equal(deadline))
+	is.True(g.Paused)
+	is.Equal(g.Submitted, map[string]bool{"England": true})
+
+	g.Apply(envelope(events.TypeGameResumed, events.GameResumed{}))
+	is.True(!g.Paused)
+
+	g.Apply(envelope(events.TypePhaseResolved, events.PhaseResolved{StateSnapshot: json.RawMessage(`{"s":1}`)}))
+	is.True(g.Deadline.IsZero())
+	is.Equal(len(g.Submitted), 0)
+}
+
+func TestProject_IgnoresMalformedPayloads(t *testing.T) {
+	is := is.New(t)
+	bad := json.RawMessage(`"not an object"`)
+	g := events.Project([]events.Envelope{
+		envelope(events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{"s":0}`)}),
+		{Type: events.TypePhaseResolved, Payload: bad},
+		{Type: events.TypePlayerJoined, Payload: bad},
+		envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Orders: []string{"F Lon-Nth"}}),
+	})
+
+	is.Equal(string(g.Snapshot), `{"s":0}`)
+	is.Equal(g.Phase, "")
+	is.Equal(len(g.Players), 0)
+	is.Equal(len(g.Pending), 1)
+}
+
+func TestProject_OrdersBeforeStartAreNotPending(t *testing.T) {
+	is := is.New(t)
+	g := events.Project([]events.Envelope{
+		envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Orders: []string{"F Lon-Nth"}}),
+	})
+	is.Equal(len(g.Pending), 0)
+}
+
+func TestProject_HistoryAndSnapshots(t *testing.T) {
+	is := is.New(t)
+	g := events.Project([]events.Envelope{
+		envelope(events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{"s":0}`)}),
+		envelope(events.TypePhaseResolved, events.PhaseResolved{Phase: "Movement", StateSnapshot: json.RawMessage(`{"s":1}`)}),
+		envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Orders: []string{"F Lon-Nth"}}),
+		envelope(events.TypePhaseResolved, json.RawMessage(`"bad"`)),
+		envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "France", Orders: []string{"A Par-Bur"}}),
+	})
+
+	is.Equal(len(g.History), 1)
+	is.Equal(g.History[0].Phase, "Movement")
+	is.Equal(len(g.Snapshots), 3)
+	is.Equal(g.Snapshots[0].Type, events.TypeGameStarted)
+	is.Equal(g.Snapshots[1].Seq, 1)
+	is.Equal(g.Snapshots[1].Phase, "Movement")
+	is.Equal(len(g.Snapshots[1].Pending), 1)
+	is.Equal(g.Snapshots[1].Pending[0].Nation, "England")
+	is.Equal(g.Snapshots[2].Seq, 3)
+	is.Err(g.Snapshots[2].Err)
+	is.Equal(len(g.Snapshots[2].Pending), 1)
+	// The game stays in the phase of the last snapshot that decoded.
+	is.Equal(string(g.Snapshot), `{"s":1}`)
+	is.Equal(len(g.Pending), 2)
+}
+
+func TestGameReadDM(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Phase: "Spring 1901 Movement", Orders: []string{"F Lon H"}})
+	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Phase: "Spring 1901 Movement", Orders: []string{"F Lon-Nth"}})
+	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, json.RawMessage(`"bad"`))
+	_ = events.WriteDM(ch, "u2", events.TypeGameCreated, events.GameCreated{})
+	_ = events.WriteDM(ch, "u2", events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "France", Phase: "Fall 1901 Movement", Orders: []string{"A Par H"}})
+
+	g := events.NewGame()
+	is.NoErr(g.ReadDM(events.NewChannelStore(ch), "u1", "u2"))
+
+	is.Equal(len(g.Submissions["Spring 1901 Movement"]), 2)
+	is.Equal(g.Submissions["Spring 1901 Movement"][1].Orders, []string{"F Lon-Nth"})
+	is.True(g.HasSubmitted("Spring 1901 Movement", "England"))
+	is.True(!g.HasSubmitted("Spring 1901 Movement", "France"))
+	is.True(g.HasSubmitted("Fall 1901 Movement", "France"))
+}
+
+func TestGameSubmittedOrders_OnlyFinalSubmissions(t *testing.T) {
+	is := is.New(t)
+	g := events.Project([]events.Envelope{
+		envelope(events.TypeSubmissionFinalised, events.SubmissionFinalised{Nation: "England"}),
+		envelope(events.TypeSubmissionFinalised, events.SubmissionFinalised{Nation: "France"}),
+		envelope(events.TypeSubmissionWithdrawn, events.SubmissionWithdrawn{Nation: "France"}),
+	})
+	g.ApplyDM(envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Phase: "Fall 1901 Movement", Orders: []string{"F Lon H"}}))
+	g.ApplyDM(envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Phase: "Spring 1901 Movement", Orders: []string{"F Lon-Nth"}}))
+	g.ApplyDM(envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "France", Phase: "Spring 1901 Movement", Orders: []string{"A Par-Bur"}}))
+
+	got := g.SubmittedOrders("Spring 1901 Movement")
+	is.Equal(len(got), 1)
+	is.Equal(got[0].Nation, "England")
+	is.Equal(got[0].Orders, []string{"F Lon-Nth"})
+}
+
+func TestGameSubmittedOrders_LatestMovementAllAdjustment(t *testing.T) {
+	is := is.New(t)
+	g := events.Project([]events.Envelope{
+		envelope(events.TypeSubmissionFinalised, events.SubmissionFinalised{Nation: "England"}),
+	})
+	g.ApplyDM(envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Phase: "Spring 1901 Movement", Orders: []string{"F Lon-Nth", "A Lvp-Yor"}}))
+	g.ApplyDM(envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Phase: "Spring 1901 Movement", Orders: []string{"F Lon-Nth"}}))
+	g.ApplyDM(envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Phase: "Fall 1901 Adjustment", Orders: []string{"build A Lon"}}))
+	g.ApplyDM(envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Phase: "Fall 1901 Adjustment", Orders: []string{"build F Edi"}}))
+
+	got := g.SubmittedOrders("Spring 1901 Movement")
+	is.Equal(len(got), 1)
+	is.Equal(got[0].Orders, []string{"F Lon-Nth"})
+
+	got = g.SubmittedOrders("Fall 1901 Adjustment")
+	is.Equal(len(got), 2)
+	is.Equal(got[0].Orders, []string{"build A Lon"})
+	is.Equal(got[1].Orders, []string{"build F Edi"})
+}
+
+func TestGameRestore(t *testing.T) {
+	is := is.New(t)
+	g := events.Project([]events.Envelope{
+		envelope(events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{"s":0}`)}),
+		envelope(events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Orders: []string{"F Lon-Nth", "A Lvp-Yor"}}),
+	})
+	eng := &mockEngine{}
+	var loaded string
+
+	got, err := g.Restore(func(snap []byte) (events.EngineState, error) {
+		loaded = string(snap)
+		return eng, nil
+	})
+
+	is.NoErr(err)
+	is.Equal(got, eng)
+	is.Equal(loaded, `{"s":0}`)
+	is.Equal(eng.submitted, []submittedOrder{{"England", "F Lon-Nth"}, {"England", "A Lvp-Yor"}})
+}
+
+func TestGameRestore_Errors(t *testing.T) {
+	is := is.New(t)
+	load := func(_ []byte) (events.EngineState, error) { return &mockEngine{}, nil }
+
+	_, err := events.NewGame().Restore(load)
+	is.Err(err)
+
+	started := events.Project([]events.Envelope{
+		envelope(events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{}`)}),
+	})
+	_, err = started.Restore(func(_ []byte) (events.EngineState, error) { return nil, errors.New("bad") })
+	is.Err(err)
+}
@@ -1,9 +1,6 @@
 package events
 
-import (
-	"encoding/json"
-	"fmt"
-)
+import "fmt"
 
 // EngineState is the subset of engine.Engine that Rebuild requires: staging
 // orders onto a restored game state. engine.Engine satisfies this interface.
@@ -15,65 +12,78 @@ type EngineState interface {
 // engine.Load satisfies this function signature.
 type Loader func(snapshot []byte) (EngineState, error)
 
-// Rebuild reconstructs the current game state from the channel's event log.
-// It finds the most recent GameStarted or PhaseResolved event, calls load to
-// restore the engine from its snapshot, then replays any OrderSubmitted events
-// that were posted after that snapshot.
+// Rebuild reconstructs the current game state from the event log of the game
+// in channelID. It folds the log into a Game and restores the engine with
+// Game.Restore: from the snapshot of the most recent GameStarted or
+// PhaseResolved event, with any OrderSubmitted events posted after it
+// replayed.
+//
+// Envelopes that fail verification are left out, and returned in rejected
+// as Events returns them.
 //
 // Returns an error if no snapshot event is found, if load fails, or if a
 // replayed order cannot be staged.
-func Rebuild(ch Channel, channelID string, load Loader) (EngineState, error) {
-	envs, err := Scan(ch, channelID)
+func Rebuild(st Store, channelID string, load Loader) (eng EngineState, rejected []Rejected, err error) {
+	g, rejected, err := ReadGame(st, channelID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	// Find the index of the last snapshot event (GameStarted or PhaseResolved).
-	snapshotIdx := -1
-	var snapshotBytes []byte
-	for i, env := range envs {
-		switch env.Type {
-		case TypeGameStarted:
-			var gs GameStarted
-			if err := json.Unmarshal(env.Payload, &gs); err != nil {
-				continue
-			}
-			snapshotIdx = i
-			snapshotBytes = gs.InitialState
-		case TypePhaseResolved:
-			var pr PhaseResolved
-			if err := json.Unmarshal(env.Payload, &pr); err != nil {
-				continue
-			}
-			snapshotIdx = i
-			snapshotBytes = pr.StateSnapshot
-		}
+	if g.Snapshot == nil {
+		return nil, rejected, fmt.Errorf("events: no snapshot found in channel %q", channelID)
 	}
-
-	if snapshotIdx < 0 {
-		return nil, fmt.Errorf("events: no snapshot found in channel %q", channelID)
+	eng, err = g.Restore(load)
+	if err != nil {
+		return nil, rejected, err
 	}
+	return eng, rejected, nil
+}
 
-	eng, err := load(snapshotBytes)
+// SkippedSnapshot describes a snapshot event that Recover could not use.
+type SkippedSnapshot struct {
+	// Seq is the event's position in the channel log, counting from zero.
+	Seq   int
+	Type  EventType
+	Phase string
+	// Err is why the snapshot was skipped: a malformed payload or the
+	// loader's error.
+	Err error
+}
+
+// Recover is Rebuild for damaged logs. It tries snapshot events newest first
+// and restores from the first one load accepts, then replays the
+// OrderSubmitted events posted between that snapshot and the next snapshot
+// event; orders after a skipped snapshot belong to a phase that can no longer
+// be reached and are dropped. Every snapshot event passed over is reported
+// in skipped, newest first. Envelopes that fail verification are never
+// considered.
+//
+// Returns an error if the log cannot be read, no snapshot loads, or a
+// replayed order cannot be staged.
+func Recover(st Store, channelID string, load Loader) (EngineState, []SkippedSnapshot, error) {
+	g, _, err := ReadGame(st, channelID)
 	if err != nil {
-		return nil, fmt.Errorf("events: load snapshot: %w", err)
+		return nil, nil, err
 	}
 
-	// Replay OrderSubmitted events posted after the snapshot.
-	for _, env := range envs[snapshotIdx+1:] {
-		if env.Type != TypeOrderSubmitted {
-			continue
-		}
-		var os OrderSubmitted
-		if err := json.Unmarshal(env.Payload, &os); err != nil {
-			continue
-		}
-		for _, order := range os.Orders {
-			if err := eng.SubmitOrder(os.Nation, order); err != nil {
-				return nil, fmt.Errorf("events: replay order %q for %s: %w", order, os.Nation, err)
+	var skipped []SkippedSnapshot
+	for i := len(g.Snapshots) - 1; i >= 0; i-- {
+		snap := g.Snapshots[i]
+		skip := SkippedSnapshot{Seq: snap.Seq, Type: snap.Type, Phase: snap.Phase, Err: snap.Err}
+		if skip.Err == nil {
+			eng, err := load(snap.State)
+			if err == nil {
+				if err := stage(eng, snap.Pending); err != nil {
+					return nil, skipped, err
+				}
+				return eng, skipped, nil
 			}
+			skip.Err = err
 		}
+		skipped = append(skipped, skip)
 	}
 
-	return eng, nil
+	if len(g.Snapshots) == 0 {
+		return nil, nil, fmt.Errorf("events: no snapshot found in channel %q", channelID)
+	}
+	return nil, skipped, fmt.Errorf("events: no loadable snapshot in channel %q", channelID)
 }
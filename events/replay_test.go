@@ -49,7 +49,7 @@ func TestRebuild_NoSnapshot(t *testing.T) {
 	_ = events.Write(ch, "c", events.TypePlayerJoined, events.PlayerJoined{UserID: "u1", Nation: "England"})
 
 	loader := &mockLoader{eng: &mockEngine{}}
-	_, err := events.Rebuild(ch, "c", loader.Load)
+	_, _, err := events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
 	is.Err(err)
 }
 
@@ -62,7 +62,7 @@ func TestRebuild_FromGameStarted(t *testing.T) {
 
 	eng := &mockEngine{}
 	loader := &mockLoader{eng: eng}
-	got, err := events.Rebuild(ch, "c", loader.Load)
+	got, _, err := events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
 	is.NoErr(err)
 	is.NotNil(got)
 	// No orders were submitted after the snapshot.
@@ -81,7 +81,7 @@ func TestRebuild_FromPhaseResolved(t *testing.T) {
 
 	eng := &mockEngine{}
 	loader := &mockLoader{eng: eng}
-	got, err := events.Rebuild(ch, "c", loader.Load)
+	got, _, err := events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
 	is.NoErr(err)
 	is.NotNil(got)
 }
@@ -105,7 +105,7 @@ func TestRebuild_ReplaysStagedOrders(t *testing.T) {
 
 	eng := &mockEngine{}
 	loader := &mockLoader{eng: eng}
-	got, err := events.Rebuild(ch, "c", loader.Load)
+	got, _, err := events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
 	is.NoErr(err)
 	is.NotNil(got)
 
@@ -138,7 +138,7 @@ func TestRebuild_IgnoresOrdersBeforeSnapshot(t *testing.T) {
 
 	eng := &mockEngine{}
 	loader := &mockLoader{eng: eng}
-	got, err := events.Rebuild(ch, "c", loader.Load)
+	got, _, err := events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
 	is.NoErr(err)
 	is.NotNil(got)
 
@@ -168,7 +168,7 @@ func TestRebuild_UsesLastSnapshot(t *testing.T) {
 
 	eng := &mockEngine{}
 	loader := &mockLoader{eng: eng}
-	got, err := events.Rebuild(ch, "c", loader.Load)
+	got, _, err := events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
 	is.NoErr(err)
 	is.NotNil(got)
 
@@ -183,7 +183,7 @@ func TestRebuild_PropagatesLoaderError(t *testing.T) {
 	_ = events.Write(ch, "c", events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{}`)})
 
 	loader := &mockLoader{loadErr: errors.New("load failed")}
-	_, err := events.Rebuild(ch, "c", loader.Load)
+	_, _, err := events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
 	is.Err(err)
 }
 
@@ -201,7 +201,7 @@ func TestRebuild_SkipsNonOrderEventsAfterSnapshot(t *testing.T) {
 
 	eng := &mockEngine{}
 	loader := &mockLoader{eng: eng}
-	got, err := events.Rebuild(ch, "c", loader.Load)
+	got, _, err := events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
 	is.NoErr(err)
 	is.NotNil(got)
 	// Only the OrderSubmitted event stages an order; PhaseSkipped is ignored.
@@ -216,16 +216,14 @@ func TestRebuild_SkipsMalformedGameStartedPayload(t *testing.T) {
 	ch := &mockChannel{}
 
 	// Inject a GameStarted envelope with a payload that cannot decode to GameStarted.
-	bad := events.Envelope{Type: events.TypeGameStarted, Payload: json.RawMessage(`"not-an-object"`)}
-	data, _ := json.Marshal(bad)
-	ch.messages = append(ch.messages, string(data))
+	_ = events.Write(ch, "c", events.TypeGameStarted, json.RawMessage(`"not-an-object"`))
 
 	// A valid GameStarted follows.
 	_ = events.Write(ch, "c", events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{}`)})
 
 	eng := &mockEngine{}
 	loader := &mockLoader{eng: eng}
-	got, err := events.Rebuild(ch, "c", loader.Load)
+	got, _, err := events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
 	is.NoErr(err)
 	is.NotNil(got)
 }
@@ -237,16 +235,14 @@ func TestRebuild_SkipsMalformedPhaseResolvedPayload(t *testing.T) {
 	ch := &mockChannel{}
 
 	// Inject a PhaseResolved envelope with an invalid payload.
-	bad := events.Envelope{Type: events.TypePhaseResolved, Payload: json.RawMessage(`"not-an-object"`)}
-	data, _ := json.Marshal(bad)
-	ch.messages = append(ch.messages, string(data))
+	_ = events.Write(ch, "c", events.TypePhaseResolved, json.RawMessage(`"not-an-object"`))
 
 	// A valid snapshot follows so Rebuild can succeed.
 	_ = events.Write(ch, "c", events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{}`)})
 
 	eng := &mockEngine{}
 	loader := &mockLoader{eng: eng}
-	got, err := events.Rebuild(ch, "c", loader.Load)
+	got, _, err := events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
 	is.NoErr(err)
 	is.NotNil(got)
 }
@@ -260,13 +256,11 @@ func TestRebuild_SkipsMalformedOrderSubmittedPayload(t *testing.T) {
 	_ = events.Write(ch, "c", events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{}`)})
 
 	// Inject an OrderSubmitted envelope with an invalid payload after the snapshot.
-	bad := events.Envelope{Type: events.TypeOrderSubmitted, Payload: json.RawMessage(`"not-an-object"`)}
-	data, _ := json.Marshal(bad)
-	ch.messages = append(ch.messages, string(data))
+	_ = events.Write(ch, "c", events.TypeOrderSubmitted, json.RawMessage(`"not-an-object"`))
 
 	eng := &mockEngine{}
 	loader := &mockLoader{eng: eng}
-	got, err := events.Rebuild(ch, "c", loader.Load)
+	got, _, err := events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
 	is.NoErr(err)
 	is.NotNil(got)
 	is.Equal(len(eng.submitted), 0)
@@ -278,7 +272,7 @@ func TestRebuild_PropagatesChannelError(t *testing.T) {
 	ch := &mockChannel{historyErr: errors.New("channel down")}
 
 	loader := &mockLoader{eng: &mockEngine{}}
-	_, err := events.Rebuild(ch, "c", loader.Load)
+	_, _, err := events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
 	is.Err(err)
 }
 
@@ -295,6 +289,115 @@ func TestRebuild_PropagatesSubmitError(t *testing.T) {
 
 	eng := &mockEngine{submitErr: errors.New("parse error")}
 	loader := &mockLoader{eng: eng}
-	_, err := events.Rebuild(ch, "c", loader.Load)
+	_, _, err := events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
 	is.Err(err)
 }
+
+// failingLoader fails to load any snapshot listed in bad.
+type failingLoader struct {
+	eng    *mockEngine
+	bad    map[string]bool
+	loaded []string
+}
+
+func (fl *failingLoader) Load(snap []byte) (events.EngineState, error) {
+	if fl.bad[string(snap)] {
+		return nil, errors.New("invalid snapshot")
+	}
+	fl.loaded = append(fl.loaded, string(snap))
+	return fl.eng, nil
+}
+
+// TestRecover_UsesLatestSnapshotWhenItLoads behaves like Rebuild on a healthy log.
+func TestRecover_UsesLatestSnapshotWhenItLoads(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "c", events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{"n":1}`)})
+	_ = events.Write(ch, "c", events.TypePhaseResolved, events.PhaseResolved{Phase: "Spring 1901 Movement", StateSnapshot: json.RawMessage(`{"n":2}`)})
+	_ = events.Write(ch, "c", events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "France", Orders: []string{"A Par-Bur"}})
+
+	eng := &mockEngine{}
+	loader := &failingLoader{eng: eng}
+	got, skipped, err := events.Recover(events.NewChannelStore(ch), "c", loader.Load)
+	is.NoErr(err)
+	is.NotNil(got)
+	is.Equal(len(skipped), 0)
+	is.Equal(loader.loaded, []string{`{"n":2}`})
+	is.Equal(eng.submitted, []submittedOrder{{"France", "A Par-Bur"}})
+}
+
+// TestRecover_FallsBackToLastGoodSnapshot skips a snapshot the loader rejects,
+// restores the previous one, replays only that phase's orders and reports the
+// skipped event.
+func TestRecover_FallsBackToLastGoodSnapshot(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "c", events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{"n":1}`)})
+	_ = events.Write(ch, "c", events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Orders: []string{"F Lon-Nth"}})
+	_ = events.Write(ch, "c", events.TypePhaseResolved, events.PhaseResolved{Phase: "Spring 1901 Movement", StateSnapshot: json.RawMessage(`{"corrupt":true}`)})
+	_ = events.Write(ch, "c", events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "France", Orders: []string{"A Par-Bur"}})
+
+	eng := &mockEngine{}
+	loader := &failingLoader{eng: eng, bad: map[string]bool{`{"corrupt":true}`: true}}
+	got, skipped, err := events.Recover(events.NewChannelStore(ch), "c", loader.Load)
+	is.NoErr(err)
+	is.NotNil(got)
+	is.Equal(loader.loaded, []string{`{"n":1}`})
+	is.Equal(eng.submitted, []submittedOrder{{"England", "F Lon-Nth"}})
+
+	is.Equal(len(skipped), 1)
+	is.Equal(skipped[0].Seq, 2)
+	is.Equal(skipped[0].Type, events.TypePhaseResolved)
+	is.Equal(skipped[0].Phase, "Spring 1901 Movement")
+	is.Err(skipped[0].Err)
+
+	// Rebuild stays strict and refuses the same log.
+	_, _, err = events.Rebuild(events.NewChannelStore(ch), "c", loader.Load)
+	is.Err(err)
+}
+
+// TestRecover_ReportsMalformedSnapshotPayload reports a snapshot event whose
+// payload cannot be decoded without passing it to the loader.
+func TestRecover_ReportsMalformedSnapshotPayload(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "c", events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{"n":1}`)})
+	_ = events.Write(ch, "c", events.TypePhaseResolved, json.RawMessage(`"not-an-object"`))
+
+	loader := &failingLoader{eng: &mockEngine{}}
+	got, skipped, err := events.Recover(events.NewChannelStore(ch), "c", loader.Load)
+	is.NoErr(err)
+	is.NotNil(got)
+	is.Equal(len(skipped), 1)
+	is.Equal(skipped[0].Seq, 1)
+	is.Err(skipped[0].Err)
+	is.Equal(loader.loaded, []string{`{"n":1}`})
+}
+
+// TestRecover_NoLoadableSnapshot returns an error and every skipped event when
+// no snapshot loads.
+func TestRecover_NoLoadableSnapshot(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "c", events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{"n":1}`)})
+	_ = events.Write(ch, "c", events.TypePhaseResolved, events.PhaseResolved{Phase: "Spring 1901 Movement", StateSnapshot: json.RawMessage(`{"n":2}`)})
+
+	loader := &failingLoader{bad: map[string]bool{`{"n":1}`: true, `{"n":2}`: true}}
+	_, skipped, err := events.Recover(events.NewChannelStore(ch), "c", loader.Load)
+	is.Err(err)
+	is.Equal(len(skipped), 2)
+	is.Equal(skipped[0].Seq, 1)
+	is.Equal(skipped[1].Seq, 0)
+}
+
+// TestRecover_NoSnapshot returns an error when the channel has no snapshot event.
+func TestRecover_NoSnapshot(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "c", events.TypePlayerJoined, events.PlayerJoined{UserID: "u1", Nation: "England"})
+
+	loader := &failingLoader{eng: &mockEngine{}}
+	_, skipped, err := events.Recover(events.NewChannelStore(ch), "c", loader.Load)
+	is.Err(err)
+	is.Equal(len(skipped), 0)
+}
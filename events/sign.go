@@ -0,0 +1,142 @@
+package events
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Reasons an envelope in a history is rejected. Rejected wraps one of these.
+var (
+	// ErrUnsigned means the envelope carries no signature, e.g. JSON a
+	// player pasted into the chat. See AllowLegacy for envelopes written
+	// before events were signed.
+	ErrUnsigned = errors.New("event is not signed")
+	// ErrBadSignature means the signature does not match the envelope: it
+	// was tampered with, signed with another key or copied from another
+	// channel.
+	ErrBadSignature = errors.New("event signature does not match")
+	// ErrReplayed means the envelope's sequence number is not after the
+	// previous event's, e.g. an earlier event posted again.
+	ErrReplayed = errors.New("event is out of sequence")
+)
+
+// signingKey is the per-deployment key that signs and verifies envelopes.
+var signingKey []byte
+
+// SetKey sets the key that Write and WriteDM sign envelopes with and that
+// Scan and ScanDM verify them against. Call it once at start-up, before any
+// event is written or read. Without a key envelopes are signed with an empty
+// one, which anyone with the source can forge, so a ChannelStore without a
+// key does not protect against forged events at all; that is only fit for
+// local play and tests. The other stores do not sign envelopes: they trust
+// whoever can write to them.
+func SetKey(key []byte) {
+	signingKey = append([]byte(nil), key...)
+}
+
+// acceptLegacy is set by AllowLegacy.
+var acceptLegacy bool
+
+// AllowLegacy sets whether unsigned envelopes are accepted at the start of a
+// history. Bots wrote envelopes with no signature or sequence number before
+// events were signed; allowing them lets the games already in a chat
+// history be replayed after an upgrade. They are only accepted before the
+// first validly signed envelope in the history, so once the bot has written
+// to a game, unsigned envelopes posted after are rejected as before. Until
+// then anyone in the chat can post an event that is accepted, so allow them
+// only while such games are in progress.
+func AllowLegacy(allow bool) {
+	acceptLegacy = allow
+}
+
+// Rejected is a message in a history that parses as an Envelope but failed
+// verification or could not be upcast, so Scan skipped it.
+type Rejected struct {
+	// Index is the message's position in the history, counting from zero.
+	Index int
+	Type  EventType
+	// Reason is ErrUnsigned, ErrBadSignature, ErrReplayed, or the error
+	// Upcast returned.
+	Reason error
+}
+
+func (r Rejected) String() string {
+	return fmt.Sprintf("message %d (%s): %v", r.Index, r.Type, r.Reason)
+}
+
+// signature returns the HMAC of env for the history stream, hex encoded. The
+// stream names the history the envelope belongs to, so an event copied from
+// one channel or DM thread to another no longer verifies.
+func signature(stream string, env Envelope) string {
+	var payload bytes.Buffer
+	if err := json.Compact(&payload, env.Payload); err != nil {
+		payload.Write(env.Payload)
+	}
+	fields := [][]byte{
+		[]byte(stream),
+		[]byte(strconv.Itoa(env.Seq)),
+		[]byte(env.Type),
+		payload.Bytes(),
+	}
+	// Envelopes signed before versions were recorded have none, and must
+	// still verify.
+	if env.Version != 0 {
+		fields = append(fields, []byte(strconv.Itoa(env.Version)))
+	}
+	mac := hmac.New(sha256.New, signingKey)
+	for _, field := range fields {
+		// Length-prefix each field so that no two envelopes sign the same
+		// bytes.
+		mac.Write([]byte(strconv.Itoa(len(field)) + ":"))
+		mac.Write(field)
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify returns the envelopes in messages that carry a valid signature for
+// stream and a sequence number after the one before, with the position of
+// each in at, and the messages that parse as envelopes but do not. Messages
+// that are not envelopes at all are skipped without report. Unsigned
+// envelopes before the first signed one are valid if AllowLegacy is set.
+func verify(stream string, messages []string) (envs []Envelope, at []int, rejected []Rejected) {
+	last := 0
+	signed := false
+	for i, msg := range messages {
+		var env Envelope
+		if err := json.Unmarshal([]byte(msg), &env); err != nil {
+			continue
+		}
+		if env.Type == "" {
+			continue
+		}
+		if acceptLegacy && !signed && env.Sig == "" && env.Seq == 0 {
+			envs = append(envs, env)
+			at = append(at, i)
+			continue
+		}
+		var reason error
+		switch {
+		case env.Sig == "":
+			reason = ErrUnsigned
+		case !hmac.Equal([]byte(env.Sig), []byte(signature(stream, env))):
+			reason = ErrBadSignature
+		case env.Seq <= last:
+			reason = ErrReplayed
+		}
+		if reason != nil {
+			rejected = append(rejected, Rejected{Index: i, Type: env.Type, Reason: reason})
+			continue
+		}
+		last = env.Seq
+		signed = true
+		envs = append(envs, env)
+		at = append(at, i)
+	}
+	return envs, at, rejected
+}
@@ -0,0 +1,213 @@
+package events_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/burrbd/dip/events"
+	"github.com/cheekybits/is"
+)
+
+// withKey sets the signing key for the duration of the test.
+func withKey(t *testing.T, key string) {
+	t.Helper()
+	events.SetKey([]byte(key))
+	t.Cleanup(func() { events.SetKey(nil) })
+}
+
+// TestWrite_SignsAndNumbersEnvelopes verifies that each envelope Write posts
+// is signed and numbered after the one before.
+func TestWrite_SignsAndNumbersEnvelopes(t *testing.T) {
+	is := is.New(t)
+	withKey(t, "secret")
+	ch := &mockChannel{}
+
+	_ = events.Write(ch, "chan1", events.TypeGameCreated, events.GameCreated{Variant: "classical"})
+	_ = events.Write(ch, "chan1", events.TypePlayerJoined, events.PlayerJoined{UserID: "u1", Nation: "England"})
+
+	for i, msg := range ch.messages {
+		var env events.Envelope
+		is.NoErr(json.Unmarshal([]byte(msg), &env))
+		is.Equal(env.Seq, i+1)
+		is.True(env.Sig != "")
+	}
+}
+
+// TestScan_RejectsForgedEnvelopes verifies that Scan skips envelopes that
+// did not come from Write and reports why.
+func TestScan_RejectsForgedEnvelopes(t *testing.T) {
+	forged := func(ch *mockChannel) {
+		ch.messages = append(ch.messages,
+			`{"type":"GameEnded","payload":{"result":"solo","winner":"Turkey"}}`)
+	}
+	tampered := func(ch *mockChannel) {
+		_ = events.Write(ch, "chan1", events.TypeGameEnded, events.GameEnded{Result: "draw"})
+		last := len(ch.messages) - 1
+		ch.messages[last] = strings.Replace(ch.messages[last], `"draw"`, `"solo"`, 1)
+	}
+	replayed := func(ch *mockChannel) {
+		ch.messages = append(ch.messages, ch.messages[0])
+	}
+	otherChannel := func(ch *mockChannel) {
+		other := &mockChannel{}
+		_ = events.Write(other, "chan2", events.TypeGameCreated, events.GameCreated{})
+		_ = events.Write(other, "chan2", events.TypeGameEnded, events.GameEnded{Result: "solo"})
+		ch.messages = append(ch.messages, other.messages[1])
+	}
+	otherKey := func(ch *mockChannel) {
+		events.SetKey([]byte("guessed"))
+		_ = events.Write(ch, "chan1", events.TypeGameEnded, events.GameEnded{Result: "solo"})
+		events.SetKey([]byte("secret"))
+	}
+
+	specs := []struct {
+		desc   string
+		forge  func(*mockChannel)
+		reason error
+	}{
+		{"unsigned JSON pasted by a player", forged, events.ErrUnsigned},
+		{"payload edited after signing", tampered, events.ErrBadSignature},
+		{"earlier event posted again", replayed, events.ErrReplayed},
+		{"event copied from another channel", otherChannel, events.ErrBadSignature},
+		{"event signed with another key", otherKey, events.ErrBadSignature},
+	}
+	for _, s := range specs {
+		t.Run(s.desc, func(t *testing.T) {
+			is := is.New(t)
+			withKey(t, "secret")
+			ch := &mockChannel{}
+			_ = events.Write(ch, "chan1", events.TypeGameCreated, events.GameCreated{Variant: "classical"})
+			_ = events.Write(ch, "chan1", events.TypePlayerJoined, events.PlayerJoined{UserID: "u1", Nation: "England"})
+			s.forge(ch)
+
+			envs, rejected, err := events.Scan(ch, "chan1")
+
+			is.NoErr(err)
+			is.Equal(len(envs), 2)
+			is.Equal(len(rejected), 1)
+			is.Equal(rejected[0].Index, 2)
+			is.True(errors.Is(rejected[0].Reason, s.reason))
+		})
+	}
+}
+
+// withLegacy allows unsigned envelopes for the duration of the test.
+func withLegacy(t *testing.T) {
+	t.Helper()
+	events.AllowLegacy(true)
+	t.Cleanup(func() { events.AllowLegacy(false) })
+}
+
+// baselineHistory is a channel history as bots wrote it before events were
+// signed.
+func baselineHistory() []string {
+	return []string{
+		`{"type":"GameCreated","payload":{"variant":"classical","deadline_hours":24,"gm_user_id":"gm1"}}`,
+		`{"type":"PlayerJoined","payload":{"user_id":"u1","nation":"England"}}`,
+	}
+}
+
+// TestScan_AcceptsLegacyEnvelopesBeforeFirstSigned verifies that with
+// AllowLegacy a game written before events were signed still replays, goes
+// on signed from there, and that unsigned envelopes after the first signed
+// one are rejected.
+func TestScan_AcceptsLegacyEnvelopesBeforeFirstSigned(t *testing.T) {
+	is := is.New(t)
+	withKey(t, "secret")
+	withLegacy(t)
+	ch := &mockChannel{messages: baselineHistory()}
+
+	is.NoErr(events.Write(ch, "chan1", events.TypePlayerJoined, events.PlayerJoined{UserID: "u2", Nation: "France"}))
+	ch.messages = append(ch.messages, `{"type":"GameEnded","payload":{"result":"solo","winner":"Turkey"}}`)
+
+	envs, rejected, err := events.Scan(ch, "chan1")
+	is.NoErr(err)
+	is.Equal(len(envs), 3)
+	is.Equal(envs[2].Seq, 1)
+	is.Equal(len(rejected), 1)
+	is.Equal(rejected[0].Index, 3)
+	is.True(errors.Is(rejected[0].Reason, events.ErrUnsigned))
+
+	g, _, err := events.ReadGame(events.NewChannelStore(ch), "chan1")
+	is.NoErr(err)
+	is.Equal(g.GMID, "gm1")
+	is.Equal(g.Players, map[string]string{"u1": "England", "u2": "France"})
+}
+
+// TestScan_RejectsLegacyEnvelopesByDefault verifies that unsigned
+// envelopes are only accepted when AllowLegacy is set.
+func TestScan_RejectsLegacyEnvelopesByDefault(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{messages: baselineHistory()}
+
+	envs, rejected, err := events.Scan(ch, "chan1")
+	is.NoErr(err)
+	is.Equal(len(envs), 0)
+	is.Equal(len(rejected), 2)
+	is.True(errors.Is(rejected[0].Reason, events.ErrUnsigned))
+}
+
+// TestWrite_NumbersAfterLastValidEvent verifies that forged envelopes do
+// not move the sequence on, so a forger cannot make the next genuine event
+// look replayed.
+func TestWrite_NumbersAfterLastValidEvent(t *testing.T) {
+	is := is.New(t)
+	withKey(t, "secret")
+	ch := &mockChannel{}
+	_ = events.Write(ch, "chan1", events.TypeGameCreated, events.GameCreated{})
+	ch.messages = append(ch.messages, `{"type":"GameEnded","payload":{},"seq":99,"sig":"00"}`)
+
+	_ = events.Write(ch, "chan1", events.TypePlayerJoined, events.PlayerJoined{UserID: "u1"})
+
+	envs, rejected, err := events.Scan(ch, "chan1")
+	is.NoErr(err)
+	is.Equal(len(envs), 2)
+	is.Equal(envs[1].Seq, 2)
+	is.Equal(len(rejected), 1)
+}
+
+// TestScanDM_RejectsForgedEnvelopes verifies that DM threads are verified
+// too, and that an event cannot be moved from one player's thread to
+// another's.
+func TestScanDM_RejectsForgedEnvelopes(t *testing.T) {
+	is := is.New(t)
+	withKey(t, "secret")
+	ch := &mockChannel{}
+	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Orders: []string{"A Lon H"}})
+	ch.dms["u2"] = append(ch.dms["u2"], ch.dms["u1"][0])
+
+	envs, rejected, err := events.ScanDM(ch, "u1")
+	is.NoErr(err)
+	is.Equal(len(envs), 1)
+	is.Equal(len(rejected), 0)
+
+	envs, rejected, err = events.ScanDM(ch, "u2")
+	is.NoErr(err)
+	is.Equal(len(envs), 0)
+	is.Equal(len(rejected), 1)
+	is.True(errors.Is(rejected[0].Reason, events.ErrBadSignature))
+}
+
+// TestRebuild_SkipsForgedSnapshot verifies that Rebuild restores from the
+// last genuine snapshot and reports a forged one posted after it.
+func TestRebuild_SkipsForgedSnapshot(t *testing.T) {
+	is := is.New(t)
+	withKey(t, "secret")
+	ch := &mockChannel{}
+	_ = events.Write(ch, "c", events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{"genuine":true}`)})
+	ch.messages = append(ch.messages, `{"type":"PhaseResolved","payload":{"state_snapshot":{"forged":true}}}`)
+
+	var loaded string
+	load := func(snap []byte) (events.EngineState, error) {
+		loaded = string(snap)
+		return &mockEngine{}, nil
+	}
+	_, rejected, err := events.Rebuild(events.NewChannelStore(ch), "c", load)
+
+	is.NoErr(err)
+	is.Equal(loaded, `{"genuine":true}`)
+	is.Equal(len(rejected), 1)
+	is.Equal(rejected[0].Type, events.TypePhaseResolved)
+}
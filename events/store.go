@@ -0,0 +1,133 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Store is an append-only event log kept apart from the chat. Events are kept
+// in streams, one for each game channel and one for each player's DM thread,
+// named by ChannelStream and DMStream. Each event in a stream is numbered
+// from 1.
+//
+// MemoryStore and FileStore keep events out of the chat entirely;
+// ChannelStore keeps them in the chat history as Write and WriteDM post them.
+type Store interface {
+	// Append adds an event of eventType with payload to the end of stream
+	// and returns the sequence number it was given.
+	Append(stream string, eventType EventType, payload json.RawMessage) (int, error)
+	// Read returns the events in stream numbered seq or later, in order.
+	Read(stream string, seq int) ([]Envelope, error)
+}
+
+// verifier is a Store whose streams can hold events that fail verification,
+// such as ChannelStore, where anyone in the chat can post.
+type verifier interface {
+	Verify(stream string) ([]Envelope, []Rejected, error)
+}
+
+// ChannelStream names the stream of the game played in channelID.
+func ChannelStream(channelID string) string { return "channel:" + channelID }
+
+// DMStream names the stream of userID's private events, such as the orders
+// they submit.
+func DMStream(userID string) string { return "dm:" + userID }
+
+// Record serialises payload as JSON and appends it to the stream of the game
+// in channelID.
+func Record(st Store, channelID string, eventType EventType, payload any) error {
+	return record(st, ChannelStream(channelID), eventType, payload)
+}
+
+// RecordDM serialises payload as JSON and appends it to userID's private
+// stream.
+func RecordDM(st Store, userID string, eventType EventType, payload any) error {
+	return record(st, DMStream(userID), eventType, payload)
+}
+
+func record(st Store, stream string, eventType EventType, payload any) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("events: marshal payload: %w", err)
+	}
+	if _, err := st.Append(stream, eventType, raw); err != nil {
+		return fmt.Errorf("events: append to %s: %w", stream, err)
+	}
+	return nil
+}
+
+// Events returns every event of the game in channelID, in order, upcast to
+// the current schema version of its type. Events that cannot be upcast, and
+// when st keeps events where they can be forged the ones that fail
+// verification, are left out and returned in rejected, as Scan returns them.
+func Events(st Store, channelID string) (envs []Envelope, rejected []Rejected, err error) {
+	return readAll(st, ChannelStream(channelID))
+}
+
+// DMEvents returns every event in userID's private stream, as Events does.
+func DMEvents(st Store, userID string) (envs []Envelope, rejected []Rejected, err error) {
+	return readAll(st, DMStream(userID))
+}
+
+func readAll(st Store, stream string) ([]Envelope, []Rejected, error) {
+	if v, ok := st.(verifier); ok {
+		return v.Verify(stream)
+	}
+	envs, err := st.Read(stream, 1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("events: read %s: %w", stream, err)
+	}
+	at := make([]int, len(envs))
+	for i := range envs {
+		at[i] = i
+	}
+	envs, rejected := upcastAll(envs, at)
+	return envs, rejected, nil
+}
+
+// newEnvelope returns the envelope for an event of eventType numbered seq,
+// recording the type's current schema version.
+func newEnvelope(eventType EventType, payload json.RawMessage, seq int) Envelope {
+	return Envelope{
+		Type:    eventType,
+		Payload: payload,
+		Seq:     seq,
+		Version: SchemaVersion(eventType),
+	}
+}
+
+// MemoryStore is a Store held in memory, for local play and tests. It is
+// safe for concurrent use.
+type MemoryStore struct {
+	mu      sync.Mutex
+	streams map[string][]Envelope
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{streams: make(map[string][]Envelope)}
+}
+
+// Append adds an event to the end of stream.
+func (s *MemoryStore) Append(stream string, eventType EventType, payload json.RawMessage) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	env := newEnvelope(eventType, append(json.RawMessage(nil), payload...), len(s.streams[stream])+1)
+	s.streams[stream] = append(s.streams[stream], env)
+	return env.Seq, nil
+}
+
+// Read returns the events in stream numbered seq or later.
+func (s *MemoryStore) Read(stream string, seq int) ([]Envelope, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	envs := s.streams[stream]
+	if seq < 1 {
+		seq = 1
+	}
+	if seq > len(envs) {
+		return nil, nil
+	}
+	return append([]Envelope(nil), envs[seq-1:]...), nil
+}
@@ -0,0 +1,177 @@
+package events_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/burrbd/dip/events"
+	"github.com/cheekybits/is"
+)
+
+// stores returns one of each Store, empty.
+func stores(t *testing.T) map[string]events.Store {
+	t.Helper()
+	fs, err := events.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]events.Store{
+		"memory":  events.NewMemoryStore(),
+		"file":    fs,
+		"channel": events.NewChannelStore(&mockChannel{}),
+	}
+}
+
+// TestStore_AppendAndRead verifies that every Store numbers the events in a
+// stream from 1 and reads them back from a given sequence number.
+func TestStore_AppendAndRead(t *testing.T) {
+	for name, st := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			for i, typ := range []events.EventType{events.TypeGameCreated, events.TypePlayerJoined, events.TypeGameStarted} {
+				seq, err := st.Append(events.ChannelStream("c"), typ, json.RawMessage(`{"n":1}`))
+				is.NoErr(err)
+				is.Equal(seq, i+1)
+			}
+			seq, err := st.Append(events.DMStream("u1"), events.TypeOrderSubmitted, json.RawMessage(`{}`))
+			is.NoErr(err)
+			is.Equal(seq, 1)
+
+			envs, err := st.Read(events.ChannelStream("c"), 2)
+			is.NoErr(err)
+			is.Equal(len(envs), 2)
+			is.Equal(envs[0].Type, events.TypePlayerJoined)
+			is.Equal(envs[0].Seq, 2)
+			is.Equal(string(envs[0].Payload), `{"n":1}`)
+			is.Equal(envs[1].Type, events.TypeGameStarted)
+
+			envs, err = st.Read(events.ChannelStream("c"), 4)
+			is.NoErr(err)
+			is.Equal(len(envs), 0)
+
+			envs, err = st.Read(events.ChannelStream("other"), 1)
+			is.NoErr(err)
+			is.Equal(len(envs), 0)
+		})
+	}
+}
+
+// TestRecord_EventsRoundTrip verifies that Events and DMEvents return what
+// Record and RecordDM appended.
+func TestRecord_EventsRoundTrip(t *testing.T) {
+	is := is.New(t)
+	st := events.NewMemoryStore()
+
+	is.NoErr(events.Record(st, "c", events.TypePlayerJoined, events.PlayerJoined{UserID: "u1", Nation: "England"}))
+	is.NoErr(events.RecordDM(st, "u1", events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England"}))
+
+	envs, rejected, err := events.Events(st, "c")
+	is.NoErr(err)
+	is.Equal(len(rejected), 0)
+	is.Equal(len(envs), 1)
+	var pj events.PlayerJoined
+	is.NoErr(json.Unmarshal(envs[0].Payload, &pj))
+	is.Equal(pj.Nation, "England")
+
+	envs, _, err = events.DMEvents(st, "u1")
+	is.NoErr(err)
+	is.Equal(len(envs), 1)
+	is.Equal(envs[0].Type, events.TypeOrderSubmitted)
+}
+
+// TestRecord_MarshalError returns an error when the payload cannot be
+// serialised.
+func TestRecord_MarshalError(t *testing.T) {
+	is := is.New(t)
+	is.Err(events.Record(events.NewMemoryStore(), "c", events.TypeGameCreated, make(chan int)))
+}
+
+// TestEvents_ReportsRejectedFromChannelStore verifies that Events reports
+// forged envelopes when the events are kept in the chat.
+func TestEvents_ReportsRejectedFromChannelStore(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "c", events.TypeGameCreated, events.GameCreated{})
+	ch.messages = append(ch.messages, `{"type":"GameEnded","payload":{"result":"solo"}}`)
+
+	envs, rejected, err := events.Events(events.NewChannelStore(ch), "c")
+
+	is.NoErr(err)
+	is.Equal(len(envs), 1)
+	is.Equal(len(rejected), 1)
+}
+
+// TestChannelStore_UnknownStream returns an error for a stream that names
+// neither a channel nor a DM thread.
+func TestChannelStore_UnknownStream(t *testing.T) {
+	is := is.New(t)
+	st := events.NewChannelStore(&mockChannel{})
+	_, err := st.Append("game:c", events.TypeGameCreated, json.RawMessage(`{}`))
+	is.Err(err)
+	_, err = st.Read("game:c", 1)
+	is.Err(err)
+}
+
+// TestFileStore_Reopen verifies that events survive a new FileStore on the
+// same directory, which carries on their numbering.
+func TestFileStore_Reopen(t *testing.T) {
+	is := is.New(t)
+	dir := t.TempDir()
+	st, err := events.NewFileStore(dir)
+	is.NoErr(err)
+	_, err = st.Append(events.ChannelStream("c"), events.TypeGameCreated, json.RawMessage(`{}`))
+	is.NoErr(err)
+
+	reopened, err := events.NewFileStore(dir)
+	is.NoErr(err)
+	seq, err := reopened.Append(events.ChannelStream("c"), events.TypePlayerJoined, json.RawMessage(`{}`))
+	is.NoErr(err)
+	is.Equal(seq, 2)
+
+	envs, err := reopened.Read(events.ChannelStream("c"), 1)
+	is.NoErr(err)
+	is.Equal(len(envs), 2)
+	is.Equal(envs[0].Type, events.TypeGameCreated)
+}
+
+// TestFileStore_TornLine verifies that a line left half written by a crash
+// is skipped and does not swallow the next event.
+func TestFileStore_TornLine(t *testing.T) {
+	is := is.New(t)
+	dir := t.TempDir()
+	st, err := events.NewFileStore(dir)
+	is.NoErr(err)
+	_, err = st.Append(events.ChannelStream("c"), events.TypeGameCreated, json.RawMessage(`{}`))
+	is.NoErr(err)
+	files, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	is.NoErr(err)
+	is.Equal(len(files), 1)
+	f, err := os.OpenFile(files[0], os.O_APPEND|os.O_WRONLY, 0o644)
+	is.NoErr(err)
+	_, err = f.WriteString(`{"type":"PlayerJoi`)
+	is.NoErr(err)
+	is.NoErr(f.Close())
+
+	reopened, err := events.NewFileStore(dir)
+	is.NoErr(err)
+	seq, err := reopened.Append(events.ChannelStream("c"), events.TypeGameStarted, json.RawMessage(`{}`))
+	is.NoErr(err)
+	is.Equal(seq, 2)
+
+	envs, err := reopened.Read(events.ChannelStream("c"), 1)
+	is.NoErr(err)
+	is.Equal(len(envs), 2)
+	is.Equal(envs[1].Type, events.TypeGameStarted)
+}
+
+// TestNewFileStore_Error returns an error when the directory cannot be
+// created.
+func TestNewFileStore_Error(t *testing.T) {
+	is := is.New(t)
+	file := filepath.Join(t.TempDir(), "file")
+	is.NoErr(os.WriteFile(file, nil, 0o644))
+	_, err := events.NewFileStore(filepath.Join(file, "events"))
+	is.Err(err)
+}
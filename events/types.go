@@ -1,10 +1,13 @@
 // Package events defines the structured event types that are serialised as JSON
-// and posted to the chat channel after each game action. The channel's message
-// history is the authoritative event log; state is restored by replaying events
-// from the last PhaseResolved snapshot.
+// and appended to a Store after each game action. The store is the
+// authoritative event log; state is restored by replaying events from the last
+// PhaseResolved snapshot.
 package events
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 // EventType identifies the kind of event.
 type EventType string
@@ -22,12 +25,27 @@ const (
 	TypeGameEnded      EventType = "GameEnded"
 	TypePlayerBooted   EventType = "PlayerBooted"
 	TypePlayerReplaced EventType = "PlayerReplaced"
+
+	TypeDeadlineSet         EventType = "DeadlineSet"
+	TypeGamePaused          EventType = "GamePaused"
+	TypeGameResumed         EventType = "GameResumed"
+	TypeSubmissionFinalised EventType = "SubmissionFinalised"
+	TypeSubmissionWithdrawn EventType = "SubmissionWithdrawn"
 )
 
-// Envelope wraps a typed event payload for serialisation in the channel.
+// Envelope wraps a typed event payload for serialisation in a Store.
 type Envelope struct {
 	Type    EventType       `json:"type"`
 	Payload json.RawMessage `json:"payload"`
+	// Seq numbers the envelope within its stream, from 1.
+	Seq int `json:"seq,omitempty"`
+	// Version is the schema version of Payload for its Type; envelopes
+	// written before versions were recorded have none, which is version 1.
+	// See Upcast.
+	Version int `json:"version,omitempty"`
+	// Sig signs the other fields with the key set by SetKey. Only
+	// ChannelStore signs envelopes.
+	Sig string `json:"sig,omitempty"`
 }
 
 // GameCreated is posted when a new game is initialised.
@@ -35,6 +53,9 @@ type GameCreated struct {
 	Variant       string `json:"variant"`
 	DeadlineHours int    `json:"deadline_hours"`
 	GMUserID      string `json:"gm_user_id"`
+	// NMRPolicy names the engine.NMRPolicy for missing orders; empty means
+	// the engine default.
+	NMRPolicy string `json:"nmr_policy,omitempty"`
 }
 
 // PlayerJoined is posted when a player claims a nation.
@@ -72,7 +93,8 @@ type PhaseSkipped struct {
 	Reason string `json:"reason"`
 }
 
-// NMRRecorded is posted when a nation does not submit orders in time.
+// NMRRecorded is posted when a nation does not submit orders in time. It
+// lists the orders the bot gave on the nation's behalf.
 type NMRRecorded struct {
 	Nation     string   `json:"nation"`
 	Phase      string   `json:"phase"`
@@ -109,3 +131,36 @@ type PlayerReplaced struct {
 	Nation    string `json:"nation"`
 	NewUserID string `json:"new_user_id"`
 }
+
+// DeadlineSet is posted whenever the current phase's deadline is set: when
+// the phase starts, when the GM extends it and when the GM resumes the game.
+type DeadlineSet struct {
+	Phase      string    `json:"phase"`
+	DeadlineAt time.Time `json:"deadline_at"`
+}
+
+// GamePaused is posted when the GM pauses the deadline timer. The deadline
+// itself is unchanged; it does not fire until the game is resumed.
+type GamePaused struct {
+	Phase string `json:"phase"`
+}
+
+// GameResumed is posted when the GM restarts a paused deadline timer. A
+// DeadlineSet follows with the deadline it runs to.
+type GameResumed struct {
+	Phase string `json:"phase"`
+}
+
+// SubmissionFinalised is posted when a nation's orders for the phase are
+// final: a movement /submit, or a retreat, disband, build or waive.
+type SubmissionFinalised struct {
+	Nation string `json:"nation"`
+	Phase  string `json:"phase"`
+}
+
+// SubmissionWithdrawn is posted when a nation that had submitted changes or
+// clears its orders, so its orders are no longer final.
+type SubmissionWithdrawn struct {
+	Nation string `json:"nation"`
+	Phase  string `json:"phase"`
+}
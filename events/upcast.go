@@ -0,0 +1,87 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrFutureVersion means an envelope records a schema version newer than
+// this build knows, e.g. one written by a newer bot.
+var ErrFutureVersion = errors.New("event schema version is newer than supported")
+
+// Upcaster migrates an event payload from one schema version of its type to
+// the next.
+type Upcaster func(payload json.RawMessage) (json.RawMessage, error)
+
+// upcasterRegistry holds each event type's upcasters; the one at index i
+// migrates version i+1 to i+2.
+var upcasterRegistry = struct {
+	sync.RWMutex
+	byType map[EventType][]Upcaster
+}{
+	byType: make(map[EventType][]Upcaster),
+}
+
+// RegisterUpcaster adds up as the migration from eventType's current schema
+// version to the next, which becomes the version new envelopes of the type
+// record. A payload struct change that old envelopes no longer decode into
+// must come with one.
+func RegisterUpcaster(eventType EventType, up Upcaster) {
+	upcasterRegistry.Lock()
+	defer upcasterRegistry.Unlock()
+	upcasterRegistry.byType[eventType] = append(upcasterRegistry.byType[eventType], up)
+}
+
+// SchemaVersion returns the current schema version of eventType's payload,
+// counting from 1.
+func SchemaVersion(eventType EventType) int {
+	upcasterRegistry.RLock()
+	defer upcasterRegistry.RUnlock()
+	return 1 + len(upcasterRegistry.byType[eventType])
+}
+
+// Upcast returns env with its payload migrated to the current schema version
+// of its type. Envelopes without a version are version 1. Scan, ScanDM,
+// Events and DMEvents upcast every envelope they return.
+//
+// Returns ErrFutureVersion for a version newer than the current one, or the
+// error of the upcaster that failed.
+func Upcast(env Envelope) (Envelope, error) {
+	version := env.Version
+	if version == 0 {
+		version = 1
+	}
+	upcasterRegistry.RLock()
+	ups := upcasterRegistry.byType[env.Type]
+	upcasterRegistry.RUnlock()
+	if version > len(ups)+1 {
+		return Envelope{}, fmt.Errorf("%w: %s version %d", ErrFutureVersion, env.Type, version)
+	}
+	for ; version <= len(ups); version++ {
+		payload, err := ups[version-1](env.Payload)
+		if err != nil {
+			return Envelope{}, fmt.Errorf("events: upcast %s from version %d: %w", env.Type, version, err)
+		}
+		env.Payload = payload
+	}
+	env.Version = version
+	return env, nil
+}
+
+// upcastAll upcasts envs, leaving out and reporting those that cannot be
+// migrated. at gives each envelope's position in its history.
+func upcastAll(envs []Envelope, at []int) ([]Envelope, []Rejected) {
+	var current []Envelope
+	var rejected []Rejected
+	for i, env := range envs {
+		up, err := Upcast(env)
+		if err != nil {
+			rejected = append(rejected, Rejected{Index: at[i], Type: env.Type, Reason: err})
+			continue
+		}
+		current = append(current, up)
+	}
+	return current, rejected
+}
@@ -0,0 +1,289 @@
+package events_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/burrbd/dip/events"
+	"github.com/cheekybits/is"
+)
+
+// payloadTypes maps every event type to its current payload struct.
+var payloadTypes = map[events.EventType]any{
+	events.TypeGameCreated:         events.GameCreated{},
+	events.TypePlayerJoined:        events.PlayerJoined{},
+	events.TypeGameStarted:         events.GameStarted{},
+	events.TypeOrderSubmitted:      events.OrderSubmitted{},
+	events.TypePhaseResolved:       events.PhaseResolved{},
+	events.TypePhaseSkipped:        events.PhaseSkipped{},
+	events.TypeNMRRecorded:         events.NMRRecorded{},
+	events.TypeDrawProposed:        events.DrawProposed{},
+	events.TypeDrawVoted:           events.DrawVoted{},
+	events.TypeGameEnded:           events.GameEnded{},
+	events.TypePlayerBooted:        events.PlayerBooted{},
+	events.TypePlayerReplaced:      events.PlayerReplaced{},
+	events.TypeDeadlineSet:         events.DeadlineSet{},
+	events.TypeGamePaused:          events.GamePaused{},
+	events.TypeGameResumed:         events.GameResumed{},
+	events.TypeSubmissionFinalised: events.SubmissionFinalised{},
+	events.TypeSubmissionWithdrawn: events.SubmissionWithdrawn{},
+}
+
+// historic holds a payload as each schema version of each event type wrote
+// it, and what it decodes to once upcast. A new version of a payload adds a
+// row here; old rows are never changed.
+var historic = []struct {
+	typ     events.EventType
+	version int
+	payload string
+	want    any
+}{
+	{events.TypeGameCreated, 1,
+		`{"variant":"classical","deadline_hours":24,"gm_user_id":"gm1"}`,
+		events.GameCreated{Variant: "classical", DeadlineHours: 24, GMUserID: "gm1"}},
+	{events.TypePlayerJoined, 1,
+		`{"user_id":"u1","nation":"England"}`,
+		events.PlayerJoined{UserID: "u1", Nation: "England"}},
+	{events.TypeGameStarted, 1,
+		`{"initial_state":{"phase":"Spring 1901 Movement"}}`,
+		events.GameStarted{InitialState: json.RawMessage(`{"phase":"Spring 1901 Movement"}`)}},
+	{events.TypeOrderSubmitted, 1,
+		`{"user_id":"u1","nation":"England","orders":["F Lon-Nth"],"phase":"Spring 1901 Movement"}`,
+		events.OrderSubmitted{UserID: "u1", Nation: "England", Orders: []string{"F Lon-Nth"}, Phase: "Spring 1901 Movement"}},
+	{events.TypePhaseResolved, 1,
+		`{"phase":"Spring 1901 Movement","state_snapshot":{"year":1901}}`,
+		events.PhaseResolved{Phase: "Spring 1901 Movement", StateSnapshot: json.RawMessage(`{"year":1901}`)}},
+	{events.TypePhaseSkipped, 1,
+		`{"phase":"Spring 1901 Retreat","reason":"no_dislodgements"}`,
+		events.PhaseSkipped{Phase: "Spring 1901 Retreat", Reason: "no_dislodgements"}},
+	{events.TypeNMRRecorded, 1,
+		`{"nation":"Italy","phase":"Spring 1901 Movement","auto_orders":["A Rom H"]}`,
+		events.NMRRecorded{Nation: "Italy", Phase: "Spring 1901 Movement", AutoOrders: []string{"A Rom H"}}},
+	{events.TypeDrawProposed, 1,
+		`{"proposer_nation":"France"}`,
+		events.DrawProposed{ProposerNation: "France"}},
+	{events.TypeDrawVoted, 1,
+		`{"nation":"Germany","accept":true}`,
+		events.DrawVoted{Nation: "Germany", Accept: true}},
+	{events.TypeGameEnded, 1,
+		`{"result":"solo","winner":"Turkey"}`,
+		events.GameEnded{Result: "solo", Winner: "Turkey"}},
+	{events.TypePlayerBooted, 1,
+		`{"nation":"Austria"}`,
+		events.PlayerBooted{Nation: "Austria"}},
+	{events.TypePlayerReplaced, 1,
+		`{"nation":"Austria","new_user_id":"u9"}`,
+		events.PlayerReplaced{Nation: "Austria", NewUserID: "u9"}},
+	{events.TypeDeadlineSet, 1,
+		`{"phase":"Spring 1901 Movement","deadline_at":"2030-01-02T03:04:05Z"}`,
+		events.DeadlineSet{Phase: "Spring 1901 Movement", DeadlineAt: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)}},
+	{events.TypeGamePaused, 1,
+		`{"phase":"Spring 1901 Movement"}`,
+		events.GamePaused{Phase: "Spring 1901 Movement"}},
+	{events.TypeGameResumed, 1,
+		`{"phase":"Spring 1901 Movement"}`,
+		events.GameResumed{Phase: "Spring 1901 Movement"}},
+	{events.TypeSubmissionFinalised, 1,
+		`{"nation":"England","phase":"Spring 1901 Retreat"}`,
+		events.SubmissionFinalised{Nation: "England", Phase: "Spring 1901 Retreat"}},
+	{events.TypeSubmissionWithdrawn, 1,
+		`{"nation":"England","phase":"Spring 1901 Movement"}`,
+		events.SubmissionWithdrawn{Nation: "England", Phase: "Spring 1901 Movement"}},
+}
+
+// TestUpcast_HistoricVersionsDecode verifies that a payload written at every
+// schema version of every event type still decodes into the current struct.
+func TestUpcast_HistoricVersionsDecode(t *testing.T) {
+	covered := make(map[events.EventType]map[int]bool)
+	for _, h := range historic {
+		if covered[h.typ] == nil {
+			covered[h.typ] = make(map[int]bool)
+		}
+		covered[h.typ][h.version] = true
+	}
+	for typ := range payloadTypes {
+		for v := 1; v <= events.SchemaVersion(typ); v++ {
+			if !covered[typ][v] {
+				t.Errorf("no historic payload for %s version %d", typ, v)
+			}
+		}
+	}
+
+	for _, h := range historic {
+		t.Run(string(h.typ), func(t *testing.T) {
+			is := is.New(t)
+			env, err := events.Upcast(events.Envelope{Type: h.typ, Version: h.version, Payload: json.RawMessage(h.payload)})
+			is.NoErr(err)
+			is.Equal(env.Version, events.SchemaVersion(h.typ))
+
+			got := reflect.New(reflect.TypeOf(payloadTypes[h.typ]))
+			is.NoErr(json.Unmarshal(env.Payload, got.Interface()))
+			is.Equal(got.Elem().Interface(), h.want)
+		})
+	}
+}
+
+// TestUpcast_UnversionedIsVersion1 verifies that envelopes written before
+// versions were recorded are upcast from version 1.
+func TestUpcast_UnversionedIsVersion1(t *testing.T) {
+	is := is.New(t)
+	env, err := events.Upcast(events.Envelope{Type: events.TypePlayerJoined, Payload: json.RawMessage(`{}`)})
+	is.NoErr(err)
+	is.Equal(env.Version, events.SchemaVersion(events.TypePlayerJoined))
+}
+
+// typeUpcastTest is registered only by this file, so its upcasters do not
+// touch the real event types.
+const typeUpcastTest events.EventType = "UpcastTest"
+
+func init() {
+	// Version 1 held a single order, version 2 a list, version 3 the phase.
+	events.RegisterUpcaster(typeUpcastTest, func(p json.RawMessage) (json.RawMessage, error) {
+		var v1 struct {
+			Order string `json:"order"`
+		}
+		if err := json.Unmarshal(p, &v1); err != nil {
+			return nil, err
+		}
+		return json.Marshal(map[string][]string{"orders": {v1.Order}})
+	})
+	events.RegisterUpcaster(typeUpcastTest, func(p json.RawMessage) (json.RawMessage, error) {
+		var v2 map[string]any
+		if err := json.Unmarshal(p, &v2); err != nil {
+			return nil, err
+		}
+		v2["phase"] = "unknown"
+		return json.Marshal(v2)
+	})
+}
+
+type upcastTest struct {
+	Orders []string `json:"orders"`
+	Phase  string   `json:"phase"`
+}
+
+// TestUpcast_Chain verifies that an old payload passes through every
+// upcaster after its version, in order.
+func TestUpcast_Chain(t *testing.T) {
+	is := is.New(t)
+	is.Equal(events.SchemaVersion(typeUpcastTest), 3)
+	specs := []struct {
+		version int
+		payload string
+	}{
+		{0, `{"order":"A Vie H"}`},
+		{1, `{"order":"A Vie H"}`},
+		{2, `{"orders":["A Vie H"]}`},
+		{3, `{"orders":["A Vie H"],"phase":"unknown"}`},
+	}
+	for _, s := range specs {
+		env, err := events.Upcast(events.Envelope{Type: typeUpcastTest, Version: s.version, Payload: json.RawMessage(s.payload)})
+		is.NoErr(err)
+		is.Equal(env.Version, 3)
+		var got upcastTest
+		is.NoErr(json.Unmarshal(env.Payload, &got))
+		is.Equal(got, upcastTest{Orders: []string{"A Vie H"}, Phase: "unknown"})
+	}
+}
+
+// TestUpcast_Errors returns an error for a version newer than the current
+// one, and for a payload an upcaster cannot migrate.
+func TestUpcast_Errors(t *testing.T) {
+	is := is.New(t)
+	_, err := events.Upcast(events.Envelope{Type: typeUpcastTest, Version: 4, Payload: json.RawMessage(`{}`)})
+	is.True(errors.Is(err, events.ErrFutureVersion))
+	_, err = events.Upcast(events.Envelope{Type: typeUpcastTest, Version: 1, Payload: json.RawMessage(`[]`)})
+	is.Err(err)
+}
+
+// TestWrite_RecordsSchemaVersion verifies that new envelopes record the
+// current schema version of their type.
+func TestWrite_RecordsSchemaVersion(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	is.NoErr(events.Write(ch, "c", typeUpcastTest, upcastTest{Orders: []string{"A Vie H"}}))
+	var env events.Envelope
+	is.NoErr(json.Unmarshal([]byte(ch.messages[0]), &env))
+	is.Equal(env.Version, 3)
+
+	st := events.NewMemoryStore()
+	is.NoErr(events.Record(st, "c", events.TypePlayerJoined, events.PlayerJoined{}))
+	envs, err := st.Read(events.ChannelStream("c"), 1)
+	is.NoErr(err)
+	is.Equal(envs[0].Version, events.SchemaVersion(events.TypePlayerJoined))
+}
+
+// TestScan_UpcastsUnversionedEnvelope verifies that an envelope as the bot
+// wrote it before events were signed or versioned is replayed, and upcast
+// from version 1.
+func TestScan_UpcastsUnversionedEnvelope(t *testing.T) {
+	is := is.New(t)
+	events.AllowLegacy(true)
+	t.Cleanup(func() { events.AllowLegacy(false) })
+	ch := &mockChannel{messages: []string{
+		`{"type":"PlayerJoined","payload":{"user_id":"u1","nation":"England"}}`,
+		`{"type":"UpcastTest","payload":{"order":"A Vie H"}}`,
+	}}
+
+	envs, rejected, err := events.Scan(ch, "c")
+
+	is.NoErr(err)
+	is.Equal(len(rejected), 0)
+	is.Equal(len(envs), 2)
+	is.Equal(envs[0].Version, events.SchemaVersion(events.TypePlayerJoined))
+	is.Equal(envs[1].Version, events.SchemaVersion(typeUpcastTest))
+	var got upcastTest
+	is.NoErr(json.Unmarshal(envs[1].Payload, &got))
+	is.Equal(got, upcastTest{Orders: []string{"A Vie H"}, Phase: "unknown"})
+}
+
+// TestScan_RejectsStrippedVersion verifies that removing the version from a
+// signed envelope breaks its signature.
+func TestScan_RejectsStrippedVersion(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	is.NoErr(events.Write(ch, "c", events.TypePlayerJoined, events.PlayerJoined{UserID: "u1"}))
+	ch.messages[0] = strings.Replace(ch.messages[0], `"version":1,`, ``, 1)
+
+	_, rejected, err := events.Scan(ch, "c")
+
+	is.NoErr(err)
+	is.Equal(len(rejected), 1)
+	is.True(errors.Is(rejected[0].Reason, events.ErrBadSignature))
+}
+
+// TestEvents_UpcastsAndRejectsFutureVersions verifies that Events upcasts
+// what a store returns and reports events from a newer schema.
+func TestEvents_UpcastsAndRejectsFutureVersions(t *testing.T) {
+	is := is.New(t)
+	dir := t.TempDir()
+	st, err := events.NewFileStore(dir)
+	is.NoErr(err)
+	_, err = st.Append(events.ChannelStream("c"), typeUpcastTest, json.RawMessage(`{"orders":[],"phase":"p"}`))
+	is.NoErr(err)
+	files, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	is.NoErr(err)
+	f, err := os.OpenFile(files[0], os.O_APPEND|os.O_WRONLY, 0o644)
+	is.NoErr(err)
+	_, err = f.WriteString(`{"type":"UpcastTest","payload":{"order":"A Vie H"},"seq":2}` + "\n" +
+		`{"type":"UpcastTest","payload":{},"seq":3,"version":9}` + "\n")
+	is.NoErr(err)
+	is.NoErr(f.Close())
+
+	envs, rejected, err := events.Events(st, "c")
+
+	is.NoErr(err)
+	is.Equal(len(envs), 2)
+	var got upcastTest
+	is.NoErr(json.Unmarshal(envs[1].Payload, &got))
+	is.Equal(got.Orders, []string{"A Vie H"})
+	is.Equal(len(rejected), 1)
+	is.Equal(rejected[0].Index, 2)
+	is.True(errors.Is(rejected[0].Reason, events.ErrFutureVersion))
+}
@@ -0,0 +1,240 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/burrbd/dip/game/order"
+	"github.com/burrbd/dip/game/order/board"
+)
+
+// convoyRoutes records which army moves of a turn travel by convoy and
+// which of those convoys have been disrupted. Moves are keyed by the abbr
+// of the territory the army moves from.
+type convoyRoutes struct {
+	graph     adjacency
+	convoys   []order.MoveConvoy
+	units     map[string]*board.Unit
+	atSea     []board.Territory
+	needed    map[string]bool
+	convoyed  map[string]order.Move
+	disrupted map[string]bool
+}
+
+// planConvoys decides which army moves go by convoy. An army must go by
+// convoy when it cannot reach its destination over land, or when its own
+// country orders a fleet to convoy it there and that fleet lies on a chain
+// of fleets between the two coasts; such an army fails without a convoy.
+// An army whose order asks to go by convoy goes by convoy if it can.
+// Either way a chain of convoying fleets, of any country, must connect the
+// two coasts.
+func (h OrderHandler) planConvoys(orders order.Set, positions map[*board.Unit]board.Position) convoyRoutes {
+	r := convoyRoutes{
+		graph:     h.ConvoyGraph,
+		convoys:   orders.MoveConvoys,
+		units:     make(map[string]*board.Unit),
+		needed:    make(map[string]bool),
+		convoyed:  make(map[string]order.Move),
+		disrupted: make(map[string]bool),
+	}
+	for unit, pos := range positions {
+		r.units[pos.Territory.Abbr] = unit
+		if pos.Territory.Sea() {
+			r.atSea = append(r.atSea, pos.Territory)
+		}
+	}
+	for unit, pos := range positions {
+		for _, move := range orders.Moves {
+			if !matchMoveToPosition(pos, move) || move.UnitType != board.Army || move.Country != unit.Country {
+				continue
+			}
+			anyFleet := func(order.MoveConvoy) bool { return true }
+			if err := h.Validator.ValidateMove(*unit, move); err != nil || r.intended(move) {
+				r.needed[move.From.Abbr] = true
+			}
+			if (r.needed[move.From.Abbr] || move.ViaConvoy) && r.path(move, anyFleet) {
+				r.convoyed[move.From.Abbr] = move
+			}
+			break
+		}
+	}
+	return r
+}
+
+// path reports whether a chain of fleets convoying move, each accepted by
+// usable, links the army's territory to its destination.
+func (r convoyRoutes) path(move order.Move, usable func(order.MoveConvoy) bool) bool {
+	if r.graph == nil || move.From.Is(move.To) {
+		return false
+	}
+	var fleets []board.Territory
+	for _, c := range r.convoys {
+		if r.carries(c, move) && usable(c) {
+			fleets = append(fleets, c.By)
+		}
+	}
+	reached := r.reach(move.From, fleets)
+	for _, fleet := range fleets {
+		if reached[fleet.ID()] && r.graph.HasEdgeBetween(fleet.ID(), move.To.ID()) {
+			return true
+		}
+	}
+	return false
+}
+
+// reach returns the IDs of the fleets that a chain of fleets links to terr.
+func (r convoyRoutes) reach(terr board.Territory, fleets []board.Territory) map[int64]bool {
+	reached := make(map[int64]bool)
+	frontier := []board.Territory{terr}
+	for len(frontier) > 0 {
+		terr := frontier[0]
+		frontier = frontier[1:]
+		for _, fleet := range fleets {
+			if reached[fleet.ID()] || !r.graph.HasEdgeBetween(terr.ID(), fleet.ID()) {
+				continue
+			}
+			reached[fleet.ID()] = true
+			frontier = append(frontier, fleet)
+		}
+	}
+	return reached
+}
+
+// carries reports whether c is a convoy order for move given to a fleet
+// that is at sea.
+func (r convoyRoutes) carries(c order.MoveConvoy, move order.Move) bool {
+	_, occupied := r.units[c.By.Abbr]
+	return occupied && c.By.Sea() &&
+		c.Move.From.Is(move.From) && c.Move.To.Is(move.To)
+}
+
+// intended reports whether the army's own country ordered a fleet to
+// convoy move that some chain of fleets at sea, whatever their orders, links
+// to both coasts.
+func (r convoyRoutes) intended(move order.Move) bool {
+	if r.graph == nil || move.From.Is(move.To) {
+		return false
+	}
+	from, to := r.reach(move.From, r.atSea), r.reach(move.To, r.atSea)
+	for _, c := range r.convoys {
+		if c.Country == move.Country && r.carries(c, move) && from[c.By.ID()] && to[c.By.ID()] {
+			return true
+		}
+	}
+	return false
+}
+
+// settle decides which convoyed moves are disrupted. resolve resolves the
+// turn on a scratch board with the given routes and returns the board.
+//
+// Whether a convoy is disrupted can turn on the moves it carries, so every
+// combination of the undecided convoys is tried. A convoy that comes out the
+// same under every combination is decided first and the rest tried again.
+// The remaining convoys take the one combination that reproduces itself.
+// When there is none, a paradox, or more than one, each convoy that does
+// not come out the same in all of them is disrupted (the Szykman rule): its
+// army stays where it is and cuts no support.
+func (r convoyRoutes) settle(resolve func(convoyRoutes) board.Manager) {
+	decided := make(map[string]bool)
+	for {
+		var open []string
+		for from := range r.convoyed {
+			if _, ok := decided[from]; !ok {
+				open = append(open, from)
+			}
+		}
+		if len(open) == 0 {
+			break
+		}
+		sort.Strings(open)
+		assumed := func(combo int) map[string]bool {
+			disrupted := make(map[string]bool)
+			for from, d := range decided {
+				disrupted[from] = d
+			}
+			for i, from := range open {
+				disrupted[from] = combo&(1<<i) != 0
+			}
+			return disrupted
+		}
+		outcomes := make([]map[string]bool, 1<<len(open))
+		for combo := range outcomes {
+			try := r
+			try.disrupted = assumed(combo)
+			outcomes[combo] = try.cutOff(resolve(try))
+		}
+		if decideConstant(open, outcomes, decided) {
+			continue
+		}
+		var consistent []map[string]bool
+		for combo, outcome := range outcomes {
+			if same(open, outcome, assumed(combo)) {
+				consistent = append(consistent, outcome)
+			}
+		}
+		for _, from := range open {
+			decided[from] = len(consistent) == 0 || !agree(from, consistent) || consistent[0][from]
+		}
+	}
+	for from, d := range decided {
+		r.disrupted[from] = d
+	}
+}
+
+// decideConstant decides each of open that comes out the same in every
+// outcome, and reports whether it decided any.
+func decideConstant(open []string, outcomes []map[string]bool, decided map[string]bool) bool {
+	found := false
+	for _, from := range open {
+		if agree(from, outcomes) {
+			decided[from] = outcomes[0][from]
+			found = true
+		}
+	}
+	return found
+}
+
+// cutOff returns, for every convoyed move, whether it has no chain left once
+// the fleets defeated on manager are taken out.
+func (r convoyRoutes) cutOff(manager board.Manager) map[string]bool {
+	afloat := func(c order.MoveConvoy) bool {
+		return manager.Position(r.units[c.By.Abbr]).Cause != board.Defeated
+	}
+	cut := make(map[string]bool)
+	for from, move := range r.convoyed {
+		cut[from] = !r.path(move, afloat)
+	}
+	return cut
+}
+
+func agree(from string, outcomes []map[string]bool) bool {
+	for _, outcome := range outcomes {
+		if outcome[from] != outcomes[0][from] {
+			return false
+		}
+	}
+	return true
+}
+
+func same(keys []string, a, b map[string]bool) bool {
+	for _, k := range keys {
+		if a[k] != b[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// moving reports whether move goes ahead by convoy.
+func (r convoyRoutes) moving(move order.Move) bool {
+	_, ok := r.convoyed[move.From.Abbr]
+	return ok && !r.disrupted[move.From.Abbr]
+}
+
+// cuts reports whether move can cut support. A move that has to go by
+// convoy cuts nothing unless its convoy goes ahead.
+func (r convoyRoutes) cuts(move order.Move) bool {
+	if _, ok := r.convoyed[move.From.Abbr]; ok || r.needed[move.From.Abbr] {
+		return r.moving(move)
+	}
+	return true
+}
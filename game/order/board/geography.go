@@ -0,0 +1,208 @@
+package board
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/zond/godip"
+	"github.com/zond/godip/variants/classical"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// classicalBoard is the classical map, built from godip's graph so that the
+// native resolver and the engine agree on geography.
+var classicalBoard = newGeography(classical.ClassicalVariant.Graph(), classical.ClassicalVariant.ProvinceLongNames)
+
+// boardAbbrs renames the seas this package has always called something
+// other than godip does. Every other province keeps its godip abbr.
+var boardAbbrs = map[godip.Province]string{
+	"bot": "gob",
+	"eas": "eme",
+	"eng": "ech",
+	"mid": "mao",
+	"nat": "nao",
+	"nrg": "nwg",
+	"wes": "wme",
+}
+
+// geography is a board's territories, keyed by Territory.String(), the
+// edges of its army, fleet and convoy graphs, and the provinces next to each
+// province by land or sea, keyed by abbr.
+type geography struct {
+	territories map[string]Territory
+	army        [][2]Territory
+	fleet       [][2]Territory
+	convoy      [][2]Territory
+	neighbours  map[string][]string
+}
+
+// newGeography builds the board for a godip graph. Territory IDs are given
+// to land provinces, then seas, then split coasts, each in abbr order.
+func newGeography(g godip.Graph, names map[godip.Province]string) geography {
+	geo := geography{territories: make(map[string]Territory), neighbours: make(map[string][]string)}
+	split := func(p godip.Province) bool { return p == p.Super() && len(g.Coasts(p)) > 1 }
+
+	var land, seas, coasts []godip.Province
+	for _, p := range g.Provinces() {
+		switch {
+		case p != p.Super():
+			coasts = append(coasts, p)
+		case g.Flags(p)[godip.Land]:
+			land = append(land, p)
+		default:
+			seas = append(seas, p)
+		}
+	}
+	var id int64
+	for _, provs := range [][]godip.Province{land, seas, coasts} {
+		sort.Slice(provs, func(i, j int) bool { return boardAbbr(provs[i]) < boardAbbr(provs[j]) })
+		for _, p := range provs {
+			abbr, coast := splitCoast(boardAbbr(p))
+			sea := !g.Flags(p.Super())[godip.Land]
+			t := Territory{
+				id:      id,
+				Abbr:    abbr,
+				Name:    names[p.Super()],
+				Coast:   coast,
+				sea:     sea,
+				coastal: !sea && g.Flags(p)[godip.Sea] && !split(p),
+			}
+			if sc := g.SC(p); sc != nil {
+				t.sc = true
+				if *sc != godip.Neutral {
+					t.home = string(*sc)
+				}
+			}
+			geo.territories[t.String()] = t
+			id++
+		}
+	}
+
+	terr := func(p godip.Province) Territory { return geo.territories[boardAbbr(p)] }
+	for _, p := range g.Provinces() {
+		for q, flags := range g.Edges(p, false) {
+			if flags[godip.Land] && p == p.Super() && q == q.Super() {
+				geo.army = append(geo.army, [2]Territory{terr(p), terr(q)})
+			}
+			if flags[godip.Sea] && !split(p) && !split(q) {
+				geo.fleet = append(geo.fleet, [2]Territory{terr(p), terr(q)})
+			}
+			if flags[godip.Sea] && terr(p).Sea() {
+				geo.convoy = append(geo.convoy, [2]Territory{terr(p), terr(q.Super())})
+			}
+			if a, b := terr(p.Super()).Abbr, terr(q.Super()).Abbr; a != b && !contains(geo.neighbours[a], b) {
+				geo.neighbours[a] = append(geo.neighbours[a], b)
+			}
+		}
+	}
+	return geo
+}
+
+func contains(abbrs []string, abbr string) bool {
+	for _, a := range abbrs {
+		if a == abbr {
+			return true
+		}
+	}
+	return false
+}
+
+// boardAbbr returns the abbr this package uses for p, e.g. "ech" for
+// godip's "eng", keeping any coast.
+func boardAbbr(p godip.Province) string {
+	if abbr, ok := boardAbbrs[p]; ok {
+		return abbr
+	}
+	return string(p)
+}
+
+// LookupGodip finds the territory godip calls p, e.g. the English Channel
+// for "eng" or the north coast of Spain for "spa/nc".
+func LookupGodip(p godip.Province) Territory {
+	return LookupTerritory(boardAbbr(p))
+}
+
+// Godip returns godip's name for t, e.g. "eng" for the English Channel,
+// keeping any coast.
+func (t Territory) Godip() godip.Province {
+	for p, abbr := range boardAbbrs {
+		if abbr == t.Abbr {
+			return p
+		}
+	}
+	return godip.Province(t.String())
+}
+
+// Adjacency reports whether a unit of unitType ("Army" or "Fleet") at src
+// can move to dst, with provinces named as godip names them, e.g. "eng" or
+// "spa/nc". A dst without a coast matches any of its coasts.
+type Adjacency func(unitType, src, dst string) bool
+
+// Mismatches asks adjacent about every pair of territories and describes
+// each answer that differs from the board's army, fleet or convoy graph,
+// e.g. "army edge lvn-yor only on board" or "fleet edge bre-gas only in
+// adjacency". It returns nil when the two agree.
+func Mismatches(adjacent Adjacency) []string {
+	var land, fleet, seas, stops []Territory
+	for _, t := range classicalBoard.territories {
+		switch {
+		case t.Sea():
+			fleet = append(fleet, t)
+			seas = append(seas, t)
+			stops = append(stops, t)
+		case t.Coast != "":
+			fleet = append(fleet, t)
+		default:
+			land = append(land, t)
+			stops = append(stops, t)
+			if t.Coastal() {
+				fleet = append(fleet, t)
+			}
+		}
+	}
+
+	var diffs []string
+	diffs = append(diffs, diffEdges("army edge", CreateArmyGraph(), land, land, func(a, b Territory) bool {
+		return adjacent("Army", string(a.Godip()), string(b.Godip()))
+	})...)
+	diffs = append(diffs, diffEdges("fleet edge", CreateFleetGraph(), fleet, fleet, func(a, b Territory) bool {
+		return adjacent("Fleet", string(a.Godip()), string(b.Godip()))
+	})...)
+	// A convoy runs from a sea to the next sea or to any coast of a province.
+	diffs = append(diffs, diffEdges("convoy edge", CreateConvoyGraph(), seas, stops, func(a, b Territory) bool {
+		return adjacent("Fleet", string(a.Godip()), string(b.Godip()))
+	})...)
+	sort.Strings(diffs)
+	return diffs
+}
+
+// diffEdges compares g's edge between each territory in from and each in to
+// with adjacent, describing each undirected edge at most once.
+func diffEdges(kind string, g *simple.UndirectedGraph, from, to []Territory, adjacent func(a, b Territory) bool) []string {
+	seen := make(map[string]bool)
+	var diffs []string
+	for _, a := range from {
+		for _, b := range to {
+			if a == b {
+				continue
+			}
+			onBoard, other := g.HasEdgeBetween(a.ID(), b.ID()), adjacent(a, b)
+			if onBoard == other {
+				continue
+			}
+			x, y := a.String(), b.String()
+			if y < x {
+				x, y = y, x
+			}
+			where := "only on board"
+			if other {
+				where = "only in adjacency"
+			}
+			if diff := fmt.Sprintf("%s %s-%s %s", kind, x, y, where); !seen[diff] {
+				seen[diff] = true
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,66 @@
+package board_test
+
+import (
+	"testing"
+
+	"github.com/burrbd/dip/engine"
+	"github.com/burrbd/dip/game/order/board"
+	"github.com/cheekybits/is"
+	"github.com/zond/godip"
+)
+
+func TestMismatches_ClassicalAgreesWithEngine(t *testing.T) {
+	is := is.New(t)
+	e, err := engine.New("classical")
+	is.NoErr(err)
+
+	for _, diff := range board.Mismatches(e.Adjacent) {
+		t.Error(diff)
+	}
+}
+
+func TestMismatches_ReportsDisagreements(t *testing.T) {
+	is := is.New(t)
+	e, err := engine.New("classical")
+	is.NoErr(err)
+	// Liverpool and Yorkshire lose their border; Yorkshire gains one with
+	// Livonia.
+	adjacent := func(unitType, src, dst string) bool {
+		pair := src + "-" + dst
+		switch {
+		case unitType == "Army" && (pair == "lvp-yor" || pair == "yor-lvp"):
+			return false
+		case unitType == "Army" && (pair == "lvn-yor" || pair == "yor-lvn"):
+			return true
+		}
+		return e.Adjacent(unitType, src, dst)
+	}
+
+	is.Equal([]string{
+		"army edge lvn-yor only in adjacency",
+		"army edge lvp-yor only on board",
+	}, board.Mismatches(adjacent))
+}
+
+func TestClassicalBoard_MatchesGodip(t *testing.T) {
+	lu := board.LookupTerritory
+	is := is.New(t)
+	g := board.CreateArmyGraph()
+
+	is.Equal("Galicia", lu("gal").Name)
+	is.False(g.HasEdgeBetween(lu("yor").ID(), lu("lvn").ID()))
+	is.True(g.HasEdgeBetween(lu("bel").ID(), lu("ruh").ID()))
+	is.Equal("English Channel", lu("ech").Name)
+	is.Equal(int64(-1), lu("eng").ID())
+}
+
+func TestLookupGodip(t *testing.T) {
+	is := is.New(t)
+	is.Equal("ech", board.LookupGodip("eng").Abbr)
+	is.Equal("spa/nc", board.LookupGodip("spa/nc").String())
+	is.Equal(int64(-1), board.LookupGodip("xyz").ID())
+
+	for _, p := range []godip.Province{"eng", "spa/nc", "vie", "nat"} {
+		is.Equal(p, board.LookupGodip(p).Godip())
+	}
+}
@@ -19,6 +19,14 @@ const (
 	Bounced
 	// Defeated unit has been defeated
 	Defeated
+	// Convoyed unit has moved territories by convoy
+	Convoyed
+	// Retreated dislodged unit has retreated to another territory
+	Retreated
+	// Disbanded unit has been removed from the board
+	Disbanded
+	// Built unit has been built in a home supply center
+	Built
 )
 
 // Position describes the unit's board position
@@ -33,12 +41,28 @@ type Manager interface {
 	Position(*Unit) *Position
 	Positions() map[*Unit]Position
 	Move(*Unit, Territory, int)
+	MoveByConvoy(*Unit, Territory, int)
 	Hold(*Unit, int)
 	Bounce(*Unit)
 	SetDefeated(*Unit)
 	Conflict() []*Unit
 	AllConflicts() [][]*Unit
 	AtOrigin(*Unit) bool
+	Dislodgements() []Dislodgement
+	Standoffs() []Territory
+	Retreat(*Unit, Territory)
+	Disband(*Unit)
+	Build(*Unit, Territory)
+}
+
+// Dislodgement is a unit dislodged in the movement phase, waiting to
+// retreat from Territory. By is where the unit that dislodged it attacked
+// from; it is the zero Territory when the attacker came by convoy, which
+// leaves that territory open to the retreat.
+type Dislodgement struct {
+	Unit      *Unit
+	Territory Territory
+	By        Territory
 }
 
 // PositionManager implements Manager
@@ -131,6 +155,12 @@ func (m PositionManager) Move(u *Unit, to Territory, strength int) {
 	m.history[u] = append(m.history[u], Position{Territory: to, Strength: strength, Cause: Moved})
 }
 
+// MoveByConvoy moves a unit to another territory by convoy. A convoyed move
+// never meets a unit coming the other way head to head.
+func (m PositionManager) MoveByConvoy(u *Unit, to Territory, strength int) {
+	m.history[u] = append(m.history[u], Position{Territory: to, Strength: strength, Cause: Convoyed})
+}
+
 // Hold holds the unit in the current position
 func (m PositionManager) Hold(u *Unit, strength int) {
 	m.history[u] = append(m.history[u], Position{Territory: m.Position(u).Territory, Cause: Held, Strength: strength})
@@ -147,6 +177,70 @@ func (m PositionManager) SetDefeated(u *Unit) {
 	m.history[u] = append(m.history[u], Position{Territory: m.Position(u).Territory, Cause: Defeated})
 }
 
+// Retreat moves a dislodged unit to another territory
+func (m PositionManager) Retreat(u *Unit, to Territory) {
+	m.history[u] = append(m.history[u], Position{Territory: to, Cause: Retreated})
+}
+
+// Disband removes a unit from the board. Its last position is kept.
+func (m PositionManager) Disband(u *Unit) {
+	m.history[u] = append(m.history[u], Position{Territory: m.Position(u).Territory, Cause: Disbanded})
+}
+
+// Build places a new unit on the board
+func (m PositionManager) Build(u *Unit, territory Territory) {
+	m.history[u] = []Position{{Territory: territory, Cause: Built}}
+}
+
+// Dislodgements returns the units dislodged on the board, in territory
+// order, each with where its attacker came from.
+func (m PositionManager) Dislodgements() []Dislodgement {
+	positions := m.Positions()
+	var dislodged []Dislodgement
+	for u, pos := range positions {
+		if pos.Cause != Defeated {
+			continue
+		}
+		d := Dislodgement{Unit: u, Territory: pos.Territory}
+		for attacker, apos := range positions {
+			if apos.Cause == Moved && apos.Territory.Is(pos.Territory) {
+				d.By = m.Origin(attacker)
+			}
+		}
+		dislodged = append(dislodged, d)
+	}
+	sort.Slice(dislodged, func(i, j int) bool { return dislodged[i].Territory.Abbr < dislodged[j].Territory.Abbr })
+	return dislodged
+}
+
+// Standoffs returns the territories left empty by a standoff, in abbr
+// order: two or more units bounced from each and none is there now.
+func (m PositionManager) Standoffs() []Territory {
+	occupied := make(map[string]bool)
+	for _, pos := range m.Positions() {
+		if pos.Cause != Defeated && pos.Cause != Disbanded {
+			occupied[pos.Territory.Abbr] = true
+		}
+	}
+	bounced := make(map[string][]Territory)
+	for _, hist := range m.history {
+		for i := 0; i+1 < len(hist); i++ {
+			if (hist[i].Cause == Moved || hist[i].Cause == Convoyed) && hist[i+1].Cause == Bounced {
+				bounced[hist[i].Territory.Abbr] = append(bounced[hist[i].Territory.Abbr], hist[i].Territory)
+				break
+			}
+		}
+	}
+	var standoffs []Territory
+	for abbr, terrs := range bounced {
+		if len(terrs) > 1 && !occupied[abbr] {
+			standoffs = append(standoffs, terrs[0])
+		}
+	}
+	sort.Slice(standoffs, func(i, j int) bool { return standoffs[i].Abbr < standoffs[j].Abbr })
+	return standoffs
+}
+
 // Position returns the current board position of a unit
 func (m PositionManager) Position(u *Unit) *Position {
 	hist := m.positionHistory(u)
@@ -187,6 +187,34 @@ func TestPositionManager_Move(t *testing.T) {
 	is.Equal(1, m.Position(u).Strength)
 }
 
+func TestPositionManager_MoveByConvoy(t *testing.T) {
+	is := is.New(t)
+	m := board.NewPositionManager()
+	t1 := board.Territory{Abbr: "t1"}
+	t2 := board.Territory{Abbr: "t2"}
+	u := &board.Unit{}
+	m.AddUnit(u, t1)
+	m.MoveByConvoy(u, t2, 1)
+
+	is.Equal(t2, m.Position(u).Territory)
+	is.Equal(board.Convoyed, m.Position(u).Cause)
+	is.Equal(1, m.Position(u).Strength)
+}
+
+func TestPositionManager_AllConflicts_ConvoyedMoveHasNoCounterAttack(t *testing.T) {
+	is := is.New(t)
+	aaa := board.Territory{Abbr: "aaa"}
+	bbb := board.Territory{Abbr: "bbb"}
+	m := board.NewPositionManager()
+	a, b := &board.Unit{}, &board.Unit{}
+	m.AddUnit(a, aaa)
+	m.AddUnit(b, bbb)
+	m.MoveByConvoy(a, bbb, 0)
+	m.Move(b, aaa, 0)
+
+	is.Equal(0, len(m.AllConflicts()))
+}
+
 func TestPositionManager_Hold(t *testing.T) {
 	is := is.New(t)
 	m := board.NewPositionManager()
@@ -236,6 +264,96 @@ func TestManager_SetDefeated(t *testing.T) {
 	is.Equal(0, m.Position(u).Strength)
 }
 
+func TestPositionManager_Retreat(t *testing.T) {
+	is := is.New(t)
+	m := board.NewPositionManager()
+	t1 := board.Territory{Abbr: "t1"}
+	t2 := board.Territory{Abbr: "t2"}
+	u := &board.Unit{}
+	m.AddUnit(u, t1)
+	m.SetDefeated(u)
+
+	m.Retreat(u, t2)
+
+	is.Equal(t2, m.Position(u).Territory)
+	is.Equal(board.Retreated, m.Position(u).Cause)
+}
+
+func TestPositionManager_Disband(t *testing.T) {
+	is := is.New(t)
+	m := board.NewPositionManager()
+	terr := board.Territory{Abbr: "terr"}
+	u := &board.Unit{}
+	m.AddUnit(u, terr)
+
+	m.Disband(u)
+
+	is.Equal(terr, m.Position(u).Territory)
+	is.Equal(board.Disbanded, m.Position(u).Cause)
+}
+
+func TestPositionManager_Build(t *testing.T) {
+	is := is.New(t)
+	m := board.NewPositionManager()
+	terr := board.Territory{Abbr: "terr"}
+	u := &board.Unit{}
+
+	m.Build(u, terr)
+
+	is.Equal(terr, m.Position(u).Territory)
+	is.Equal(board.Built, m.Position(u).Cause)
+	is.True(m.AtOrigin(u))
+}
+
+func TestPositionManager_Dislodgements(t *testing.T) {
+	is := is.New(t)
+	aaa := board.Territory{Abbr: "aaa"}
+	bbb := board.Territory{Abbr: "bbb"}
+	ccc := board.Territory{Abbr: "ccc"}
+	ddd := board.Territory{Abbr: "ddd"}
+	m := board.NewPositionManager()
+	attacker, defender := &board.Unit{}, &board.Unit{}
+	convoyed, convoyDefender := &board.Unit{}, &board.Unit{}
+	m.AddUnit(attacker, aaa)
+	m.AddUnit(defender, bbb)
+	m.AddUnit(convoyed, ccc)
+	m.AddUnit(convoyDefender, ddd)
+	m.Move(attacker, bbb, 1)
+	m.SetDefeated(defender)
+	m.MoveByConvoy(convoyed, ddd, 1)
+	m.SetDefeated(convoyDefender)
+
+	is.Equal([]board.Dislodgement{
+		{Unit: defender, Territory: bbb, By: aaa},
+		{Unit: convoyDefender, Territory: ddd},
+	}, m.Dislodgements())
+}
+
+func TestPositionManager_Standoffs(t *testing.T) {
+	is := is.New(t)
+	aaa := board.Territory{Abbr: "aaa"}
+	bbb := board.Territory{Abbr: "bbb"}
+	ccc := board.Territory{Abbr: "ccc"}
+	ddd := board.Territory{Abbr: "ddd"}
+	eee := board.Territory{Abbr: "eee"}
+	m := board.NewPositionManager()
+	a, c, d, e := &board.Unit{}, &board.Unit{}, &board.Unit{}, &board.Unit{}
+	m.AddUnit(a, aaa)
+	m.AddUnit(c, ccc)
+	m.AddUnit(d, ddd)
+	m.AddUnit(e, eee)
+
+	// a and c stand off in bbb; d bounces alone off e in eee.
+	m.Move(a, bbb, 0)
+	m.Move(c, bbb, 0)
+	m.Move(d, eee, 0)
+	m.Bounce(a)
+	m.Bounce(c)
+	m.Bounce(d)
+
+	is.Equal([]board.Territory{bbb}, m.Standoffs())
+}
+
 func with(position ...interface{}) positionInstruction {
 	return position
 }
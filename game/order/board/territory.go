@@ -1,110 +1,158 @@
 package board
 
 import (
+	"sort"
 	"strings"
 
 	"gonum.org/v1/gonum/graph/simple"
 )
 
+// Territory is a province of the board. A fleet on a province with split
+// coasts occupies one of them, named by Coast (e.g. "nc"); the coast shares
+// the province's Abbr but has its own ID in the fleet graph.
 type Territory struct {
-	id    int64
-	Abbr  string
-	Name  string
-	edges []string
+	id      int64
+	Abbr    string
+	Name    string
+	Coast   string
+	sea     bool
+	coastal bool
+	sc      bool
+	home    string
 }
 
 func (t Territory) ID() int64 {
 	return t.id
 }
 
+// Is reports whether t and cmp are the same province, whatever their coasts.
 func (t Territory) Is(cmp Territory) bool {
-	return t.id == cmp.id
+	return t.Abbr == cmp.Abbr
 }
 
 func (t Territory) IsNot(cmp Territory) bool {
-	return t.id != cmp.id
+	return !t.Is(cmp)
 }
 
-func CreateArmyGraph() *simple.UndirectedGraph {
-	tm := make(map[string]Territory)
-	for _, terr := range armyTerritories {
-		tm[terr.Abbr] = terr
+// String returns the abbr with any coast, e.g. "spa/nc".
+func (t Territory) String() string {
+	if t.Coast == "" {
+		return t.Abbr
 	}
+	return t.Abbr + "/" + t.Coast
+}
 
-	g := simple.NewUndirectedGraph()
-	for _, terr := range tm {
-		for _, neighbor := range terr.edges {
-			g.SetEdge(g.NewEdge(terr, tm[neighbor]))
+// Sea reports whether the territory is a sea, where a fleet can convoy.
+func (t Territory) Sea() bool {
+	return t.sea
+}
+
+// Coastal reports whether a fleet can stand on the territory without being
+// at sea: a coastal province, or one coast of a province with split coasts.
+func (t Territory) Coastal() bool {
+	return t.coastal
+}
+
+// SupplyCenter reports whether the territory's province is a supply center.
+func (t Territory) SupplyCenter() bool {
+	return t.sc
+}
 
+// Home returns the country whose home supply center the territory's
+// province is, e.g. "England" for Liverpool, or "" if it is no country's.
+func (t Territory) Home() string {
+	return t.home
+}
+
+// HomeCenters returns country's home supply centers in abbr order.
+func HomeCenters(country string) []Territory {
+	var homes []Territory
+	for _, terr := range classicalBoard.territories {
+		if terr.Coast == "" && terr.home == country {
+			homes = append(homes, terr)
+		}
+	}
+	sort.Slice(homes, func(i, j int) bool { return homes[i].Abbr < homes[j].Abbr })
+	return homes
+}
+
+// Distance counts the moves from a to b as though a unit could pass through
+// land and sea alike, the way the civil disorder rule measures how far a
+// unit is from home. It returns -1 if b cannot be reached.
+func Distance(a, b Territory) int {
+	seen := map[string]bool{a.Abbr: true}
+	frontier := []string{a.Abbr}
+	for dist := 0; len(frontier) > 0; dist++ {
+		var next []string
+		for _, abbr := range frontier {
+			if abbr == b.Abbr {
+				return dist
+			}
+			for _, n := range classicalBoard.neighbours[abbr] {
+				if !seen[n] {
+					seen[n] = true
+					next = append(next, n)
+				}
+			}
 		}
+		frontier = next
+	}
+	return -1
+}
+
+// CreateArmyGraph returns the graph armies move along: land provinces linked
+// to the land provinces they border.
+func CreateArmyGraph() *simple.UndirectedGraph {
+	return createGraph(classicalBoard.army)
+}
+
+// CreateConvoyGraph returns the graph a convoyed army travels along: each
+// sea is linked to the seas next to it and to the coasts it touches.
+func CreateConvoyGraph() *simple.UndirectedGraph {
+	return createGraph(classicalBoard.convoy)
+}
+
+// CreateFleetGraph returns the graph fleets move along. Its nodes are seas,
+// coastal provinces and, for provinces with split coasts, each coast; the
+// province itself has no fleet edges there.
+func CreateFleetGraph() *simple.UndirectedGraph {
+	return createGraph(classicalBoard.fleet)
+}
+
+func createGraph(edges [][2]Territory) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph()
+	for _, e := range edges {
+		g.SetEdge(g.NewEdge(e[0], e[1]))
 	}
 	return g
 }
 
+// LookupTerritory finds a territory by abbr, optionally with a coast written
+// as "spa/nc" or "spa(nc)".
 func LookupTerritory(abbr string) Territory {
-	for _, terr := range armyTerritories {
-		if strings.ToLower(abbr) == terr.Abbr {
-			return terr
-		}
+	abbr, coast := splitCoast(strings.ToLower(abbr))
+	if terr, ok := classicalBoard.territories[Territory{Abbr: abbr, Coast: coast}.String()]; ok {
+		return terr
 	}
 	return Territory{id: -1} // TODO: why this way?
 }
 
-var armyTerritories = []Territory{
-	Territory{id: 0, Name: "Albania", Abbr: "alb", edges: []string{"gre", "ser", "tri"}},
-	Territory{id: 1, Name: "Ankara", Abbr: "ank", edges: []string{"arm", "con", "smy"}},
-	Territory{id: 2, Name: "Apulia", Abbr: "apu", edges: []string{"nap", "rom", "ven"}},
-	Territory{id: 3, Name: "Armenia", Abbr: "arm", edges: []string{"sev", "smy", "syr"}},
-	Territory{id: 4, Name: "Belgium", Abbr: "bel", edges: []string{"bur", "hol", "pic", "rur"}},
-	Territory{id: 5, Name: "Berlin", Abbr: "ber", edges: []string{"kie", "mun", "pru", "sil"}},
-	Territory{id: 6, Name: "Bohemia", Abbr: "boh", edges: []string{"gal", "mun", "sil", "tyr"}},
-	Territory{id: 7, Name: "Brest", Abbr: "bre", edges: []string{"gas", "par", "pic"}},
-	Territory{id: 8, Name: "Budapest", Abbr: "bud", edges: []string{"gal", "rum", "ser", "tri", "vie"}},
-	Territory{id: 9, Name: "Bulgaria", Abbr: "bul", edges: []string{"con", "gre", "rum", "ser"}},
-	Territory{id: 10, Name: "Burgundy", Abbr: "bur", edges: []string{"bel", "gas", "mar", "mun", "par", "pic", "ruh"}},
-	Territory{id: 11, Name: "Clyde", Abbr: "cly", edges: []string{"edi", "lvp"}},
-	Territory{id: 12, Name: "Constantinople", Abbr: "con", edges: []string{"smy", "ank", "bul"}},
-	Territory{id: 13, Name: "Denmark", Abbr: "den", edges: []string{"kie", "swe"}},
-	Territory{id: 14, Name: "Edinburgh", Abbr: "edi", edges: []string{"lvp", "yor", "cly"}},
-	Territory{id: 15, Name: "Finland", Abbr: "fin", edges: []string{"nwy", "stp", "swe"}},
-	Territory{id: 16, Name: "Galacia", Abbr: "gal", edges: []string{"rum", "sil", "ukr", "vie", "war", "boh", "bud"}},
-	Territory{id: 17, Name: "Gascony", Abbr: "gas", edges: []string{"mar", "par", "spa", "bre", "bur"}},
-	Territory{id: 18, Name: "Greece", Abbr: "gre", edges: []string{"ser", "alb", "bul"}},
-	Territory{id: 19, Name: "Holland", Abbr: "hol", edges: []string{"kie", "ruh", "bel"}},
-	Territory{id: 20, Name: "Kiel", Abbr: "kie", edges: []string{"mun", "ruh", "ber", "den", "hol"}},
-	Territory{id: 21, Name: "Liverpool", Abbr: "lvp", edges: []string{"wal", "yor", "cly", "edi"}},
-	Territory{id: 22, Name: "Livonia", Abbr: "lvn", edges: []string{"mos", "pru", "stp", "war"}},
-	Territory{id: 23, Name: "London", Abbr: "lon", edges: []string{"wal", "yor"}},
-	Territory{id: 24, Name: "Marseilles", Abbr: "mar", edges: []string{"pie", "spa", "bur", "gas"}},
-	Territory{id: 25, Name: "Moscow", Abbr: "mos", edges: []string{"sev", "stp", "urk", "war", "lvn"}},
-	Territory{id: 26, Name: "Munich", Abbr: "mun", edges: []string{"ruh", "sil", "tyr", "ber", "boh", "bur", "kie"}},
-	Territory{id: 27, Name: "Naples", Abbr: "nap", edges: []string{"rom", "apu"}},
-	Territory{id: 28, Name: "North Africa", Abbr: "naf", edges: []string{"tun"}},
-	Territory{id: 29, Name: "Norway", Abbr: "nwy", edges: []string{"stp", "swe", "fin"}},
-	Territory{id: 30, Name: "Paris", Abbr: "par", edges: []string{"pic", "bre", "bur", "gas"}},
-	Territory{id: 31, Name: "Picardy", Abbr: "pic", edges: []string{"bel", "bre", "bur", "par"}},
-	Territory{id: 32, Name: "Piedmont", Abbr: "pie", edges: []string{"tus", "tyr", "ven", "mar"}},
-	Territory{id: 33, Name: "Portugal", Abbr: "por", edges: []string{"spa"}},
-	Territory{id: 34, Name: "Prussia", Abbr: "pru", edges: []string{"sil", "war", "ber", "lvn"}},
-	Territory{id: 35, Name: "Rome", Abbr: "rom", edges: []string{"tus", "ven", "apu", "nap"}},
-	Territory{id: 36, Name: "Ruhr", Abbr: "ruh", edges: []string{"bel", "bur", "hol", "kie", "mun"}},
-	Territory{id: 37, Name: "Rumania", Abbr: "rum", edges: []string{"ser", "sev", "ukr", "bud", "bul", "gal"}},
-	Territory{id: 38, Name: "Saint Petersburg", Abbr: "stp", edges: []string{"fin", "lvn", "nwy", "mos"}},
-	Territory{id: 39, Name: "Serbia", Abbr: "ser", edges: []string{"tri", "alb", "bud", "bul", "gre", "rum"}},
-	Territory{id: 40, Name: "Sevastopol", Abbr: "sev", edges: []string{"ukr", "arm", "mos", "rum"}},
-	Territory{id: 41, Name: "Silesia", Abbr: "sil", edges: []string{"war", "ber", "boh", "gal", "mun", "pru"}},
-	Territory{id: 42, Name: "Smyrna", Abbr: "smy", edges: []string{"syr", "ank", "arm", "con"}},
-	Territory{id: 43, Name: "Spain", Abbr: "spa", edges: []string{"gas", "mar", "por"}},
-	Territory{id: 44, Name: "Sweden", Abbr: "swe", edges: []string{"den", "fin", "nwy"}},
-	Territory{id: 45, Name: "Syria", Abbr: "syr", edges: []string{"arm", "smy"}},
-	Territory{id: 46, Name: "Trieste", Abbr: "tri", edges: []string{"tyr", "ven", "vie", "alb", "bud", "ser"}},
-	Territory{id: 47, Name: "Tunis", Abbr: "tun", edges: []string{"naf"}},
-	Territory{id: 48, Name: "Tuscany", Abbr: "tus", edges: []string{"ven", "pie", "rom"}},
-	Territory{id: 49, Name: "Tyrolia", Abbr: "tyr", edges: []string{"ven", "vie", "boh", "mun", "pie", "tri"}},
-	Territory{id: 50, Name: "Ukraine", Abbr: "ukr", edges: []string{"war", "gal", "mos", "rum", "sev"}},
-	Territory{id: 51, Name: "Venice", Abbr: "ven", edges: []string{"apu", "pie", "rom", "tri", "tus", "tyr"}},
-	Territory{id: 52, Name: "Vienna", Abbr: "vie", edges: []string{"boh", "bud", "gal", "tri", "tyr"}},
-	Territory{id: 53, Name: "Wales", Abbr: "wal", edges: []string{"yor", "lon", "lvp"}},
-	Territory{id: 54, Name: "Warsaw", Abbr: "war", edges: []string{"gal", "lvn", "mos", "pru", "sil", "ukr"}},
-	Territory{id: 55, Name: "Yorkshire", Abbr: "yor", edges: []string{"edi", "lon", "lvn", "wal"}},
+// Coasts returns the coasts of the province t, or nil if its coast is not
+// split.
+func Coasts(t Territory) []Territory {
+	var coasts []Territory
+	for _, terr := range classicalBoard.territories {
+		if terr.Is(t) && terr.Coast != "" {
+			coasts = append(coasts, terr)
+		}
+	}
+	sort.Slice(coasts, func(i, j int) bool { return coasts[i].Coast < coasts[j].Coast })
+	return coasts
+}
+
+func splitCoast(abbr string) (string, string) {
+	if i := strings.IndexAny(abbr, "/("); i >= 0 {
+		return abbr[:i], strings.TrimSuffix(abbr[i+1:], ")")
+	}
+	return abbr, ""
 }
@@ -18,3 +18,101 @@ func TestCreateArmyGraph(t *testing.T) {
 
 	is.False(g.HasEdgeBetween(lu("mar").ID(), lu("mos").ID()))
 }
+
+func TestCreateConvoyGraph(t *testing.T) {
+	lu := board.LookupTerritory
+	is := is.New(t)
+
+	g := board.CreateConvoyGraph()
+
+	is.True(g.HasEdgeBetween(lu("lon").ID(), lu("nth").ID()))
+	is.True(g.HasEdgeBetween(lu("nth").ID(), lu("ech").ID()))
+	is.True(g.HasEdgeBetween(lu("ech").ID(), lu("bre").ID()))
+
+	is.False(g.HasEdgeBetween(lu("lon").ID(), lu("bel").ID()))
+	is.False(g.HasEdgeBetween(lu("nth").ID(), lu("ion").ID()))
+}
+
+func TestLookupTerritory_Sea(t *testing.T) {
+	is := is.New(t)
+
+	is.True(board.LookupTerritory("Nth").Sea())
+	is.Equal("North Sea", board.LookupTerritory("nth").Name)
+	is.False(board.LookupTerritory("con").Sea())
+}
+
+func TestCreateFleetGraph(t *testing.T) {
+	lu := board.LookupTerritory
+	is := is.New(t)
+
+	g := board.CreateFleetGraph()
+
+	is.True(g.HasEdgeBetween(lu("lon").ID(), lu("nth").ID()))
+	is.True(g.HasEdgeBetween(lu("lon").ID(), lu("yor").ID()))
+	is.True(g.HasEdgeBetween(lu("stp/nc").ID(), lu("bar").ID()))
+
+	is.False(g.HasEdgeBetween(lu("stp/sc").ID(), lu("bar").ID()))
+	is.False(g.HasEdgeBetween(lu("rom").ID(), lu("ven").ID()))
+	is.False(g.HasEdgeBetween(lu("kie").ID(), lu("mun").ID()))
+}
+
+func TestLookupTerritory_Coast(t *testing.T) {
+	is := is.New(t)
+
+	nc := board.LookupTerritory("Spa/NC")
+	is.Equal("spa", nc.Abbr)
+	is.Equal("nc", nc.Coast)
+	is.Equal("spa/nc", nc.String())
+	is.Equal(nc, board.LookupTerritory("spa(nc)"))
+	is.True(nc.Is(board.LookupTerritory("spa")))
+	is.True(nc.ID() != board.LookupTerritory("spa").ID())
+
+	is.Equal(int64(-1), board.LookupTerritory("lon/nc").ID())
+	is.Equal(2, len(board.Coasts(board.LookupTerritory("stp"))))
+	is.Equal(0, len(board.Coasts(board.LookupTerritory("lon"))))
+}
+
+func TestLookupTerritory_SupplyCenters(t *testing.T) {
+	lu := board.LookupTerritory
+	is := is.New(t)
+
+	is.True(lu("lvp").SupplyCenter())
+	is.Equal("England", lu("lvp").Home())
+	is.True(lu("bel").SupplyCenter())
+	is.Equal("", lu("bel").Home())
+	is.False(lu("wal").SupplyCenter())
+	is.True(lu("stp/nc").SupplyCenter())
+	is.Equal("Russia", lu("stp/nc").Home())
+}
+
+func TestLookupTerritory_Coastal(t *testing.T) {
+	lu := board.LookupTerritory
+	is := is.New(t)
+
+	is.True(lu("lon").Coastal())
+	is.True(lu("stp/sc").Coastal())
+	is.False(lu("stp").Coastal())
+	is.False(lu("mun").Coastal())
+	is.False(lu("nth").Coastal())
+}
+
+func TestHomeCenters(t *testing.T) {
+	is := is.New(t)
+	var abbrs []string
+	for _, terr := range board.HomeCenters("Russia") {
+		abbrs = append(abbrs, terr.Abbr)
+	}
+	is.Equal([]string{"mos", "sev", "stp", "war"}, abbrs)
+	is.Equal(0, len(board.HomeCenters("a_country")))
+}
+
+func TestDistance(t *testing.T) {
+	lu := board.LookupTerritory
+	is := is.New(t)
+
+	is.Equal(0, board.Distance(lu("lon"), lu("lon")))
+	is.Equal(1, board.Distance(lu("lon"), lu("nth")))
+	is.Equal(2, board.Distance(lu("lon"), lu("nwy")))
+	is.Equal(1, board.Distance(lu("stp/nc"), lu("bar")))
+	is.Equal(-1, board.Distance(lu("lon"), lu("xyz")))
+}
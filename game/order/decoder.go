@@ -7,114 +7,200 @@ import (
 	"github.com/burrbd/dip/game/order/board"
 )
 
+// Decode decodes one order written in the short form, e.g. "A Vie-Bud",
+// into the order struct for its kind. Case and spacing are free, and a
+// territory may name its coast as "spa/sc" or "spa(sc)". It reads:
+//
+//	A Vie H                 Hold
+//	A Vie-Bud               Move, also "A Vie - Bud"
+//	A Lon-Bel via convoy    Move with ViaConvoy set
+//	A Gal S A Vie           HoldSupport, also "A Gal S A Vie H"
+//	A Gal S A Vie-Bud       MoveSupport
+//	F Nth C A Lon-Bel       MoveConvoy
+//	A Vie R Boh             Retreat
+//	A Vie D                 Disband, also "A Vie disband" or "disband A Vie"
+//	build A Vie             Build
+//
+// Territories Decode does not know are an error.
 func Decode(order, country string) (interface{}, error) {
-	tokens := strings.Split(strings.ToLower(order), " ")
-	n := len(tokens)
-	switch n {
-	case 2:
-		return decodeMove(tokens, country)
-	case 3:
-		return decodeHold(tokens, country)
-	case 5:
-		if tokens[2] == "c" {
-			return decodeConvoy(tokens, country)
-		} else if tokens[2] == "s" {
-			return decodeSupport(tokens, country)
+	d := &decoder{text: order, tokens: tokenize(order), country: country}
+	o, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+	if !d.done() {
+		return nil, d.invalid()
+	}
+	return o, nil
+}
+
+// tokenize splits order into lower case words, with each "-" a word of its
+// own.
+func tokenize(order string) []string {
+	return strings.Fields(strings.ReplaceAll(strings.ToLower(order), "-", " - "))
+}
+
+// decoder walks the tokens of one order.
+type decoder struct {
+	text    string
+	tokens  []string
+	i       int
+	country string
+}
+
+func (d *decoder) done() bool { return d.i >= len(d.tokens) }
+
+// peek returns the token at the cursor, or "" after the last.
+func (d *decoder) peek() string {
+	if d.done() {
+		return ""
+	}
+	return d.tokens[d.i]
+}
+
+// accept consumes the token at the cursor if it is one of words.
+func (d *decoder) accept(words ...string) bool {
+	for _, w := range words {
+		if d.peek() == w {
+			d.i++
+			return true
 		}
-		fallthrough
-	default:
-		return nil, fmt.Errorf("invalid order: %s", order)
 	}
+	return false
+}
+
+func (d *decoder) invalid() error {
+	return fmt.Errorf("invalid order: %s", d.text)
 }
 
-func decodeMove(tokens []string, country string) (interface{}, error) {
-	unit, err := unitType(tokens[0])
+func (d *decoder) decode() (interface{}, error) {
+	switch {
+	case d.accept("build"):
+		unit, at, err := d.unitAt()
+		if err != nil {
+			return nil, err
+		}
+		return Build{Country: d.country, UnitType: unit, At: at}, nil
+	case d.accept("disband"):
+		unit, at, err := d.unitAt()
+		if err != nil {
+			return nil, err
+		}
+		return Disband{Country: d.country, UnitType: unit, At: at}, nil
+	}
+
+	unit, at, err := d.unitAt()
 	if err != nil {
 		return nil, err
 	}
-	fromTo := strings.Split(tokens[1], "-")
-	if len(fromTo) != 2 {
-		return nil, fmt.Errorf("invalid order: %s", strings.Join(tokens, " "))
+	switch {
+	case d.accept("h", "hold"):
+		return Hold{Country: d.country, UnitType: unit, At: at}, nil
+	case d.accept("d", "disband"):
+		return Disband{Country: d.country, UnitType: unit, At: at}, nil
+	case d.accept("r", "retreat"):
+		d.accept("-")
+		to, err := d.territory()
+		if err != nil {
+			return nil, err
+		}
+		return Retreat{Country: d.country, UnitType: unit, From: at, To: to}, nil
+	case d.accept("-"):
+		return d.move(unit, at)
+	case d.accept("s", "support", "supports"):
+		return d.support(unit, at)
+	case d.accept("c", "convoy", "convoys"):
+		if unit != board.Fleet {
+			return nil, fmt.Errorf("invalid order; only fleet can convoy: %s", d.text)
+		}
+		moveUnit, from, err := d.unitAt()
+		if err != nil {
+			return nil, err
+		}
+		if !d.accept("-") {
+			return nil, d.invalid()
+		}
+		move, err := d.move(moveUnit, from)
+		if err != nil {
+			return nil, err
+		}
+		return MoveConvoy{Country: d.country, By: at, Move: move}, nil
 	}
-	from, to := fromTo[0], fromTo[1]
-	return Move{
-		Country:  country,
-		UnitType: unit,
-		From:     board.LookupTerritory(from),
-		To:       board.LookupTerritory(to)}, nil
+	return nil, d.invalid()
 }
 
-func decodeHold(tokens []string, country string) (interface{}, error) {
-	unit, err := unitType(tokens[0])
+// move decodes the rest of a move by unit from from, once past the "-".
+func (d *decoder) move(unit board.UnitType, from board.Territory) (Move, error) {
+	to, err := d.territory()
 	if err != nil {
-		return nil, err
+		return Move{}, err
 	}
-	at := tokens[1]
-	if tokens[2] != "h" {
-		return nil, fmt.Errorf("invalid order: %s", strings.Join(tokens, " "))
+	move := Move{Country: d.country, UnitType: unit, From: from, To: to}
+	if d.accept("via") {
+		if !d.accept("convoy") {
+			return Move{}, d.invalid()
+		}
+		move.ViaConvoy = true
 	}
-	return Hold{
-		Country:  country,
-		UnitType: unit,
-		At:       board.LookupTerritory(at),
-	}, nil
+	return move, nil
 }
 
-func decodeSupport(tokens []string, country string) (interface{}, error) {
-	unit, err := unitType(tokens[0])
+// support decodes the rest of a support given by unit at by, once past the
+// "S".
+func (d *decoder) support(unit board.UnitType, by board.Territory) (interface{}, error) {
+	supported, at, err := d.unitAt()
 	if err != nil {
 		return nil, err
 	}
-	fromTo := strings.Split(tokens[4], "-")
-	if len(fromTo) == 2 {
-		move, err := decodeMove(tokens[3:], country)
+	if d.accept("-") {
+		move, err := d.move(supported, at)
 		if err != nil {
 			return nil, err
 		}
-		return MoveSupport{
-			Country:  country,
-			UnitType: unit,
-			By:       board.LookupTerritory(tokens[1]),
-			Move:     move.(Move),
-		}, nil
-	}
-	supportedUnit, err := unitType(tokens[3])
-	if err != nil {
-		return nil, err
+		return MoveSupport{Country: d.country, UnitType: unit, By: by, Move: move}, nil
 	}
+	d.accept("h", "hold")
 	return HoldSupport{
-		Country:  country,
+		Country:  d.country,
 		UnitType: unit,
-		By:       board.LookupTerritory(tokens[1]),
-		Hold:     Hold{UnitType: supportedUnit, At: board.LookupTerritory(tokens[4])},
+		By:       by,
+		Hold:     Hold{UnitType: supported, At: at},
 	}, nil
 }
 
-func decodeConvoy(tokens []string, country string) (interface{}, error) {
-	if tokens[0] != "f" {
-		return nil, fmt.Errorf("invalid order; only fleet can convoy: %s", strings.Join(tokens, " "))
-	}
-	fromTo := strings.Split(tokens[4], "-")
-	if len(fromTo) != 2 {
-		return nil, fmt.Errorf("invalid order: %s", strings.Join(tokens, " "))
+// unitAt decodes a unit type followed by the territory it is in.
+func (d *decoder) unitAt() (board.UnitType, board.Territory, error) {
+	unit, err := unitType(d.peek())
+	if err != nil {
+		return "", board.Territory{}, err
 	}
-	move, err := decodeMove(tokens[3:], country)
+	d.i++
+	at, err := d.territory()
 	if err != nil {
-		return nil, err
+		return "", board.Territory{}, err
 	}
-	return MoveConvoy{
-		Country: country,
-		By:      board.LookupTerritory(tokens[1]),
-		Move:    move.(Move),
-	}, nil
+	return unit, at, nil
+}
+
+// territory decodes the territory at the cursor.
+func (d *decoder) territory() (board.Territory, error) {
+	if d.done() {
+		return board.Territory{}, d.invalid()
+	}
+	abbr := d.peek()
+	terr := board.LookupTerritory(abbr)
+	if terr.ID() < 0 {
+		return board.Territory{}, fmt.Errorf("unknown territory: %s", abbr)
+	}
+	d.i++
+	return terr, nil
 }
 
 func unitType(unitToken string) (board.UnitType, error) {
-	n := len(unitToken)
-	switch {
-	case n == 1 && unitToken[0] == 'f':
+	switch unitToken {
+	case "f", "fleet":
 		return board.Fleet, nil
-	case n == 1 && unitToken[0] == 'a':
+	case "a", "army":
 		return board.Army, nil
 	default:
 		return board.UnitType(""), fmt.Errorf("invalid unit type: %s", unitToken)
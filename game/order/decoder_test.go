@@ -201,3 +201,104 @@ func TestDecode_NoSpaceBetweenSuffixAndMove(t *testing.T) {
 	_, err := order.Decode(convoy, country)
 	is.Err(err)
 }
+
+func TestDecode_MoveFromCoast(t *testing.T) {
+	for _, move := range []string{"F Spa/sc-Mar", "F Spa(sc)-Mar", "f spa/sc - mar", "  F  Spa/sc-Mar "} {
+		t.Run(move, func(t *testing.T) {
+			is := is.New(t)
+			decMove, err := order.Decode(move, country)
+			is.NoErr(err)
+			is.Equal(order.Move{
+				UnitType: board.Fleet,
+				From:     board.LookupTerritory("spa/sc"),
+				To:       board.LookupTerritory("mar"),
+				Country:  country,
+			}, decMove)
+		})
+	}
+}
+
+func TestDecode_MoveViaConvoy(t *testing.T) {
+	is := is.New(t)
+	decMove, err := order.Decode("A Vie-Bud via convoy", country)
+	is.NoErr(err)
+	is.Equal(order.Move{UnitType: board.Army, From: vie, To: bud, Country: country, ViaConvoy: true}, decMove)
+}
+
+func TestDecode_SupportUnitHoldWithHoldToken(t *testing.T) {
+	is := is.New(t)
+	decSupport, err := order.Decode("A Gal S A Vie H", country)
+	is.NoErr(err)
+	is.Equal(order.HoldSupport{
+		UnitType: board.Army,
+		By:       gal,
+		Hold:     order.Hold{UnitType: board.Army, At: vie},
+		Country:  country,
+	}, decSupport)
+}
+
+func TestDecode_Retreat(t *testing.T) {
+	is := is.New(t)
+	decRetreat, err := order.Decode("A Vie R Bud", country)
+	is.NoErr(err)
+	is.Equal(order.Retreat{UnitType: board.Army, From: vie, To: bud, Country: country}, decRetreat)
+}
+
+func TestDecode_Disband(t *testing.T) {
+	for _, disband := range []string{"A Vie D", "A Vie disband", "disband A Vie"} {
+		t.Run(disband, func(t *testing.T) {
+			is := is.New(t)
+			decDisband, err := order.Decode(disband, country)
+			is.NoErr(err)
+			is.Equal(order.Disband{UnitType: board.Army, At: vie, Country: country}, decDisband)
+		})
+	}
+}
+
+func TestDecode_Build(t *testing.T) {
+	is := is.New(t)
+	decBuild, err := order.Decode("build F Stp/nc", country)
+	is.NoErr(err)
+	is.Equal(order.Build{UnitType: board.Fleet, At: board.LookupTerritory("stp/nc"), Country: country}, decBuild)
+}
+
+func TestDecode_UnknownTerritory_Errors(t *testing.T) {
+	for _, o := range []string{
+		"A Xyz-Bud",
+		"A Vie-Xyz",
+		"A Xyz H",
+		"A Gal S A Xyz",
+		"A Gal S A Vie-Xyz",
+		"F Xyz C A Vie-Bud",
+		"A Vie R Xyz",
+		"build A Xyz",
+		"F Spa/xc-Mar",
+	} {
+		t.Run(o, func(t *testing.T) {
+			is := is.New(t)
+			_, err := order.Decode(o, country)
+			is.Err(err)
+		})
+	}
+}
+
+func TestDecode_Incomplete_Errors(t *testing.T) {
+	for _, o := range []string{
+		"",
+		"A",
+		"A Vie",
+		"A Vie-",
+		"A Vie-Bud via",
+		"A Vie R",
+		"build A",
+		"build Vie",
+		"A Gal S",
+		"A Vie H H",
+	} {
+		t.Run(o, func(t *testing.T) {
+			is := is.New(t)
+			_, err := order.Decode(o, country)
+			is.Err(err)
+		})
+	}
+}
@@ -7,6 +7,8 @@ type Move struct {
 	UnitType board.UnitType
 	From, To board.Territory
 	Strength int
+	// ViaConvoy records that the order asked to go by convoy.
+	ViaConvoy bool
 }
 
 type MoveSupport struct {
@@ -35,11 +37,36 @@ type HoldSupport struct {
 	Hold     Hold
 }
 
+// Retreat moves a unit dislodged at From to To in the retreat phase.
+type Retreat struct {
+	Country  string
+	UnitType board.UnitType
+	From, To board.Territory
+}
+
+// Disband removes the unit at At, in the retreat or adjustment phase.
+type Disband struct {
+	Country  string
+	UnitType board.UnitType
+	At       board.Territory
+}
+
+// Build places a new unit at At in the adjustment phase.
+type Build struct {
+	Country  string
+	UnitType board.UnitType
+	At       board.Territory
+}
+
 type Set struct {
 	Moves        []Move
 	MoveSupports []MoveSupport
 	Holds        []Hold
 	HoldSupports []HoldSupport
+	MoveConvoys  []MoveConvoy
+	Retreats     []Retreat
+	Disbands     []Disband
+	Builds       []Build
 }
 
 func (s *Set) AddMove(m Move) {
@@ -58,6 +85,18 @@ func (s *Set) AddHoldSupport(sup HoldSupport) {
 	s.HoldSupports = append(s.HoldSupports, sup)
 }
 
-func (s *Set) AddMoveConvoy(sup MoveConvoy) {
+func (s *Set) AddMoveConvoy(c MoveConvoy) {
+	s.MoveConvoys = append(s.MoveConvoys, c)
+}
+
+func (s *Set) AddRetreat(r Retreat) {
+	s.Retreats = append(s.Retreats, r)
+}
+
+func (s *Set) AddDisband(d Disband) {
+	s.Disbands = append(s.Disbands, d)
+}
 
+func (s *Set) AddBuild(b Build) {
+	s.Builds = append(s.Builds, b)
 }
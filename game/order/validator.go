@@ -1,41 +1,190 @@
 package order
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/burrbd/dip/game/order/board"
 )
 
+// Reasons an order can be invalid. InvalidOrderError wraps one of these so
+// callers can test with errors.Is.
+var (
+	// ErrWrongCountry means the unit belongs to another country.
+	ErrWrongCountry = errors.New("unit belongs to another country")
+	// ErrWrongUnitType means the order names a different type of unit from
+	// the one in the territory.
+	ErrWrongUnitType = errors.New("wrong unit type")
+	// ErrUnreachable means the unit cannot move to the target by its own
+	// movement rules.
+	ErrUnreachable = errors.New("cannot reach")
+	// ErrCoastRequired means a fleet could reach more than one coast of the
+	// target and none was named.
+	ErrCoastRequired = errors.New("coast required")
+	// ErrSupportSelf means a unit supports into its own territory.
+	ErrSupportSelf = errors.New("support into own territory")
+	// ErrNotAtSea means a convoying fleet is not at sea.
+	ErrNotAtSea = errors.New("convoying fleet not at sea")
+	// ErrNotArmy means a convoy carries a unit that is not an army.
+	ErrNotArmy = errors.New("only armies can be convoyed")
+)
+
+// InvalidOrderError reports why the order of the unit at At is invalid.
+// An invalid order leaves the unit holding.
+type InvalidOrderError struct {
+	At board.Territory
+	// Target is the territory the order moves, supports or convoys to.
+	Target board.Territory
+	// Reason is one of the Err values above.
+	Reason error
+}
+
+func (e *InvalidOrderError) Error() string {
+	if e.Target.Abbr == "" {
+		return fmt.Sprintf("invalid order at %s: %v", e.At, e.Reason)
+	}
+	return fmt.Sprintf("invalid order from %s to %s: %v", e.At, e.Target, e.Reason)
+}
+
+// Unwrap exposes Reason so errors.Is(err, ErrUnreachable) works.
+func (e *InvalidOrderError) Unwrap() error { return e.Reason }
+
 type simpleGraph interface {
 	HasEdgeBetween(xid, yid int64) bool
 }
 
 type Validator struct {
-	armyGraph simpleGraph
+	armyGraph  simpleGraph
+	fleetGraph simpleGraph
 }
 
-func NewValidator(g simpleGraph) Validator {
-	return Validator{armyGraph: g}
+func NewValidator(armyGraph, fleetGraph simpleGraph) Validator {
+	return Validator{armyGraph: armyGraph, fleetGraph: fleetGraph}
 }
 
+// ValidateMove checks move against the movement rules of its unit type:
+// armies along the army graph, fleets along the fleet graph.
 func (v Validator) ValidateMove(unit board.Unit, move Move) error {
-	if move.Country != unit.Country {
-		return fmt.Errorf("invalid country: %s", move.Country)
+	if err := checkUnit(unit, move.Country, move.UnitType, move.From); err != nil {
+		return err
+	}
+	if move.UnitType == board.Fleet {
+		return v.validateFleetMove(move)
 	}
 	if !v.armyGraph.HasEdgeBetween(move.From.ID(), move.To.ID()) {
-		return fmt.Errorf("cannot move from %s to %s", move.From.Abbr, move.To.Abbr)
+		return &InvalidOrderError{At: move.From, Target: move.To, Reason: ErrUnreachable}
+	}
+	return nil
+}
+
+// validateFleetMove checks a fleet move coast by coast. A destination with
+// split coasts needs its coast named unless the fleet can reach only one of
+// them; a fleet whose coast is not known may leave from either.
+func (v Validator) validateFleetMove(move Move) error {
+	reachable := 0
+	for _, to := range coastsOf(move.To) {
+		if v.fleetReaches(move.From, to) {
+			reachable++
+		}
+	}
+	switch {
+	case reachable == 0:
+		return &InvalidOrderError{At: move.From, Target: move.To, Reason: ErrUnreachable}
+	case reachable > 1:
+		return &InvalidOrderError{At: move.From, Target: move.To, Reason: ErrCoastRequired}
+	}
+	return nil
+}
+
+// fleetReaches reports whether a fleet at from, on any of its coasts when
+// none is named, can move to to.
+func (v Validator) fleetReaches(from, to board.Territory) bool {
+	for _, f := range coastsOf(from) {
+		if v.fleetGraph.HasEdgeBetween(f.ID(), to.ID()) {
+			return true
+		}
+	}
+	return false
+}
+
+// reaches reports whether a unit of unitType at from could move into to's
+// province. A fleet need only reach one of its coasts: a fleet may support
+// into a coast it could not move to itself.
+func (v Validator) reaches(unitType board.UnitType, from, to board.Territory) bool {
+	if unitType != board.Fleet {
+		return v.armyGraph.HasEdgeBetween(from.ID(), board.LookupTerritory(to.Abbr).ID())
+	}
+	targets := board.Coasts(to)
+	if len(targets) == 0 {
+		targets = []board.Territory{to}
+	}
+	for _, t := range targets {
+		if v.fleetReaches(from, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// coastsOf returns t when its coast is named or not split, and otherwise
+// every coast of t.
+func coastsOf(t board.Territory) []board.Territory {
+	if coasts := board.Coasts(t); t.Coast == "" && len(coasts) > 0 {
+		return coasts
+	}
+	return []board.Territory{t}
+}
+
+// checkUnit checks that the unit at at belongs to country and, when its
+// type is known, is of unitType.
+func checkUnit(unit board.Unit, country string, unitType board.UnitType, at board.Territory) error {
+	if country != unit.Country {
+		return &InvalidOrderError{At: at, Reason: ErrWrongCountry}
+	}
+	if unit.Type != "" && unitType != unit.Type {
+		return &InvalidOrderError{At: at, Reason: ErrWrongUnitType}
 	}
 	return nil
 }
 
+// ValidateMoveSupport checks that the supporting unit could itself move into
+// the territory the supported move goes to, and that it is not its own.
 func (v Validator) ValidateMoveSupport(unit board.Unit, sup MoveSupport) error {
-	if sup.Country != unit.Country {
-		return fmt.Errorf("invalid country: %s", sup.Country)
+	if err := checkUnit(unit, sup.Country, sup.UnitType, sup.By); err != nil {
+		return err
 	}
+	return v.validateSupport(sup.UnitType, sup.By, sup.Move.To)
+}
 
-	if !v.armyGraph.HasEdgeBetween(sup.By.ID(), sup.Move.To.ID()) {
-		return fmt.Errorf("cannot support to %s by %s", sup.Move.To.Abbr, sup.By.Abbr)
+// ValidateHoldSupport checks that the supporting unit could itself move into
+// the territory it supports, and that it is not its own.
+func (v Validator) ValidateHoldSupport(unit board.Unit, sup HoldSupport) error {
+	if err := checkUnit(unit, sup.Country, sup.UnitType, sup.By); err != nil {
+		return err
+	}
+	return v.validateSupport(sup.UnitType, sup.By, sup.Hold.At)
+}
+
+func (v Validator) validateSupport(unitType board.UnitType, by, to board.Territory) error {
+	if by.Is(to) {
+		return &InvalidOrderError{At: by, Target: to, Reason: ErrSupportSelf}
+	}
+	if !v.reaches(unitType, by, to) {
+		return &InvalidOrderError{At: by, Target: to, Reason: ErrUnreachable}
 	}
+	return nil
+}
 
+// ValidateMoveConvoy checks that a fleet at sea convoys an army.
+func (v Validator) ValidateMoveConvoy(unit board.Unit, c MoveConvoy) error {
+	if err := checkUnit(unit, c.Country, board.Fleet, c.By); err != nil {
+		return err
+	}
+	if !c.By.Sea() {
+		return &InvalidOrderError{At: c.By, Reason: ErrNotAtSea}
+	}
+	if c.Move.UnitType != "" && c.Move.UnitType != board.Army {
+		return &InvalidOrderError{At: c.By, Target: c.Move.From, Reason: ErrNotArmy}
+	}
 	return nil
 }
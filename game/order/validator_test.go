@@ -1,6 +1,7 @@
 package order_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/burrbd/dip/game/order"
@@ -11,11 +12,12 @@ import (
 func TestValidator_ValidateMove(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		is := is.New(t)
-		v := order.NewValidator(&mockSimpleGraph{
+		g := &mockSimpleGraph{
 			hasEdgeBetweenFunc: func(_, _ int64) bool {
 				return true
 			},
-		})
+		}
+		v := order.NewValidator(g, g)
 		u := board.Unit{Country: "fr"}
 		m := order.Move{Country: "fr"}
 		is.NoErr(v.ValidateMove(u, m))
@@ -23,7 +25,7 @@ func TestValidator_ValidateMove(t *testing.T) {
 
 	t.Run("country mismatch", func(t *testing.T) {
 		is := is.New(t)
-		v := order.NewValidator(&mockSimpleGraph{})
+		v := order.NewValidator(&mockSimpleGraph{}, &mockSimpleGraph{})
 		u := board.Unit{Country: "fr"}
 		m := order.Move{Country: "bogus"}
 		is.Err(v.ValidateMove(u, m))
@@ -36,30 +38,94 @@ func TestValidator_ValidateMove(t *testing.T) {
 				return false
 			},
 		}
-		v := order.NewValidator(g)
+		v := order.NewValidator(g, &mockSimpleGraph{})
 		u := board.Unit{Country: "fr"}
 		m := order.Move{Country: "fr", From: board.Territory{}, To: board.Territory{}}
 		is.Err(v.ValidateMove(u, m))
 		is.True(g.called)
 	})
+
+	t.Run("fleet moves along fleet graph", func(t *testing.T) {
+		is := is.New(t)
+		army := &mockSimpleGraph{}
+		fleet := &mockSimpleGraph{
+			hasEdgeBetweenFunc: func(_, _ int64) bool {
+				return true
+			},
+		}
+		v := order.NewValidator(army, fleet)
+		u := board.Unit{Country: "fr", Type: board.Fleet}
+		m := order.Move{Country: "fr", UnitType: board.Fleet}
+		is.NoErr(v.ValidateMove(u, m))
+		is.True(fleet.called)
+		is.False(army.called)
+	})
+
+	t.Run("unit type mismatch", func(t *testing.T) {
+		is := is.New(t)
+		v := order.NewValidator(&mockSimpleGraph{}, &mockSimpleGraph{})
+		u := board.Unit{Country: "fr", Type: board.Army}
+		m := order.Move{Country: "fr", UnitType: board.Fleet}
+		is.Err(v.ValidateMove(u, m))
+	})
+}
+
+func TestValidator_ValidateMove_Classical(t *testing.T) {
+	lu := board.LookupTerritory
+	v := order.NewValidator(board.CreateArmyGraph(), board.CreateFleetGraph())
+
+	specs := []struct {
+		unit     board.UnitType
+		from, to string
+		valid    bool
+	}{
+		{board.Army, "lvp", "yor", true},
+		{board.Army, "lvp", "iri", false},
+		{board.Fleet, "lon", "nth", true},
+		{board.Fleet, "nth", "pic", false},
+		{board.Fleet, "kie", "mun", false},
+		{board.Fleet, "kie", "kie", false},
+		{board.Fleet, "rom", "ven", false},
+		{board.Fleet, "por", "spa", false},
+		{board.Fleet, "por", "spa/nc", true},
+		{board.Fleet, "gas", "spa", true},
+		{board.Fleet, "mar", "spa(nc)", false},
+		{board.Fleet, "spa/nc", "mar", false},
+		{board.Fleet, "spa/sc", "mar", true},
+		{board.Fleet, "bla", "bul/ec", true},
+		{board.Fleet, "nwy", "stp/sc", false},
+	}
+	for _, s := range specs {
+		t.Run(string(s.unit)+" "+s.from+"-"+s.to, func(t *testing.T) {
+			is := is.New(t)
+			u := board.Unit{Country: "fr", Type: s.unit}
+			m := order.Move{Country: "fr", UnitType: s.unit, From: lu(s.from), To: lu(s.to)}
+			is.Equal(s.valid, v.ValidateMove(u, m) == nil)
+		})
+	}
 }
 
 func TestValidator_ValidateMoveSupport(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		is := is.New(t)
-		v := order.NewValidator(&mockSimpleGraph{
+		g := &mockSimpleGraph{
 			hasEdgeBetweenFunc: func(_, _ int64) bool {
 				return true
 			},
-		})
+		}
+		v := order.NewValidator(g, g)
 		u := board.Unit{Country: "fr"}
-		m := order.MoveSupport{Country: "fr"}
+		m := order.MoveSupport{
+			Country: "fr",
+			By:      board.Territory{Abbr: "bur"},
+			Move:    order.Move{From: board.Territory{Abbr: "par"}, To: board.Territory{Abbr: "pic"}},
+		}
 		is.NoErr(v.ValidateMoveSupport(u, m))
 	})
 
 	t.Run("country mismatch", func(t *testing.T) {
 		is := is.New(t)
-		v := order.NewValidator(&mockSimpleGraph{})
+		v := order.NewValidator(&mockSimpleGraph{}, &mockSimpleGraph{})
 
 		u := board.Unit{Country: "fr"}
 		s := order.MoveSupport{Country: "bougus"}
@@ -74,16 +140,174 @@ func TestValidator_ValidateMoveSupport(t *testing.T) {
 				return false
 			},
 		}
-		v := order.NewValidator(g)
+		v := order.NewValidator(g, &mockSimpleGraph{})
 		u := board.Unit{}
 		s := order.MoveSupport{
-			By:   board.Territory{},
-			Move: order.Move{From: board.Territory{}, To: board.Territory{}},
+			By:   board.Territory{Abbr: "bur"},
+			Move: order.Move{From: board.Territory{Abbr: "par"}, To: board.Territory{Abbr: "pic"}},
 		}
 
 		is.Err(v.ValidateMoveSupport(u, s))
 		is.True(g.called)
 	})
+
+	t.Run("support into own territory", func(t *testing.T) {
+		is := is.New(t)
+		v := order.NewValidator(&mockSimpleGraph{}, &mockSimpleGraph{})
+		u := board.Unit{Country: "fr"}
+		s := order.MoveSupport{
+			Country: "fr",
+			By:      board.Territory{Abbr: "pru"},
+			Move:    order.Move{From: board.Territory{Abbr: "lvn"}, To: board.Territory{Abbr: "pru"}},
+		}
+
+		is.True(errors.Is(v.ValidateMoveSupport(u, s), order.ErrSupportSelf))
+	})
+}
+
+func TestValidator_ValidateSupport_Classical(t *testing.T) {
+	lu := board.LookupTerritory
+	v := order.NewValidator(board.CreateArmyGraph(), board.CreateFleetGraph())
+
+	specs := []struct {
+		unit   board.UnitType
+		by, to string
+		reason error
+	}{
+		{board.Army, "tyr", "tri", nil},
+		{board.Army, "bud", "tri", nil},
+		{board.Army, "bud", "rum", nil},
+		{board.Army, "sil", "vie", order.ErrUnreachable},
+		{board.Army, "tri", "tri", order.ErrSupportSelf},
+		{board.Army, "bur", "mun", nil},
+		{board.Fleet, "rom", "ven", order.ErrUnreachable},
+		{board.Fleet, "rom", "nap", nil},
+		{board.Fleet, "mar", "spa/nc", nil},
+		{board.Fleet, "spa/nc", "gol", order.ErrUnreachable},
+		{board.Fleet, "spa/sc", "gol", nil},
+		{board.Fleet, "bal", "pru", nil},
+		{board.Fleet, "kie", "mun", order.ErrUnreachable},
+	}
+	for _, s := range specs {
+		t.Run(string(s.unit)+" "+s.by+" to "+s.to, func(t *testing.T) {
+			is := is.New(t)
+			u := board.Unit{Country: "fr", Type: s.unit}
+			hold := order.HoldSupport{
+				Country:  "fr",
+				UnitType: s.unit,
+				By:       lu(s.by),
+				Hold:     order.Hold{At: lu(s.to)},
+			}
+			move := order.MoveSupport{
+				Country:  "fr",
+				UnitType: s.unit,
+				By:       lu(s.by),
+				Move:     order.Move{To: lu(s.to)},
+			}
+			is.Equal(s.reason, unwrap(v.ValidateHoldSupport(u, hold)))
+			is.Equal(s.reason, unwrap(v.ValidateMoveSupport(u, move)))
+		})
+	}
+}
+
+func TestValidator_ValidateHoldSupport(t *testing.T) {
+	t.Run("country mismatch", func(t *testing.T) {
+		is := is.New(t)
+		v := order.NewValidator(&mockSimpleGraph{}, &mockSimpleGraph{})
+		u := board.Unit{Country: "fr"}
+		s := order.HoldSupport{Country: "bogus"}
+		is.True(errors.Is(v.ValidateHoldSupport(u, s), order.ErrWrongCountry))
+	})
+
+	t.Run("unit type mismatch", func(t *testing.T) {
+		is := is.New(t)
+		v := order.NewValidator(&mockSimpleGraph{}, &mockSimpleGraph{})
+		u := board.Unit{Country: "fr", Type: board.Army}
+		s := order.HoldSupport{Country: "fr", UnitType: board.Fleet}
+		is.True(errors.Is(v.ValidateHoldSupport(u, s), order.ErrWrongUnitType))
+	})
+
+	t.Run("support to hold yourself", func(t *testing.T) {
+		is := is.New(t)
+		v := order.NewValidator(&mockSimpleGraph{}, &mockSimpleGraph{})
+		u := board.Unit{Country: "fr"}
+		s := order.HoldSupport{
+			Country: "fr",
+			By:      board.Territory{Abbr: "tri"},
+			Hold:    order.Hold{At: board.Territory{Abbr: "tri"}},
+		}
+		is.True(errors.Is(v.ValidateHoldSupport(u, s), order.ErrSupportSelf))
+	})
+}
+
+func TestValidator_ValidateMoveConvoy(t *testing.T) {
+	lu := board.LookupTerritory
+	v := order.NewValidator(&mockSimpleGraph{}, &mockSimpleGraph{})
+	specs := []struct {
+		desc   string
+		unit   board.Unit
+		convoy order.MoveConvoy
+		reason error
+	}{
+		{
+			desc:   "fleet at sea convoys an army",
+			unit:   board.Unit{Country: "en", Type: board.Fleet},
+			convoy: order.MoveConvoy{Country: "en", By: lu("nth"), Move: order.Move{UnitType: board.Army}},
+		},
+		{
+			desc:   "fleet of another country",
+			unit:   board.Unit{Country: "en", Type: board.Fleet},
+			convoy: order.MoveConvoy{Country: "fr", By: lu("nth"), Move: order.Move{UnitType: board.Army}},
+			reason: order.ErrWrongCountry,
+		},
+		{
+			desc:   "army convoys",
+			unit:   board.Unit{Country: "en", Type: board.Army},
+			convoy: order.MoveConvoy{Country: "en", By: lu("lon"), Move: order.Move{UnitType: board.Army}},
+			reason: order.ErrWrongUnitType,
+		},
+		{
+			desc:   "fleet on a coast",
+			unit:   board.Unit{Country: "en", Type: board.Fleet},
+			convoy: order.MoveConvoy{Country: "en", By: lu("lon"), Move: order.Move{UnitType: board.Army}},
+			reason: order.ErrNotAtSea,
+		},
+		{
+			desc:   "fleet convoys a fleet",
+			unit:   board.Unit{Country: "en", Type: board.Fleet},
+			convoy: order.MoveConvoy{Country: "en", By: lu("nth"), Move: order.Move{UnitType: board.Fleet}},
+			reason: order.ErrNotArmy,
+		},
+	}
+	for _, s := range specs {
+		t.Run(s.desc, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(s.reason, unwrap(v.ValidateMoveConvoy(s.unit, s.convoy)))
+		})
+	}
+}
+
+func TestInvalidOrderError(t *testing.T) {
+	is := is.New(t)
+	v := order.NewValidator(board.CreateArmyGraph(), board.CreateFleetGraph())
+	u := board.Unit{Country: "en", Type: board.Army}
+	m := order.Move{Country: "en", UnitType: board.Army, From: board.LookupTerritory("lvp"), To: board.LookupTerritory("iri")}
+
+	err := v.ValidateMove(u, m)
+
+	var invalid *order.InvalidOrderError
+	is.True(errors.As(err, &invalid))
+	is.Equal("lvp", invalid.At.Abbr)
+	is.Equal("iri", invalid.Target.Abbr)
+	is.Equal("invalid order from lvp to iri: cannot reach", err.Error())
+}
+
+// unwrap returns the reason an order is invalid, or nil.
+func unwrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errors.Unwrap(err)
 }
 
 type mockSimpleGraph struct {
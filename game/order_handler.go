@@ -8,44 +8,115 @@ import (
 type validator interface {
 	ValidateMove(board.Unit, order.Move) error
 	ValidateMoveSupport(board.Unit, order.MoveSupport) error
+	ValidateHoldSupport(board.Unit, order.HoldSupport) error
+	ValidateMoveConvoy(board.Unit, order.MoveConvoy) error
 }
 
-// OrderHandler applies orders to the board.
+// adjacency is a graph of territory edges, such as the one returned by
+// board.CreateConvoyGraph.
+type adjacency interface {
+	HasEdgeBetween(xid, yid int64) bool
+}
+
+// OrderHandler applies orders to the board. ConvoyGraph is the graph convoyed
+// armies travel along; without it no army is convoyed.
 type OrderHandler struct {
-	Validator validator
+	Validator   validator
+	ConvoyGraph adjacency
 }
 
 // ApplyOrders applies orders for a turn. Satisifies Handler interface
+//
+// Supports and convoys that fail validation are dropped and the units given
+// them hold, as do units given an invalid move. An army whose move needed a
+// convoy it did not get has failed rather than held, so it cannot be
+// supported to hold.
+//
+// A convoy is disrupted when a fleet in its only chain is dislodged, which
+// in turn can change which fleets are dislodged. Convoys are therefore
+// settled first by resolving the orders on scratch boards; see
+// convoyRoutes.settle for how paradoxes are broken. The settled orders are
+// then applied to manager.
 func (h OrderHandler) ApplyOrders(orders order.Set, manager board.Manager) {
+	positions := manager.Positions()
+	orders = h.validOrders(orders, positions)
+	routes := h.planConvoys(orders, positions)
+	routes.settle(func(try convoyRoutes) board.Manager {
+		scratch := board.NewPositionManager()
+		for unit, pos := range positions {
+			scratch.AddUnit(unit, pos.Territory)
+		}
+		h.apply(orders, scratch, try)
+		ResolveOrders(scratch)
+		return scratch
+	})
+	h.apply(orders, manager, routes)
+}
+
+func (h OrderHandler) apply(orders order.Set, manager board.Manager, routes convoyRoutes) {
 boardPositionLoop:
 	for unit, pos := range manager.Positions() {
 		for _, move := range orders.Moves {
 			if matchMoveToPosition(pos, move) {
-				if err := h.Validator.ValidateMove(*unit, move); err == nil {
-					manager.Move(unit, move.To, h.moveStrength(move, orders))
+				if _, ok := routes.convoyed[move.From.Abbr]; ok {
+					if routes.moving(move) {
+						manager.MoveByConvoy(unit, move.To, h.moveStrength(move, orders, routes))
+					}
+				} else if err := h.Validator.ValidateMove(*unit, move); err == nil {
+					manager.Move(unit, move.To, h.moveStrength(move, orders, routes))
+				} else if !routes.needed[move.From.Abbr] {
+					manager.Hold(unit, h.holdStrength(pos, orders, routes))
 				}
 				continue boardPositionLoop
 			}
 		}
-		manager.Hold(unit, h.holdStrength(pos, orders))
+		manager.Hold(unit, h.holdStrength(pos, orders, routes))
+	}
+}
+
+// validOrders returns orders without the supports and convoys that are
+// invalid for the unit in the territory they are given from, or that have
+// no unit there.
+func (h OrderHandler) validOrders(orders order.Set, positions map[*board.Unit]board.Position) order.Set {
+	units := make(map[string]*board.Unit)
+	for unit, pos := range positions {
+		units[pos.Territory.Abbr] = unit
+	}
+	valid := orders
+	valid.MoveSupports, valid.HoldSupports, valid.MoveConvoys = nil, nil, nil
+	for _, sup := range orders.MoveSupports {
+		if unit, ok := units[sup.By.Abbr]; ok && h.Validator.ValidateMoveSupport(*unit, sup) == nil {
+			valid.AddMoveSupport(sup)
+		}
+	}
+	for _, sup := range orders.HoldSupports {
+		if unit, ok := units[sup.By.Abbr]; ok && h.Validator.ValidateHoldSupport(*unit, sup) == nil {
+			valid.AddHoldSupport(sup)
+		}
+	}
+	for _, c := range orders.MoveConvoys {
+		if unit, ok := units[c.By.Abbr]; ok && h.Validator.ValidateMoveConvoy(*unit, c) == nil {
+			valid.AddMoveConvoy(c)
+		}
 	}
+	return valid
 }
 
-func (h OrderHandler) moveStrength(move order.Move, orders order.Set) (strength int) {
+func (h OrderHandler) moveStrength(move order.Move, orders order.Set, routes convoyRoutes) (strength int) {
 	for _, support := range orders.MoveSupports {
 		if support.Move.From.Abbr == move.From.Abbr &&
 			support.Move.To.Abbr == move.To.Abbr &&
-			!moveSupportCut(support, orders.Moves) {
+			!moveSupportCut(support, orders.Moves, routes) {
 			strength++
 		}
 	}
 	return
 }
 
-func (h OrderHandler) holdStrength(pos board.Position, orders order.Set) (strength int) {
+func (h OrderHandler) holdStrength(pos board.Position, orders order.Set, routes convoyRoutes) (strength int) {
 	for _, support := range orders.HoldSupports {
 		if support.Hold.At.Abbr == pos.Territory.Abbr &&
-			!holdSupportCut(support, orders.Moves) {
+			!holdSupportCut(support, orders.Moves, routes) {
 			strength++
 		}
 	}
@@ -56,18 +127,19 @@ func matchMoveToPosition(pos board.Position, move order.Move) bool {
 	return move.From.Abbr == pos.Territory.Abbr
 }
 
-func moveSupportCut(sup order.MoveSupport, moves []order.Move) bool {
+func moveSupportCut(sup order.MoveSupport, moves []order.Move, routes convoyRoutes) bool {
 	for _, cut := range moves {
-		if sup.By.Is(cut.To) && sup.Move.To.IsNot(cut.From) {
+		if sup.By.Is(cut.To) && sup.Move.To.IsNot(cut.From) &&
+			routes.cuts(cut) {
 			return true
 		}
 	}
 	return false
 }
 
-func holdSupportCut(sup order.HoldSupport, moves []order.Move) bool {
+func holdSupportCut(sup order.HoldSupport, moves []order.Move, routes convoyRoutes) bool {
 	for _, cut := range moves {
-		if sup.By.Is(cut.To) {
+		if sup.By.Is(cut.To) && routes.cuts(cut) {
 			return true
 		}
 	}
@@ -0,0 +1,146 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/burrbd/dip/game/order"
+	"github.com/burrbd/dip/game/order/board"
+)
+
+// UpdateSupplyCenters returns who owns each supply center at the end of the
+// year, keyed by territory abbr. A center with a unit on it passes to the
+// unit's country; an empty one keeps its owner. owners is not changed.
+func UpdateSupplyCenters(owners map[string]string, manager board.Manager) map[string]string {
+	updated := make(map[string]string, len(owners))
+	for abbr, country := range owners {
+		updated[abbr] = country
+	}
+	for u, pos := range manager.Positions() {
+		if standing(pos) && pos.Territory.SupplyCenter() {
+			updated[pos.Territory.Abbr] = u.Country
+		}
+	}
+	return updated
+}
+
+// Adjustments returns how many units each country builds, or disbands when
+// negative: the supply centers it owns less the units it has.
+func Adjustments(owners map[string]string, manager board.Manager) map[string]int {
+	counts := make(map[string]int)
+	for _, country := range owners {
+		counts[country]++
+	}
+	for u, pos := range manager.Positions() {
+		if standing(pos) {
+			counts[u.Country]--
+		}
+	}
+	return counts
+}
+
+// ApplyAdjustments carries out the adjustment phase given the supply center
+// owners returned by UpdateSupplyCenters.
+//
+// A country builds in order until it has no builds left. Each build must be
+// in one of its home centers that it still owns and that is empty, and be a
+// unit that can stand there. A country that disbands fewer units than it must
+// loses the rest to civil disorder: those farthest from its home centers go
+// first, fleets before armies, then in abbr order.
+func (h OrderHandler) ApplyAdjustments(orders order.Set, owners map[string]string, manager board.Manager) {
+	counts := Adjustments(owners, manager)
+	occupied := make(map[string]bool)
+	for _, pos := range manager.Positions() {
+		if standing(pos) {
+			occupied[pos.Territory.Abbr] = true
+		}
+	}
+
+	for _, b := range orders.Builds {
+		if counts[b.Country] <= 0 || occupied[b.At.Abbr] || !canBuild(b, owners) {
+			continue
+		}
+		manager.Build(&board.Unit{Country: b.Country, Type: b.UnitType}, b.At)
+		occupied[b.At.Abbr] = true
+		counts[b.Country]--
+	}
+
+	for _, d := range orders.Disbands {
+		if counts[d.Country] >= 0 {
+			continue
+		}
+		for u, pos := range manager.Positions() {
+			if standing(pos) && u.Country == d.Country && pos.Territory.Is(d.At) {
+				manager.Disband(u)
+				counts[d.Country]++
+				break
+			}
+		}
+	}
+
+	for country, n := range counts {
+		for _, u := range civilDisorder(country, manager) {
+			if n >= 0 {
+				break
+			}
+			manager.Disband(u)
+			n++
+		}
+	}
+}
+
+// canBuild reports whether b is in one of its country's home centers that
+// the country owns, for a unit that can stand there.
+func canBuild(b order.Build, owners map[string]string) bool {
+	if !b.At.SupplyCenter() || b.At.Home() != b.Country || owners[b.At.Abbr] != b.Country {
+		return false
+	}
+	switch b.UnitType {
+	case board.Army:
+		return b.At.Coast == ""
+	case board.Fleet:
+		return b.At.Coastal()
+	}
+	return false
+}
+
+// civilDisorder returns country's units in the order civil disorder
+// disbands them.
+func civilDisorder(country string, manager board.Manager) []*board.Unit {
+	homes := board.HomeCenters(country)
+	distance := func(terr board.Territory) int {
+		nearest := -1
+		for _, home := range homes {
+			if d := board.Distance(terr, home); d >= 0 && (nearest < 0 || d < nearest) {
+				nearest = d
+			}
+		}
+		return nearest
+	}
+
+	type candidate struct {
+		unit     *board.Unit
+		terr     board.Territory
+		distance int
+	}
+	var candidates []candidate
+	for u, pos := range manager.Positions() {
+		if standing(pos) && u.Country == country {
+			candidates = append(candidates, candidate{u, pos.Territory, distance(pos.Territory)})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.distance != b.distance {
+			return a.distance > b.distance
+		}
+		if a.unit.Type != b.unit.Type {
+			return a.unit.Type == board.Fleet
+		}
+		return a.terr.Abbr < b.terr.Abbr
+	})
+	units := make([]*board.Unit, len(candidates))
+	for i, c := range candidates {
+		units[i] = c.unit
+	}
+	return units
+}
@@ -0,0 +1,185 @@
+package game_test
+
+import (
+	"testing"
+
+	"github.com/burrbd/dip/game"
+	"github.com/burrbd/dip/game/order"
+	"github.com/burrbd/dip/game/order/board"
+	"github.com/cheekybits/is"
+)
+
+// boardOf places units written as "country type abbr", e.g.
+// "England fleet lon", and returns the board with the units keyed by abbr.
+func boardOf(units ...[3]string) (board.PositionManager, map[string]*board.Unit) {
+	manager := board.NewPositionManager()
+	byAbbr := make(map[string]*board.Unit)
+	for _, u := range units {
+		unit := &board.Unit{Country: u[0], Type: board.UnitType(u[1])}
+		terr := board.LookupTerritory(u[2])
+		manager.AddUnit(unit, terr)
+		byAbbr[terr.Abbr] = unit
+	}
+	return manager, byAbbr
+}
+
+func build(country string, unitType board.UnitType, at string) order.Build {
+	return order.Build{Country: country, UnitType: unitType, At: board.LookupTerritory(at)}
+}
+
+// builtAt returns the type of each unit built on manager, keyed by territory.
+func builtAt(manager board.PositionManager) map[string]board.UnitType {
+	built := make(map[string]board.UnitType)
+	for u, pos := range manager.Positions() {
+		if pos.Cause == board.Built {
+			built[pos.Territory.String()] = u.Type
+		}
+	}
+	return built
+}
+
+func TestUpdateSupplyCenters(t *testing.T) {
+	is := is.New(t)
+	manager, units := boardOf(
+		[3]string{"England", "army", "bel"},
+		[3]string{"England", "army", "wal"},
+		[3]string{"France", "army", "bur"},
+		[3]string{"Germany", "army", "hol"},
+	)
+	manager.SetDefeated(units["hol"])
+	owners := map[string]string{"bel": "France", "bre": "France", "hol": "England"}
+
+	updated := game.UpdateSupplyCenters(owners, manager)
+
+	is.Equal(map[string]string{"bel": "England", "bre": "France", "hol": "England"}, updated)
+	is.Equal("France", owners["bel"])
+}
+
+func TestAdjustments(t *testing.T) {
+	is := is.New(t)
+	manager, _ := boardOf(
+		[3]string{"England", "army", "lvp"},
+		[3]string{"France", "army", "par"},
+		[3]string{"France", "army", "bur"},
+		[3]string{"France", "army", "gas"},
+	)
+	owners := map[string]string{"lvp": "England", "lon": "England", "edi": "England", "par": "France", "mar": "France"}
+
+	is.Equal(map[string]int{"England": 2, "France": -1}, game.Adjustments(owners, manager))
+}
+
+func TestApplyAdjustments_Builds(t *testing.T) {
+	russia := map[string]string{"mos": "Russia", "sev": "Russia", "stp": "Russia", "war": "Russia", "rum": "Russia"}
+	specs := []struct {
+		desc   string
+		owners map[string]string
+		builds []order.Build
+		built  map[string]board.UnitType
+	}{
+		{
+			desc:   "army in an owned empty home center",
+			owners: russia,
+			builds: []order.Build{build("Russia", board.Army, "war")},
+			built:  map[string]board.UnitType{"war": board.Army},
+		},
+		{
+			desc:   "fleet on a named coast",
+			owners: russia,
+			builds: []order.Build{build("Russia", board.Fleet, "stp/nc")},
+			built:  map[string]board.UnitType{"stp/nc": board.Fleet},
+		},
+		{
+			desc:   "fleet without a coast where coasts are split",
+			owners: russia,
+			builds: []order.Build{build("Russia", board.Fleet, "stp")},
+			built:  map[string]board.UnitType{},
+		},
+		{
+			desc:   "fleet inland",
+			owners: russia,
+			builds: []order.Build{build("Russia", board.Fleet, "mos")},
+			built:  map[string]board.UnitType{},
+		},
+		{
+			desc:   "center that is not a home center",
+			owners: russia,
+			builds: []order.Build{build("Russia", board.Army, "rum")},
+			built:  map[string]board.UnitType{},
+		},
+		{
+			desc:   "home center owned by another country",
+			owners: map[string]string{"mos": "Russia", "sev": "Turkey", "stp": "Russia", "war": "Russia", "rum": "Russia"},
+			builds: []order.Build{build("Russia", board.Fleet, "sev")},
+			built:  map[string]board.UnitType{},
+		},
+		{
+			desc:   "occupied home center",
+			owners: russia,
+			builds: []order.Build{build("Russia", board.Army, "mos")},
+			built:  map[string]board.UnitType{},
+		},
+		{
+			desc:   "no more builds than centers allow",
+			owners: map[string]string{"mos": "Russia", "sev": "Russia", "stp": "Russia", "war": "Russia"},
+			builds: []order.Build{
+				build("Russia", board.Army, "war"),
+				build("Russia", board.Fleet, "sev"),
+				build("Russia", board.Fleet, "stp/sc"),
+			},
+			built: map[string]board.UnitType{"war": board.Army, "sev": board.Fleet},
+		},
+	}
+	for _, spec := range specs {
+		t.Run(spec.desc, func(t *testing.T) {
+			is := is.New(t)
+			manager, _ := boardOf(
+				[3]string{"Russia", "army", "mos"},
+				[3]string{"Russia", "army", "ukr"},
+				[3]string{"Turkey", "fleet", "bla"},
+			)
+
+			handler := newOrderHandler()
+			handler.ApplyAdjustments(order.Set{Builds: spec.builds}, spec.owners, manager)
+
+			is.Equal(spec.built, builtAt(manager))
+		})
+	}
+}
+
+func TestApplyAdjustments_Disbands(t *testing.T) {
+	is := is.New(t)
+	manager, units := boardOf(
+		[3]string{"Germany", "army", "mun"},
+		[3]string{"Germany", "army", "bur"},
+		[3]string{"Germany", "fleet", "nth"},
+		[3]string{"Germany", "army", "ukr"},
+	)
+	owners := map[string]string{"mun": "Germany"}
+
+	handler := newOrderHandler()
+	handler.ApplyAdjustments(order.Set{Disbands: []order.Disband{
+		{Country: "Germany", UnitType: board.Army, At: board.LookupTerritory("bur")},
+	}}, owners, manager)
+
+	is.Equal(board.Disbanded, manager.Position(units["bur"]).Cause)
+	// Civil disorder takes the rest: Ukraine is three moves from the
+	// nearest home center and the North Sea two.
+	is.Equal(board.Disbanded, manager.Position(units["ukr"]).Cause)
+	is.Equal(board.Disbanded, manager.Position(units["nth"]).Cause)
+	is.Equal(board.UnitPlaced, manager.Position(units["mun"]).Cause)
+}
+
+func TestApplyAdjustments_CivilDisorderDisbandsFleetsFirst(t *testing.T) {
+	is := is.New(t)
+	manager, units := boardOf(
+		[3]string{"England", "army", "yor"},
+		[3]string{"England", "fleet", "nth"},
+	)
+	owners := map[string]string{"lon": "England"}
+
+	handler := newOrderHandler()
+	handler.ApplyAdjustments(order.Set{}, owners, manager)
+
+	is.Equal(board.Disbanded, manager.Position(units["nth"]).Cause)
+	is.Equal(board.UnitPlaced, manager.Position(units["yor"]).Cause)
+}
@@ -21,9 +21,9 @@ import (
 // watch it fail, implement the feature, watch it pass. See CLAUDE.md for the
 // recommended implementation order.
 //
-// Sea-territory abbreviations used in commented-out fleet tests:
+// Sea-territory abbreviations used in fleet and convoy tests:
 //
-//	adr=Adriatic Sea, aeg=Aegean Sea, bal=Baltic Sea, bla=Black Sea,
+//	adr=Adriatic Sea, aeg=Aegean Sea, bal=Baltic Sea, bar=Barents Sea, bla=Black Sea,
 //	ech=English Channel, eme=Eastern Mediterranean, gob=Gulf of Bothnia,
 //	gol=Gulf of Lyon, hel=Helgoland Bight, ion=Ionian Sea, iri=Irish Sea,
 //	mao=Mid-Atlantic Ocean, nao=North Atlantic Ocean, nth=North Sea,
@@ -120,7 +120,7 @@ var specs = []spec{
 		orders: []*result{
 			{order: "A Vie-Bud", position: "vie"},
 			{order: "A Bud-Vie", position: "bud"},
-			{order: "A Sil S A Bud-Vie", position: "sil"},
+			{order: "A Gal S A Bud-Vie", position: "gal"},
 			{order: "A Boh-Vie", position: "boh"},
 			{order: "A Tyr S A Boh-Vie", position: "tyr"},
 		},
@@ -183,76 +183,59 @@ var specs = []spec{
 
 	// ===== DATC 6.A. BASIC CHECKS =====
 	//
-	// 6.A.1 through 6.A.10 require the fleet model (sea territories, fleet
-	// adjacency graph) or army-to-sea validation. Uncomment when implementing
-	// Phase 4 (fleet model). See CLAUDE.md.
+	// 6.A.6 is still commented out; it notes what it needs.
 
-	/*
-		// DATC 6.A.1. MOVING TO AN AREA THAT IS NOT A NEIGHBOUR
-		// Needs: fleet model + sea territories (nth, pic adjacency check)
-		// England: F North Sea - Picardy → order should fail; fleet stays in nth
-		{
-			description: "DATC 6.A.1 - moving to an area that is not a neighbour",
-			orders: []*result{
-				{order: "F Nth-Pic", position: "nth"},
-			},
+	// DATC 6.A.1. MOVING TO AN AREA THAT IS NOT A NEIGHBOUR
+	// England: F North Sea - Picardy → order should fail; fleet stays in nth
+	{
+		description: "DATC 6.A.1 - moving to an area that is not a neighbour",
+		orders: []*result{
+			{order: "F Nth-Pic", position: "nth"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.A.2. MOVE ARMY TO SEA
-		// Needs: sea territories and army-to-sea validation
-		// England: A Liverpool - Irish Sea → order should fail
-		{
-			description: "DATC 6.A.2 - move army to sea",
-			orders: []*result{
-				{order: "A Lvp-Iri", position: "lvp"},
-			},
+	// DATC 6.A.2. MOVE ARMY TO SEA
+	// England: A Liverpool - Irish Sea → order should fail
+	{
+		description: "DATC 6.A.2 - move army to sea",
+		orders: []*result{
+			{order: "A Lvp-Iri", position: "lvp"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.A.3. MOVE FLEET TO LAND
-		// Needs: fleet model; fleet cannot enter land territory
-		// Germany: F Kiel - Munich → order should fail
-		{
-			description: "DATC 6.A.3 - move fleet to land",
-			orders: []*result{
-				{order: "F Kie-Mun", position: "kie"},
-			},
+	// DATC 6.A.3. MOVE FLEET TO LAND
+	// Germany: F Kiel - Munich → order should fail
+	{
+		description: "DATC 6.A.3 - move fleet to land",
+		orders: []*result{
+			{order: "F Kie-Mun", position: "kie"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.A.4. MOVE TO OWN SECTOR
-		// Needs: fleet model; moving to current territory is illegal
-		// Germany: F Kiel - Kiel → program should not crash
-		{
-			description: "DATC 6.A.4 - move to own sector",
-			orders: []*result{
-				{order: "F Kie-Kie", position: "kie"},
-			},
+	// DATC 6.A.4. MOVE TO OWN SECTOR
+	// Germany: F Kiel - Kiel → program should not crash
+	{
+		description: "DATC 6.A.4 - move to own sector",
+		orders: []*result{
+			{order: "F Kie-Kie", position: "kie"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.A.5. MOVE TO OWN SECTOR WITH CONVOY
-		// Needs: fleet model + convoy model
-		// England: F North Sea Convoys A Yorkshire - Yorkshire (illegal)
-		//          A Yorkshire - Yorkshire, A Liverpool Supports A Yorkshire - Yorkshire
-		// Germany: F London - Yorkshire, A Wales Supports F London - Yorkshire
-		// Result: Yorkshire's self-move is illegal; German dislodges Yorkshire army.
-		{
-			description: "DATC 6.A.5 - move to own sector with convoy",
-			orders: []*result{
-				{order: "F Nth C A Yor-Yor", position: "nth"},
-				{order: "A Yor-Yor", position: "yor", defeated: true},
-				{order: "A Lvp S A Yor-Yor", position: "lvp"},
-				{order: "F Lon-Yor", position: "yor"},
-				{order: "A Wal S F Lon-Yor", position: "wal"},
-			},
+	// DATC 6.A.5. MOVE TO OWN SECTOR WITH CONVOY
+	// England: F North Sea Convoys A Yorkshire - Yorkshire (illegal)
+	//          A Yorkshire - Yorkshire, A Liverpool Supports A Yorkshire - Yorkshire
+	// Germany: F London - Yorkshire, A Wales Supports F London - Yorkshire
+	// Result: Yorkshire's self-move is illegal; German dislodges Yorkshire army.
+	{
+		description: "DATC 6.A.5 - move to own sector with convoy",
+		orders: []*result{
+			{order: "F Nth C A Yor-Yor", position: "nth"},
+			{order: "A Yor-Yor", position: "yor", defeated: true},
+			{order: "A Lvp S A Yor-Yor", position: "lvp"},
+			{order: "F Lon-Yor", position: "yor"},
+			{order: "A Wal S F Lon-Yor", position: "wal"},
 		},
-	*/
+	},
 
 	/*
 		// DATC 6.A.6. ORDERING A UNIT OF ANOTHER COUNTRY
@@ -266,66 +249,51 @@ var specs = []spec{
 		},
 	*/
 
-	/*
-		// DATC 6.A.7. ONLY ARMIES CAN BE CONVOYED
-		// Needs: fleet model + convoy validation
-		// England: F London - Belgium (fleet cannot be convoyed), F North Sea Convoys A London - Belgium
-		// Move from London to Belgium should fail.
-		{
-			description: "DATC 6.A.7 - only armies can be convoyed",
-			orders: []*result{
-				{order: "F Lon-Bel", position: "lon"},
-				{order: "F Nth C A Lon-Bel", position: "nth"},
-			},
+	// DATC 6.A.7. ONLY ARMIES CAN BE CONVOYED
+	// England: F London - Belgium (fleet cannot be convoyed), F North Sea Convoys A London - Belgium
+	// Move from London to Belgium should fail.
+	{
+		description: "DATC 6.A.7 - only armies can be convoyed",
+		orders: []*result{
+			{order: "F Lon-Bel", position: "lon"},
+			{order: "F Nth C A Lon-Bel", position: "nth"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.A.8. SUPPORT TO HOLD YOURSELF IS NOT POSSIBLE
-		// Needs: self-support validation (a unit cannot support itself holding)
-		// Italy: A Venice - Trieste, A Tyrolia Supports A Venice - Trieste
-		// Austria: F Trieste Supports F Trieste (illegal self-support)
-		// Result: Trieste is dislodged (self-support adds 0 strength).
-		// Note: with army-only engine and no self-support check, this test passes
-		// already — Trieste's "support" of itself is simply ignored by the engine.
-		// Uncomment once self-support order validation is wired in.
-		{
-			description: "DATC 6.A.8 - support to hold yourself is not possible",
-			orders: []*result{
-				{order: "A Ven-Tri", position: "tri"},
-				{order: "A Tyr S A Ven-Tri", position: "tyr"},
-				{order: "A Tri S A Tri", position: "tri", defeated: true},
-			},
+	// DATC 6.A.8. SUPPORT TO HOLD YOURSELF IS NOT POSSIBLE
+	// Italy: A Venice - Trieste, A Tyrolia Supports A Venice - Trieste
+	// Austria: F Trieste Supports F Trieste (illegal self-support)
+	// Result: Trieste is dislodged (self-support adds 0 strength).
+	{
+		description: "DATC 6.A.8 - support to hold yourself is not possible",
+		orders: []*result{
+			{order: "A Ven-Tri", position: "tri"},
+			{order: "A Tyr S A Ven-Tri", position: "tyr"},
+			{order: "A Tri S A Tri", position: "tri", defeated: true},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.A.9. FLEETS MUST FOLLOW COAST IF NOT ON SEA
-		// Needs: fleet model; fleet adjacency differs from army adjacency
-		// Italy: F Rome - Venice → move fails (fleet cannot go Rome→Venice by coast)
-		{
-			description: "DATC 6.A.9 - fleets must follow coast if not on sea",
-			orders: []*result{
-				{order: "F Rom-Ven", position: "rom"},
-			},
+	// DATC 6.A.9. FLEETS MUST FOLLOW COAST IF NOT ON SEA
+	// Italy: F Rome - Venice → move fails (fleet cannot go Rome→Venice by coast)
+	{
+		description: "DATC 6.A.9 - fleets must follow coast if not on sea",
+		orders: []*result{
+			{order: "F Rom-Ven", position: "rom"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.A.10. SUPPORT ON UNREACHABLE DESTINATION NOT POSSIBLE
-		// Needs: fleet model; fleet adjacency validation on support orders
-		// Austria: A Venice Hold
-		// Italy: F Rome Supports A Apulia - Venice (Rome can't reach Venice by fleet), A Apulia - Venice
-		// Result: support of Rome is illegal; Venice is not dislodged.
-		{
-			description: "DATC 6.A.10 - support on unreachable destination not possible",
-			orders: []*result{
-				{order: "A Ven H", position: "ven"},
-				{order: "F Rom S A Apu-Ven", position: "rom"},
-				{order: "A Apu-Ven", position: "apu"},
-			},
+	// DATC 6.A.10. SUPPORT ON UNREACHABLE DESTINATION NOT POSSIBLE
+	// Austria: A Venice Hold
+	// Italy: F Rome Supports A Apulia - Venice (Rome can't reach Venice by fleet), A Apulia - Venice
+	// Result: support of Rome is illegal; Venice is not dislodged.
+	{
+		description: "DATC 6.A.10 - support on unreachable destination not possible",
+		orders: []*result{
+			{order: "A Ven H", position: "ven"},
+			{order: "F Rom S A Apu-Ven", position: "rom"},
+			{order: "A Apu-Ven", position: "apu"},
 		},
-	*/
+	},
 
 	// DATC 6.A.11. SIMPLE BOUNCE
 	// Two armies move to the same empty territory; both bounce.
@@ -349,99 +317,79 @@ var specs = []spec{
 	},
 
 	// ===== DATC 6.B. COASTAL ISSUES =====
-	// All 6.B tests require the fleet model with coast designators.
-	// Uncomment when implementing Phase 4. See CLAUDE.md.
 
-	/*
-		// DATC 6.B.1. MOVING WITH UNSPECIFIED COAST WHEN COAST IS NECESSARY
-		// Needs: fleet model + coast designators on Spain (nc/sc)
-		// France: F Portugal - Spain → move should fail (coast required)
-		{
-			description: "DATC 6.B.1 - moving with unspecified coast when coast is necessary",
-			orders: []*result{
-				{order: "F Por-Spa", position: "por"},
-			},
+	// DATC 6.B.1. MOVING WITH UNSPECIFIED COAST WHEN COAST IS NECESSARY
+	// France: F Portugal - Spain → move should fail (coast required)
+	{
+		description: "DATC 6.B.1 - moving with unspecified coast when coast is necessary",
+		orders: []*result{
+			{order: "F Por-Spa", position: "por"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.B.2. MOVING WITH UNSPECIFIED COAST WHEN COAST IS NOT NECESSARY
-		// Needs: fleet model + coast designators
-		// France: F Gascony - Spain → north coast is the only reachable coast; move succeeds
-		{
-			description: "DATC 6.B.2 - moving with unspecified coast when coast is not necessary",
-			orders: []*result{
-				{order: "F Gas-Spa", position: "spa"},
-			},
+	// DATC 6.B.2. MOVING WITH UNSPECIFIED COAST WHEN COAST IS NOT NECESSARY
+	// France: F Gascony - Spain → north coast is the only reachable coast; move succeeds
+	{
+		description: "DATC 6.B.2 - moving with unspecified coast when coast is not necessary",
+		orders: []*result{
+			{order: "F Gas-Spa", position: "spa"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.B.3. MOVING WITH WRONG COAST WHEN COAST IS NOT NECESSARY
-		// Needs: fleet model + coast designators
-		// France: F Gascony - Spain(sc) → wrong coast; order is illegal; fleet holds
-		{
-			description: "DATC 6.B.3 - moving with wrong coast when coast is not necessary",
-			orders: []*result{
-				{order: "F Gas-Spa(sc)", position: "gas"},
-			},
+	// DATC 6.B.3. MOVING WITH WRONG COAST WHEN COAST IS NOT NECESSARY
+	// France: F Gascony - Spain(sc) → wrong coast; order is illegal; fleet holds
+	{
+		description: "DATC 6.B.3 - moving with wrong coast when coast is not necessary",
+		orders: []*result{
+			{order: "F Gas-Spa(sc)", position: "gas"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.B.4. SUPPORT TO UNREACHABLE COAST ALLOWED
-		// Needs: fleet model + coast designators
-		// France: F Gascony - Spain(nc), F Marseilles Supports F Gascony - Spain(nc)
-		// Italy: F Western Mediterranean - Spain(sc)
-		// Result: Gascony moves to Spain(nc); Italian fleet fails.
-		{
-			description: "DATC 6.B.4 - support to unreachable coast allowed",
-			orders: []*result{
-				{order: "F Gas-Spa(nc)", position: "spa"},
-				{order: "F Mar S F Gas-Spa(nc)", position: "mar"},
-				{order: "F Wme-Spa(sc)", position: "wme"},
-			},
+	// DATC 6.B.4. SUPPORT TO UNREACHABLE COAST ALLOWED
+	// France: F Gascony - Spain(nc), F Marseilles Supports F Gascony - Spain(nc)
+	// Italy: F Western Mediterranean - Spain(sc)
+	// Result: Gascony moves to Spain(nc); Italian fleet fails.
+	{
+		description: "DATC 6.B.4 - support to unreachable coast allowed",
+		orders: []*result{
+			{order: "F Gas-Spa(nc)", position: "spa"},
+			{order: "F Mar S F Gas-Spa(nc)", position: "mar"},
+			{order: "F Wme-Spa(sc)", position: "wme"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.B.5. SUPPORT FROM UNREACHABLE COAST NOT ALLOWED
-		// Needs: fleet model + coast designators
-		// France: F Marseilles - Gulf of Lyon, F Spain(nc) Supports F Marseilles - Gulf of Lyon
-		// Italy: F Gulf of Lyon Hold
-		// Result: Spain(nc) cannot reach Gulf of Lyon; support illegal; Gulf of Lyon not dislodged.
-		{
-			description: "DATC 6.B.5 - support from unreachable coast not allowed",
-			orders: []*result{
-				{order: "F Mar-Gol", position: "mar"},
-				{order: "F Spa(nc) S F Mar-Gol", position: "spa"},
-				{order: "F Gol H", position: "gol"},
-			},
+	// DATC 6.B.5. SUPPORT FROM UNREACHABLE COAST NOT ALLOWED
+	// France: F Marseilles - Gulf of Lyon, F Spain(nc) Supports F Marseilles - Gulf of Lyon
+	// Italy: F Gulf of Lyon Hold
+	// Result: Spain(nc) cannot reach Gulf of Lyon; support illegal; Gulf of Lyon not dislodged.
+	{
+		description: "DATC 6.B.5 - support from unreachable coast not allowed",
+		orders: []*result{
+			{order: "F Mar-Gol", position: "mar"},
+			{order: "F Spa(nc) S F Mar-Gol", position: "spa"},
+			{order: "F Gol H", position: "gol"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.B.6. SUPPORT CAN BE CUT WITH OTHER COAST
-		// Needs: fleet model + coast designators
-		// England: F Irish Sea Supports F North Atlantic - Mid-Atlantic, F North Atlantic - Mid-Atlantic
-		// France: F Spain(nc) Supports F Mid-Atlantic, F Mid-Atlantic Hold
-		// Italy: F Gulf of Lyon - Spain(sc)
-		// Result: Italian fleet cuts Spanish support; French Mid-Atlantic dislodged.
-		{
-			description: "DATC 6.B.6 - support can be cut with other coast",
-			orders: []*result{
-				{order: "F Iri S F Nao-Mao", position: "iri"},
-				{order: "F Nao-Mao", position: "mao"},
-				{order: "F Spa(nc) S F Mao", position: "spa"},
-				{order: "F Mao H", position: "mao", defeated: true},
-				{order: "F Gol-Spa(sc)", position: "spa"},
-			},
+	// DATC 6.B.6. SUPPORT CAN BE CUT WITH OTHER COAST
+	// England: F Irish Sea Supports F North Atlantic - Mid-Atlantic, F North Atlantic - Mid-Atlantic
+	// France: F Spain(nc) Supports F Mid-Atlantic, F Mid-Atlantic Hold
+	// Italy: F Gulf of Lyon - Spain(sc)
+	// Result: Italian fleet cuts Spanish support and bounces; French Mid-Atlantic dislodged.
+	{
+		description: "DATC 6.B.6 - support can be cut with other coast",
+		orders: []*result{
+			{order: "F Iri S F Nao-Mao", position: "iri"},
+			{order: "F Nao-Mao", position: "mao"},
+			{order: "F Spa(nc) S F Mao", position: "spa"},
+			{order: "F Mao H", position: "mao", defeated: true},
+			{order: "F Gol-Spa(sc)", position: "gol"},
 		},
-	*/
+	},
 
 	/*
 		// DATC 6.B.7-6.B.15: Various coastal edge cases.
-		// All need fleet model + coast designators. Uncomment for Phase 4.
+		// Not yet written out. See DATC.txt for full scenario details.
 	*/
 
 	// ===== DATC 6.C. CIRCULAR MOVEMENT =====
@@ -484,84 +432,72 @@ var specs = []spec{
 		},
 	},
 
-	/*
-		// DATC 6.C.4. A CIRCULAR MOVEMENT WITH ATTACKED CONVOY
-		// Needs: convoy model
-		// Austria: A Trieste - Serbia, A Serbia - Bulgaria
-		// Turkey: A Bulgaria - Trieste (via convoy), F Aegean/Ionian/Adriatic Convoys
-		// Italy: F Naples - Ionian Sea (attacks convoy fleet but does not dislodge)
-		// Result: circular movement succeeds; all three armies advance.
-		{
-			description: "DATC 6.C.4 - circular movement with attacked convoy",
-			orders: []*result{
-				{order: "A Tri-Ser", position: "ser"},
-				{order: "A Ser-Bul", position: "bul"},
-				{order: "A Bul-Tri", position: "tri"},
-				{order: "F Aeg C A Bul-Tri", position: "aeg"},
-				{order: "F Ion C A Bul-Tri", position: "ion"},
-				{order: "F Adr C A Bul-Tri", position: "adr"},
-				{order: "F Nap-Ion", position: "nap"},
-			},
+	// DATC 6.C.4. A CIRCULAR MOVEMENT WITH ATTACKED CONVOY
+	// Austria: A Trieste - Serbia, A Serbia - Bulgaria
+	// Turkey: A Bulgaria - Trieste (via convoy), F Aegean/Ionian/Adriatic Convoys
+	// Italy: F Naples - Ionian Sea (attacks convoy fleet but does not dislodge)
+	// Result: circular movement succeeds; all three armies advance.
+	{
+		description: "DATC 6.C.4 - circular movement with attacked convoy",
+		orders: []*result{
+			{order: "A Tri-Ser", position: "ser"},
+			{order: "A Ser-Bul", position: "bul"},
+			{order: "A Bul-Tri", position: "tri"},
+			{order: "F Aeg C A Bul-Tri", position: "aeg"},
+			{order: "F Ion C A Bul-Tri", position: "ion"},
+			{order: "F Adr C A Bul-Tri", position: "adr"},
+			{order: "F Nap-Ion", position: "nap"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.C.5. A DISRUPTED CIRCULAR MOVEMENT DUE TO DISLODGED CONVOY
-		// Needs: convoy model
-		// Same as 6.C.4 but Italy adds F Tunis Supports F Naples - Ionian Sea.
-		// The Ionian convoy fleet is dislodged; circular movement fails; all armies stay.
-		{
-			description: "DATC 6.C.5 - disrupted circular movement due to dislodged convoy",
-			orders: []*result{
-				{order: "A Tri-Ser", position: "tri"},
-				{order: "A Ser-Bul", position: "ser"},
-				{order: "A Bul-Tri", position: "bul"},
-				{order: "F Aeg C A Bul-Tri", position: "aeg"},
-				{order: "F Ion C A Bul-Tri", position: "ion", defeated: true},
-				{order: "F Adr C A Bul-Tri", position: "adr"},
-				{order: "F Nap-Ion", position: "ion"},
-				{order: "F Tun S F Nap-Ion", position: "tun"},
-			},
+	// DATC 6.C.5. A DISRUPTED CIRCULAR MOVEMENT DUE TO DISLODGED CONVOY
+	// Same as 6.C.4 but Italy adds F Tunis Supports F Naples - Ionian Sea.
+	// The Ionian convoy fleet is dislodged; circular movement fails; all armies stay.
+	{
+		description: "DATC 6.C.5 - disrupted circular movement due to dislodged convoy",
+		orders: []*result{
+			{order: "A Tri-Ser", position: "tri"},
+			{order: "A Ser-Bul", position: "ser"},
+			{order: "A Bul-Tri", position: "bul"},
+			{order: "F Aeg C A Bul-Tri", position: "aeg"},
+			{order: "F Ion C A Bul-Tri", position: "ion", defeated: true},
+			{order: "F Adr C A Bul-Tri", position: "adr"},
+			{order: "F Nap-Ion", position: "ion"},
+			{order: "F Tun S F Nap-Ion", position: "tun"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.C.6. TWO ARMIES WITH TWO CONVOYS
-		// Needs: convoy model
-		// England: F North Sea Convoys A London - Belgium, A London - Belgium
-		// France: F English Channel Convoys A Belgium - London, A Belgium - London
-		// Both convoys succeed; armies swap.
-		{
-			description: "DATC 6.C.6 - two armies with two convoys",
-			orders: []*result{
-				{order: "F Nth C A Lon-Bel", position: "nth"},
-				{order: "A Lon-Bel", position: "bel"},
-				{order: "F Ech C A Bel-Lon", position: "ech"},
-				{order: "A Bel-Lon", position: "lon"},
-			},
+	// DATC 6.C.6. TWO ARMIES WITH TWO CONVOYS
+	// England: F North Sea Convoys A London - Belgium, A London - Belgium
+	// France: F English Channel Convoys A Belgium - London, A Belgium - London
+	// Both convoys succeed; armies swap.
+	{
+		description: "DATC 6.C.6 - two armies with two convoys",
+		orders: []*result{
+			{order: "F Nth C A Lon-Bel", position: "nth"},
+			{order: "A Lon-Bel", position: "bel"},
+			{order: "F Ech C A Bel-Lon", position: "ech"},
+			{order: "A Bel-Lon", position: "lon"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.C.7. DISRUPTED UNIT SWAP
-		// Needs: convoy model
-		// Same as 6.C.6 but France adds A Burgundy - Belgium; the swap is disrupted
-		// and neither army moves.
-		{
-			description: "DATC 6.C.7 - disrupted unit swap",
-			orders: []*result{
-				{order: "F Nth C A Lon-Bel", position: "nth"},
-				{order: "A Lon-Bel", position: "lon"},
-				{order: "F Ech C A Bel-Lon", position: "ech"},
-				{order: "A Bel-Lon", position: "bel"},
-				{order: "A Bur-Bel", position: "bur"},
-			},
+	// DATC 6.C.7. DISRUPTED UNIT SWAP
+	// Same as 6.C.6 but France adds A Burgundy - Belgium; the swap is disrupted
+	// and neither army moves.
+	{
+		description: "DATC 6.C.7 - disrupted unit swap",
+		orders: []*result{
+			{order: "F Nth C A Lon-Bel", position: "nth"},
+			{order: "A Lon-Bel", position: "lon"},
+			{order: "F Ech C A Bel-Lon", position: "ech"},
+			{order: "A Bel-Lon", position: "bel"},
+			{order: "A Bur-Bel", position: "bur"},
 		},
-	*/
+	},
 
 	/*
 		// DATC 6.C.8. NO SELF DISLODGEMENT IN DISRUPTED CIRCULAR MOVEMENT
-		// Needs: fleet model + country/self-dislodgement rules
+		// Needs: country/self-dislodgement rules
 		// Turkey: F Constantinople - Black Sea, A Bulgaria - Constantinople,
 		//         A Smyrna Supports A Bulgaria - Constantinople
 		// Russia: F Black Sea - Bulgaria(ec)
@@ -581,7 +517,7 @@ var specs = []spec{
 
 	/*
 		// DATC 6.C.9. NO HELP IN DISLODGEMENT OF OWN UNIT IN DISRUPTED CIRCULAR MOVEMENT
-		// Needs: fleet model + country/self-dislodgement rules
+		// Needs: country/self-dislodgement rules
 		// Turkey: F Constantinople - Black Sea, A Smyrna Supports A Bulgaria - Constantinople
 		// Russia: F Black Sea - Bulgaria(ec)
 		// Austria: A Serbia - Bulgaria, A Bulgaria - Constantinople
@@ -677,61 +613,53 @@ var specs = []spec{
 		},
 	},
 
-	/*
-		// DATC 6.D.6. SUPPORT TO HOLD ON CONVOYING UNIT ALLOWED
-		// Needs: convoy model
-		// Germany: A Berlin - Sweden (via convoy), F Baltic Sea Convoys A Berlin - Sweden,
-		//          F Prussia Supports F Baltic Sea
-		// Russia: F Livonia - Baltic Sea, F Gulf of Bothnia Supports F Livonia - Baltic Sea
-		// Result: Baltic Sea not dislodged; convoy succeeds.
-		{
-			description: "DATC 6.D.6 - support to hold on convoying unit allowed",
-			orders: []*result{
-				{order: "A Ber-Swe", position: "swe"},
-				{order: "F Bal C A Ber-Swe", position: "bal"},
-				{order: "F Pru S F Bal", position: "pru"},
-				{order: "F Lvn-Bal", position: "lvn"},
-				{order: "F Gob S F Lvn-Bal", position: "gob"},
-			},
+	// DATC 6.D.6. SUPPORT TO HOLD ON CONVOYING UNIT ALLOWED
+	// Germany: A Berlin - Sweden (via convoy), F Baltic Sea Convoys A Berlin - Sweden,
+	//          F Prussia Supports F Baltic Sea
+	// Russia: F Livonia - Baltic Sea, F Gulf of Bothnia Supports F Livonia - Baltic Sea
+	// Result: Baltic Sea not dislodged; convoy succeeds.
+	{
+		description: "DATC 6.D.6 - support to hold on convoying unit allowed",
+		orders: []*result{
+			{order: "A Ber-Swe", position: "swe"},
+			{order: "F Bal C A Ber-Swe", position: "bal"},
+			{order: "F Pru S F Bal", position: "pru"},
+			{order: "F Lvn-Bal", position: "lvn"},
+			{order: "F Gob S F Lvn-Bal", position: "gob"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.D.7. SUPPORT TO HOLD ON MOVING UNIT NOT ALLOWED
-		// Needs: fleet model (all units are fleets in original)
-		// Germany: F Baltic Sea - Sweden, F Prussia Supports F Baltic Sea
-		// Russia: F Livonia - Baltic Sea, F Gulf of Bothnia Supports F Livonia - Baltic Sea,
-		//         A Finland - Sweden
-		// Result: Prussia's support for the moving Baltic Sea is invalid; Baltic dislodged.
-		{
-			description: "DATC 6.D.7 - support to hold on moving unit not allowed",
-			orders: []*result{
-				{order: "F Bal-Swe", position: "bal", defeated: true},
-				{order: "F Pru S F Bal", position: "pru"},
-				{order: "F Lvn-Bal", position: "bal"},
-				{order: "F Gob S F Lvn-Bal", position: "gob"},
-				{order: "A Fin-Swe", position: "swe"},
-			},
+	// DATC 6.D.7. SUPPORT TO HOLD ON MOVING UNIT NOT ALLOWED
+	// Germany: F Baltic Sea - Sweden, F Prussia Supports F Baltic Sea
+	// Russia: F Livonia - Baltic Sea, F Gulf of Bothnia Supports F Livonia - Baltic Sea,
+	//         A Finland - Sweden
+	// Result: Prussia's support for the moving Baltic Sea is invalid; Baltic dislodged;
+	//         Finland still bounces with Baltic Sea.
+	{
+		description: "DATC 6.D.7 - support to hold on moving unit not allowed",
+		orders: []*result{
+			{order: "F Bal-Swe", position: "bal", defeated: true},
+			{order: "F Pru S F Bal", position: "pru"},
+			{order: "F Lvn-Bal", position: "bal"},
+			{order: "F Gob S F Lvn-Bal", position: "gob"},
+			{order: "A Fin-Swe", position: "fin"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.D.8. FAILED CONVOY CANNOT RECEIVE HOLD SUPPORT
-		// Needs: convoy model
-		// Austria: F Ionian Sea Hold, A Serbia Supports A Albania - Greece, A Albania - Greece
-		// Turkey: A Greece - Naples (convoy attempt), A Bulgaria Supports A Greece
-		// Result: convoy fails; Greece cannot receive hold support; Albania dislodges Greece.
-		{
-			description: "DATC 6.D.8 - failed convoy cannot receive hold support",
-			orders: []*result{
-				{order: "F Ion H", position: "ion"},
-				{order: "A Ser S A Alb-Gre", position: "ser"},
-				{order: "A Alb-Gre", position: "gre"},
-				{order: "A Gre-Nap", position: "gre", defeated: true},
-				{order: "A Bul S A Gre", position: "bul"},
-			},
+	// DATC 6.D.8. FAILED CONVOY CANNOT RECEIVE HOLD SUPPORT
+	// Austria: F Ionian Sea Hold, A Serbia Supports A Albania - Greece, A Albania - Greece
+	// Turkey: A Greece - Naples (convoy attempt), A Bulgaria Supports A Greece
+	// Result: convoy fails; Greece cannot receive hold support; Albania dislodges Greece.
+	{
+		description: "DATC 6.D.8 - failed convoy cannot receive hold support",
+		orders: []*result{
+			{order: "F Ion H", position: "ion"},
+			{order: "A Ser S A Alb-Gre", position: "ser"},
+			{order: "A Alb-Gre", position: "gre"},
+			{order: "A Gre-Nap", position: "gre", defeated: true},
+			{order: "A Bul S A Gre", position: "bul"},
 		},
-	*/
+	},
 
 	// DATC 6.D.9. SUPPORT TO MOVE ON HOLDING UNIT NOT ALLOWED
 	// Italy: A Venice - Trieste, A Tyrolia Supports A Venice - Trieste
@@ -798,7 +726,7 @@ var specs = []spec{
 
 	/*
 		// DATC 6.D.13. SUPPORTING A FOREIGN UNIT TO DISLODGE A RETURNING OWN UNIT PROHIBITED
-		// Needs: country/self-dislodgement rules + fleet model
+		// Needs: country/self-dislodgement rules
 		// Austria: F Trieste - Adriatic Sea, A Vienna Supports A Venice - Trieste
 		// Italy: A Venice - Trieste, F Apulia - Adriatic Sea
 		// Result: Trieste bounces with Apulia; not dislodged.
@@ -845,26 +773,23 @@ var specs = []spec{
 		},
 	},
 
-	/*
-		// DATC 6.D.16. CONVOYING A UNIT DISLODGING A UNIT OF SAME POWER IS ALLOWED
-		// Needs: convoy model
-		// England: A London Hold, F North Sea Convoys A Belgium - London
-		// France: F English Channel Supports A Belgium - London, A Belgium - London
-		// Result: English army dislodged by French army via convoy.
-		{
-			description: "DATC 6.D.16 - convoying a unit dislodging a unit of same power is allowed",
-			orders: []*result{
-				{order: "A Lon H", position: "lon", defeated: true},
-				{order: "F Nth C A Bel-Lon", position: "nth"},
-				{order: "F Ech S A Bel-Lon", position: "ech"},
-				{order: "A Bel-Lon", position: "lon"},
-			},
+	// DATC 6.D.16. CONVOYING A UNIT DISLODGING A UNIT OF SAME POWER IS ALLOWED
+	// England: A London Hold, F North Sea Convoys A Belgium - London
+	// France: F English Channel Supports A Belgium - London, A Belgium - London
+	// Result: English army dislodged by French army via convoy.
+	{
+		description: "DATC 6.D.16 - convoying a unit dislodging a unit of same power is allowed",
+		orders: []*result{
+			{order: "A Lon H", position: "lon", defeated: true},
+			{order: "F Nth C A Bel-Lon", position: "nth"},
+			{order: "F Ech S A Bel-Lon", position: "ech"},
+			{order: "A Bel-Lon", position: "lon"},
 		},
-	*/
+	},
 
 	/*
 		// DATC 6.D.17. DISLODGEMENT CUTS SUPPORTS
-		// Needs: fleet model + support recalculation after dislodgement
+		// Needs: support recalculation after dislodgement
 		// Russia: F Constantinople Supports F Black Sea - Ankara, F Black Sea - Ankara
 		// Turkey: F Ankara - Constantinople, A Smyrna Supports F Ankara - Constantinople,
 		//         A Armenia - Ankara
@@ -883,7 +808,7 @@ var specs = []spec{
 
 	/*
 		// DATC 6.D.18. A SURVIVING UNIT WILL SUSTAIN SUPPORT
-		// Needs: fleet model + support recalculation
+		// Needs: support recalculation
 		// Same as 6.D.17 but Russia adds A Bulgaria Supports F Constantinople.
 		// Result: Constantinople survives → support holds → Black Sea dislodges Ankara.
 		{
@@ -901,7 +826,7 @@ var specs = []spec{
 
 	/*
 		// DATC 6.D.19. EVEN WHEN SURVIVING IS IN ALTERNATIVE WAY
-		// Needs: fleet model + support recalculation + country rules
+		// Needs: support recalculation + country rules
 		// Russia: F Constantinople Supports F Black Sea - Ankara, F Black Sea - Ankara,
 		//         A Smyrna Supports F Ankara - Constantinople
 		// Turkey: F Ankara - Constantinople
@@ -920,7 +845,7 @@ var specs = []spec{
 
 	/*
 		// DATC 6.D.20. UNIT CANNOT CUT SUPPORT OF ITS OWN COUNTRY
-		// Needs: country rules (same-power support-cut exemption) + fleet model
+		// Needs: country rules (same-power support-cut exemption)
 		// England: F London Supports F North Sea - English Channel,
 		//          F North Sea - English Channel, A Yorkshire - London
 		// France: F English Channel Hold
@@ -955,59 +880,50 @@ var specs = []spec{
 		},
 	},
 
-	/*
-		// DATC 6.D.22. IMPOSSIBLE FLEET MOVE CANNOT BE SUPPORTED
-		// Needs: fleet model + illegal-move support invalidation
-		// Germany: F Kiel - Munich (illegal), A Burgundy Supports F Kiel - Munich
-		// Russia: A Munich - Kiel, A Berlin Supports A Munich - Kiel
-		// Result: Kiel's illegal move makes Burgundy's support invalid; Munich dislodges Kiel.
-		{
-			description: "DATC 6.D.22 - impossible fleet move cannot be supported",
-			orders: []*result{
-				{order: "F Kie-Mun", position: "kie", defeated: true},
-				{order: "A Bur S F Kie-Mun", position: "bur"},
-				{order: "A Mun-Kie", position: "kie"},
-				{order: "A Ber S A Mun-Kie", position: "ber"},
-			},
+	// DATC 6.D.22. IMPOSSIBLE FLEET MOVE CANNOT BE SUPPORTED
+	// Germany: F Kiel - Munich (illegal), A Burgundy Supports F Kiel - Munich
+	// Russia: A Munich - Kiel, A Berlin Supports A Munich - Kiel
+	// Result: Kiel's illegal move makes Burgundy's support invalid; Munich dislodges Kiel.
+	{
+		description: "DATC 6.D.22 - impossible fleet move cannot be supported",
+		orders: []*result{
+			{order: "F Kie-Mun", position: "kie", defeated: true},
+			{order: "A Bur S F Kie-Mun", position: "bur"},
+			{order: "A Mun-Kie", position: "kie"},
+			{order: "A Ber S A Mun-Kie", position: "ber"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.D.23. IMPOSSIBLE COAST MOVE CANNOT BE SUPPORTED
-		// Needs: fleet model + coast designators
-		// Italy: F Gulf of Lyon - Spain(sc), F Western Med Supports F Gulf - Spain(sc)
-		// France: F Spain(nc) - Gulf of Lyon, F Marseilles Supports F Spain(nc) - Gulf of Lyon
-		// Result: French move is illegal (wrong coast); Marseilles support fails; Spain dislodged.
-		{
-			description: "DATC 6.D.23 - impossible coast move cannot be supported",
-			orders: []*result{
-				{order: "F Gol-Spa(sc)", position: "spa"},
-				{order: "F Wme S F Gol-Spa(sc)", position: "wme"},
-				{order: "F Spa(nc)-Gol", position: "spa", defeated: true},
-				{order: "F Mar S F Spa(nc)-Gol", position: "mar"},
-			},
+	// DATC 6.D.23. IMPOSSIBLE COAST MOVE CANNOT BE SUPPORTED
+	// Italy: F Gulf of Lyon - Spain(sc), F Western Med Supports F Gulf - Spain(sc)
+	// France: F Spain(nc) - Gulf of Lyon, F Marseilles Supports F Spain(nc) - Gulf of Lyon
+	// Result: French move is illegal (wrong coast); Marseilles support fails; Spain dislodged.
+	{
+		description: "DATC 6.D.23 - impossible coast move cannot be supported",
+		orders: []*result{
+			{order: "F Gol-Spa(sc)", position: "spa"},
+			{order: "F Wme S F Gol-Spa(sc)", position: "wme"},
+			{order: "F Spa(nc)-Gol", position: "spa", defeated: true},
+			{order: "F Mar S F Spa(nc)-Gol", position: "mar"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.D.24. IMPOSSIBLE ARMY MOVE CANNOT BE SUPPORTED
-		// Needs: army-to-sea validation + fleet model
-		// France: A Marseilles - Gulf of Lyon (illegal), F Spain(sc) Supports A Marseilles - Gulf of Lyon
-		// Italy: F Gulf of Lyon Hold
-		// Turkey: F Tyrrhenian Sea Supports F Western Mediterranean - Gulf of Lyon,
-		//         F Western Mediterranean - Gulf of Lyon
-		// Result: French move is illegal; Spain's support fails; Gulf dislodged by Turkey.
-		{
-			description: "DATC 6.D.24 - impossible army move cannot be supported",
-			orders: []*result{
-				{order: "A Mar-Gol", position: "mar"},
-				{order: "F Spa(sc) S A Mar-Gol", position: "spa"},
-				{order: "F Gol H", position: "gol", defeated: true},
-				{order: "F Tys S F Wme-Gol", position: "tys"},
-				{order: "F Wme-Gol", position: "gol"},
-			},
+	// DATC 6.D.24. IMPOSSIBLE ARMY MOVE CANNOT BE SUPPORTED
+	// France: A Marseilles - Gulf of Lyon (illegal), F Spain(sc) Supports A Marseilles - Gulf of Lyon
+	// Italy: F Gulf of Lyon Hold
+	// Turkey: F Tyrrhenian Sea Supports F Western Mediterranean - Gulf of Lyon,
+	//         F Western Mediterranean - Gulf of Lyon
+	// Result: French move is illegal; Spain's support fails; Gulf dislodged by Turkey.
+	{
+		description: "DATC 6.D.24 - impossible army move cannot be supported",
+		orders: []*result{
+			{order: "A Mar-Gol", position: "mar"},
+			{order: "F Spa(sc) S A Mar-Gol", position: "spa"},
+			{order: "F Gol H", position: "gol", defeated: true},
+			{order: "F Tys S F Wme-Gol", position: "tys"},
+			{order: "F Wme-Gol", position: "gol"},
 		},
-	*/
+	},
 
 	// DATC 6.D.25. FAILING HOLD SUPPORT CAN BE SUPPORTED
 	// Germany: A Berlin Supports A Prussia (hold support that fails — Prussia is moving),
@@ -1042,84 +958,72 @@ var specs = []spec{
 		},
 	},
 
-	/*
-		// DATC 6.D.27. FAILING CONVOY CAN BE SUPPORTED
-		// Needs: convoy model
-		// England: F Sweden - Baltic Sea, F Denmark Supports F Sweden - Baltic Sea
-		// Germany: A Berlin Hold
-		// Russia: F Baltic Sea Convoys A Berlin - Livonia (unmatched convoy),
-		//         F Prussia Supports F Baltic Sea
-		// Result: Baltic's convoy is unmatched but support of Prussia is still valid;
-		//         Baltic not dislodged.
-		{
-			description: "DATC 6.D.27 - failing convoy can be supported",
-			orders: []*result{
-				{order: "F Swe-Bal", position: "swe"},
-				{order: "F Den S F Swe-Bal", position: "den"},
-				{order: "A Ber H", position: "ber"},
-				{order: "F Bal C A Ber-Lvn", position: "bal"},
-				{order: "F Pru S F Bal", position: "pru"},
-			},
+	// DATC 6.D.27. FAILING CONVOY CAN BE SUPPORTED
+	// England: F Sweden - Baltic Sea, F Denmark Supports F Sweden - Baltic Sea
+	// Germany: A Berlin Hold
+	// Russia: F Baltic Sea Convoys A Berlin - Livonia (unmatched convoy),
+	//         F Prussia Supports F Baltic Sea
+	// Result: Baltic's convoy is unmatched but support of Prussia is still valid;
+	//         Baltic not dislodged.
+	{
+		description: "DATC 6.D.27 - failing convoy can be supported",
+		orders: []*result{
+			{order: "F Swe-Bal", position: "swe"},
+			{order: "F Den S F Swe-Bal", position: "den"},
+			{order: "A Ber H", position: "ber"},
+			{order: "F Bal C A Ber-Lvn", position: "bal"},
+			{order: "F Pru S F Bal", position: "pru"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.D.28. IMPOSSIBLE MOVE AND SUPPORT
-		// Needs: fleet model + impossible-move validation
-		// Austria: A Budapest Supports F Rumania (hold support for Rumania)
-		// Russia: F Rumania - Holland (illegal: too far)
-		// Turkey: F Black Sea - Rumania, A Bulgaria Supports F Black Sea - Rumania
-		// Result: Rumania's illegal order ignored; Rumania holds with support; not dislodged.
-		{
-			description: "DATC 6.D.28 - impossible move and support",
-			orders: []*result{
-				{order: "A Bud S F Rum", position: "bud"},
-				{order: "F Rum-Hol", position: "rum"},
-				{order: "F Bla-Rum", position: "bla"},
-				{order: "A Bul S F Bla-Rum", position: "bul"},
-			},
+	// DATC 6.D.28. IMPOSSIBLE MOVE AND SUPPORT
+	// Austria: A Budapest Supports F Rumania (hold support for Rumania)
+	// Russia: F Rumania - Holland (illegal: too far)
+	// Turkey: F Black Sea - Rumania, A Bulgaria Supports F Black Sea - Rumania
+	// Result: Rumania's illegal order ignored; Rumania holds with support; not dislodged.
+	{
+		description: "DATC 6.D.28 - impossible move and support",
+		orders: []*result{
+			{order: "A Bud S F Rum", position: "bud"},
+			{order: "F Rum-Hol", position: "rum"},
+			{order: "F Bla-Rum", position: "bla"},
+			{order: "A Bul S F Bla-Rum", position: "bul"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.D.29. MOVE TO IMPOSSIBLE COAST AND SUPPORT
-		// Needs: fleet model + coast designators
-		// Austria: A Budapest Supports F Rumania
-		// Russia: F Rumania - Bulgaria(sc) (impossible coast from Rumania)
-		// Turkey: F Black Sea - Rumania, A Bulgaria Supports F Black Sea - Rumania
-		// Result: same as 6.D.28; Rumania not dislodged.
-		{
-			description: "DATC 6.D.29 - move to impossible coast and support",
-			orders: []*result{
-				{order: "A Bud S F Rum", position: "bud"},
-				{order: "F Rum-Bul(sc)", position: "rum"},
-				{order: "F Bla-Rum", position: "bla"},
-				{order: "A Bul S F Bla-Rum", position: "bul"},
-			},
+	// DATC 6.D.29. MOVE TO IMPOSSIBLE COAST AND SUPPORT
+	// Austria: A Budapest Supports F Rumania
+	// Russia: F Rumania - Bulgaria(sc) (impossible coast from Rumania)
+	// Turkey: F Black Sea - Rumania, A Bulgaria Supports F Black Sea - Rumania
+	// Result: same as 6.D.28; Rumania not dislodged.
+	{
+		description: "DATC 6.D.29 - move to impossible coast and support",
+		orders: []*result{
+			{order: "A Bud S F Rum", position: "bud"},
+			{order: "F Rum-Bul(sc)", position: "rum"},
+			{order: "F Bla-Rum", position: "bla"},
+			{order: "A Bul S F Bla-Rum", position: "bul"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.D.30. MOVE WITHOUT COAST AND SUPPORT
-		// Needs: fleet model + coast designators
-		// Italy: F Aegean Sea Supports F Constantinople
-		// Russia: F Constantinople - Bulgaria (coast unspecified; illegal)
-		// Turkey: F Black Sea - Constantinople, A Bulgaria Supports F Black Sea - Constantinople
-		// Result: Constantinople not dislodged.
-		{
-			description: "DATC 6.D.30 - move without coast and support",
-			orders: []*result{
-				{order: "F Aeg S F Con", position: "aeg"},
-				{order: "F Con-Bul", position: "con"},
-				{order: "F Bla-Con", position: "bla"},
-				{order: "A Bul S F Bla-Con", position: "bul"},
-			},
+	// DATC 6.D.30. MOVE WITHOUT COAST AND SUPPORT
+	// Italy: F Aegean Sea Supports F Constantinople
+	// Russia: F Constantinople - Bulgaria (coast unspecified; illegal)
+	// Turkey: F Black Sea - Constantinople, A Bulgaria Supports F Black Sea - Constantinople
+	// Result: Constantinople not dislodged.
+	{
+		description: "DATC 6.D.30 - move without coast and support",
+		orders: []*result{
+			{order: "F Aeg S F Con", position: "aeg"},
+			{order: "F Con-Bul", position: "con"},
+			{order: "F Bla-Con", position: "bla"},
+			{order: "A Bul S F Bla-Con", position: "bul"},
 		},
-	*/
+	},
 
 	/*
 		// DATC 6.D.31. A TRICKY IMPOSSIBLE SUPPORT
-		// Needs: fleet model + convoy-route awareness for support validation
+		// Needs: convoy-route awareness for support validation
 		// Austria: A Rumania - Armenia
 		// Turkey: F Black Sea Supports A Rumania - Armenia (impossible: only route is via Black Sea,
 		//         which can't convoy and support simultaneously)
@@ -1135,7 +1039,7 @@ var specs = []spec{
 
 	/*
 		// DATC 6.D.32. A MISSING FLEET
-		// Needs: fleet model + convoy-route validation for move support
+		// Needs: convoy-route validation for move support
 		// England: F Edinburgh Supports A Liverpool - Yorkshire, A Liverpool - Yorkshire
 		// France: F London Supports A Yorkshire
 		// Germany: A Yorkshire - Holland (requires North Sea fleet that isn't there)
@@ -1167,27 +1071,24 @@ var specs = []spec{
 		},
 	},
 
-	/*
-		// DATC 6.D.34. SUPPORT TARGETING OWN AREA NOT ALLOWED
-		// Needs: fleet model + self-targeting support validation
-		// Germany: A Berlin - Prussia, A Silesia Supports A Berlin - Prussia,
-		//          F Baltic Sea Supports A Berlin - Prussia
-		// Italy: A Prussia Supports A Livonia - Prussia (illegal: Prussia can't support
-		//        into its own area)
-		// Russia: A Warsaw Supports A Livonia - Prussia, A Livonia - Prussia
-		// Result: Italian order illegal; German attack succeeds.
-		{
-			description: "DATC 6.D.34 - support targeting own area not allowed",
-			orders: []*result{
-				{order: "A Ber-Pru", position: "pru"},
-				{order: "A Sil S A Ber-Pru", position: "sil"},
-				{order: "F Bal S A Ber-Pru", position: "bal"},
-				{order: "A Pru S A Lvn-Pru", position: "pru", defeated: true},
-				{order: "A War S A Lvn-Pru", position: "war"},
-				{order: "A Lvn-Pru", position: "lvn"},
-			},
+	// DATC 6.D.34. SUPPORT TARGETING OWN AREA NOT ALLOWED
+	// Germany: A Berlin - Prussia, A Silesia Supports A Berlin - Prussia,
+	//          F Baltic Sea Supports A Berlin - Prussia
+	// Italy: A Prussia Supports A Livonia - Prussia (illegal: Prussia can't support
+	//        into its own area)
+	// Russia: A Warsaw Supports A Livonia - Prussia, A Livonia - Prussia
+	// Result: Italian order illegal; German attack succeeds.
+	{
+		description: "DATC 6.D.34 - support targeting own area not allowed",
+		orders: []*result{
+			{order: "A Ber-Pru", position: "pru"},
+			{order: "A Sil S A Ber-Pru", position: "sil"},
+			{order: "F Bal S A Ber-Pru", position: "bal"},
+			{order: "A Pru S A Lvn-Pru", position: "pru", defeated: true},
+			{order: "A War S A Lvn-Pru", position: "war"},
+			{order: "A Lvn-Pru", position: "lvn"},
 		},
-	*/
+	},
 
 	// ===== DATC 6.E. HEAD-TO-HEAD BATTLES AND BELEAGUERED GARRISON =====
 	//
@@ -1244,93 +1145,401 @@ var specs = []spec{
 		},
 	*/
 
-	/*
-		// DATC 6.E.4. NON-DISLODGED LOSER STILL HAS EFFECT
-		// Needs: head-to-head algorithm + fleet model
-		// Complex beleaguered garrison scenario with fleets. See DATC.txt 6.E.4.
-		{
-			description: "DATC 6.E.4 - non-dislodged loser still has effect",
-			orders: []*result{
-				{order: "F Hol-Nth", position: "hol"},
-				{order: "F Hel S F Hol-Nth", position: "hel"},
-				{order: "F Ska S F Hol-Nth", position: "ska"},
-				{order: "F Nth-Hol", position: "nth"},
-				{order: "F Bel S F Nth-Hol", position: "bel"},
-				{order: "F Edi S F Nwg-Nth", position: "edi"},
-				{order: "F Yor S F Nwg-Nth", position: "yor"},
-				{order: "F Nwg-Nth", position: "nwg"},
-				{order: "A Kie S A Ruh-Hol", position: "kie"},
-				{order: "A Ruh-Hol", position: "ruh"},
-			},
+	// DATC 6.E.4. NON-DISLODGED LOSER STILL HAS EFFECT
+	// Complex beleaguered garrison scenario with fleets. See DATC.txt 6.E.4.
+	{
+		description: "DATC 6.E.4 - non-dislodged loser still has effect",
+		orders: []*result{
+			{order: "F Hol-Nth", position: "hol"},
+			{order: "F Hel S F Hol-Nth", position: "hel"},
+			{order: "F Ska S F Hol-Nth", position: "ska"},
+			{order: "F Nth-Hol", position: "nth"},
+			{order: "F Bel S F Nth-Hol", position: "bel"},
+			{order: "F Edi S F Nwg-Nth", position: "edi"},
+			{order: "F Yor S F Nwg-Nth", position: "yor"},
+			{order: "F Nwg-Nth", position: "nwg"},
+			{order: "A Kie S A Ruh-Hol", position: "kie"},
+			{order: "A Ruh-Hol", position: "ruh"},
 		},
-	*/
+	},
 
 	/*
 		// DATC 6.E.5–6.E.15: Further head-to-head and beleaguered garrison scenarios.
-		// All need head-to-head algorithm (Phase 3) and most need fleet model (Phase 4).
+		// All need head-to-head algorithm (Phase 3).
 		// See DATC.txt sections 6.E.5 through 6.E.15 for full scenario details.
 	*/
 
 	// ===== DATC 6.F. CONVOYS =====
-	// All 6.F tests require the convoy model (Phase 5). See CLAUDE.md.
 
-	/*
-		// DATC 6.F.1. NO CONVOY IN COASTAL AREAS
-		// Turkey: A Greece - Sevastopol (convoy), F Aegean Convoys, F Constantinople Convoys,
-		//         F Black Sea Convoys
-		// Result: Constantinople is coastal; convoy fails; army stays in Greece.
-		{
-			description: "DATC 6.F.1 - no convoy in coastal areas",
-			orders: []*result{
-				{order: "A Gre-Sev", position: "gre"},
-				{order: "F Aeg C A Gre-Sev", position: "aeg"},
-				{order: "F Con C A Gre-Sev", position: "con"},
-				{order: "F Bla C A Gre-Sev", position: "bla"},
-			},
+	// DATC 6.F.1. NO CONVOY IN COASTAL AREAS
+	// Turkey: A Greece - Sevastopol (convoy), F Aegean Convoys, F Constantinople Convoys,
+	//         F Black Sea Convoys
+	// Result: Constantinople is coastal; convoy fails; army stays in Greece.
+	{
+		description: "DATC 6.F.1 - no convoy in coastal areas",
+		orders: []*result{
+			{order: "A Gre-Sev", position: "gre"},
+			{order: "F Aeg C A Gre-Sev", position: "aeg"},
+			{order: "F Con C A Gre-Sev", position: "con"},
+			{order: "F Bla C A Gre-Sev", position: "bla"},
 		},
-	*/
+	},
 
-	/*
-		// DATC 6.F.2. AN ARMY BEING CONVOYED CAN BOUNCE AS NORMAL
-		// England: F English Channel Convoys A London - Brest, A London - Brest
-		// France: A Paris - Brest
-		// Result: London and Paris bounce at Brest.
-		{
-			description: "DATC 6.F.2 - an army being convoyed can bounce as normal",
-			orders: []*result{
-				{order: "F Ech C A Lon-Bre", position: "ech"},
-				{order: "A Lon-Bre", position: "lon"},
-				{order: "A Par-Bre", position: "par"},
-			},
+	// DATC 6.F.2. AN ARMY BEING CONVOYED CAN BOUNCE AS NORMAL
+	// England: F English Channel Convoys A London - Brest, A London - Brest
+	// France: A Paris - Brest
+	// Result: London and Paris bounce at Brest.
+	{
+		description: "DATC 6.F.2 - an army being convoyed can bounce as normal",
+		orders: []*result{
+			{order: "F Ech C A Lon-Bre", position: "ech"},
+			{order: "A Lon-Bre", position: "lon"},
+			{order: "A Par-Bre", position: "par"},
 		},
-	*/
+	},
+
+	// DATC 6.F.3. AN ARMY BEING CONVOYED CAN RECEIVE SUPPORT
+	// England: F English Channel Convoys A London - Brest, A London - Brest,
+	//          F Mid-Atlantic Ocean Supports A London - Brest
+	// France: A Paris - Brest
+	// Result: supported London wins Brest.
+	{
+		description: "DATC 6.F.3 - an army being convoyed can receive support",
+		orders: []*result{
+			{order: "F Ech C A Lon-Bre", position: "ech"},
+			{order: "A Lon-Bre", position: "bre"},
+			{order: "F Mao S A Lon-Bre", position: "mao"},
+			{order: "A Par-Bre", position: "par"},
+		},
+	},
+
+	// DATC 6.F.4. AN ATTACKED CONVOY IS NOT DISRUPTED
+	// England: F North Sea Convoys A London - Holland, A London - Holland
+	// Germany: F Skagerrak - North Sea
+	// Result: the convoy is attacked but not dislodged; London reaches Holland.
+	{
+		description: "DATC 6.F.4 - an attacked convoy is not disrupted",
+		orders: []*result{
+			{order: "F Nth C A Lon-Hol", position: "nth"},
+			{order: "A Lon-Hol", position: "hol"},
+			{order: "F Ska-Nth", position: "ska"},
+		},
+	},
+
+	// DATC 6.F.5. A BELEAGUERED CONVOY IS NOT DISRUPTED
+	// England: F North Sea Convoys A London - Holland, A London - Holland
+	// France: F English Channel - North Sea, F Belgium Supports F English Channel - North Sea
+	// Germany: F Skagerrak - North Sea, F Denmark Supports F Skagerrak - North Sea
+	// Result: the attacks on North Sea bounce; London reaches Holland.
+	{
+		description: "DATC 6.F.5 - a beleaguered convoy is not disrupted",
+		orders: []*result{
+			{order: "F Nth C A Lon-Hol", position: "nth"},
+			{order: "A Lon-Hol", position: "hol"},
+			{order: "F Ech-Nth", position: "ech"},
+			{order: "F Bel S F Ech-Nth", position: "bel"},
+			{order: "F Ska-Nth", position: "ska"},
+			{order: "F Den S F Ska-Nth", position: "den"},
+		},
+	},
+
+	// DATC 6.F.6. DISLODGED CONVOY DOES NOT CUT SUPPORT
+	// England: F North Sea Convoys A London - Holland, A London - Holland
+	// Germany: A Holland Supports A Belgium, A Belgium Supports A Holland,
+	//          F Helgoland Bight Supports F Skagerrak - North Sea, F Skagerrak - North Sea
+	// France: A Picardy - Belgium, A Burgundy Supports A Picardy - Belgium
+	// Result: North Sea is dislodged, so London does not cut the support of
+	//         Holland and Belgium holds.
+	{
+		description: "DATC 6.F.6 - dislodged convoy does not cut support",
+		orders: []*result{
+			{order: "F Nth C A Lon-Hol", position: "nth", defeated: true},
+			{order: "A Lon-Hol", position: "lon"},
+			{order: "A Hol S A Bel", position: "hol"},
+			{order: "A Bel S A Hol", position: "bel"},
+			{order: "F Hel S F Ska-Nth", position: "hel"},
+			{order: "F Ska-Nth", position: "nth"},
+			{order: "A Pic-Bel", position: "pic"},
+			{order: "A Bur S A Pic-Bel", position: "bur"},
+		},
+	},
+
+	// DATC 6.F.7. DISLODGED CONVOY DOES NOT CAUSE CONTESTED AREA
+	// England: F North Sea Convoys A London - Holland, A London - Holland
+	// Germany: F Helgoland Bight Supports F Skagerrak - North Sea, F Skagerrak - North Sea
+	// Result: North Sea is dislodged and London stays.
+	{
+		description: "DATC 6.F.7 - dislodged convoy does not cause contested area",
+		orders: []*result{
+			{order: "F Nth C A Lon-Hol", position: "nth", defeated: true},
+			{order: "A Lon-Hol", position: "lon"},
+			{order: "F Hel S F Ska-Nth", position: "hel"},
+			{order: "F Ska-Nth", position: "nth"},
+		},
+	},
+
+	// DATC 6.F.8. DISLODGED CONVOY DOES NOT CAUSE A BOUNCE
+	// Same as 6.F.7 but Germany adds A Belgium - Holland.
+	// Result: the disrupted convoy does not bounce Belgium out of Holland.
+	{
+		description: "DATC 6.F.8 - dislodged convoy does not cause a bounce",
+		orders: []*result{
+			{order: "F Nth C A Lon-Hol", position: "nth", defeated: true},
+			{order: "A Lon-Hol", position: "lon"},
+			{order: "F Hel S F Ska-Nth", position: "hel"},
+			{order: "F Ska-Nth", position: "nth"},
+			{order: "A Bel-Hol", position: "hol"},
+		},
+	},
+
+	// DATC 6.F.9. DISLODGE OF MULTI-ROUTE CONVOY
+	// England: F English Channel Convoys A London - Belgium,
+	//          F North Sea Convoys A London - Belgium, A London - Belgium
+	// France: F Brest Supports F Mid-Atlantic Ocean - English Channel,
+	//         F Mid-Atlantic Ocean - English Channel
+	// Result: English Channel is dislodged, but the route through North Sea
+	//         still carries London to Belgium.
+	{
+		description: "DATC 6.F.9 - dislodge of multi-route convoy",
+		orders: []*result{
+			{order: "F Ech C A Lon-Bel", position: "ech", defeated: true},
+			{order: "F Nth C A Lon-Bel", position: "nth"},
+			{order: "A Lon-Bel", position: "bel"},
+			{order: "F Bre S F Mao-Ech", position: "bre"},
+			{order: "F Mao-Ech", position: "ech"},
+		},
+	},
+
+	// DATC 6.F.13. THE UNWANTED ALTERNATIVE
+	// England: A London - Belgium, F North Sea Convoys A London - Belgium
+	// France: F English Channel Convoys A London - Belgium
+	// Germany: F Holland Supports F Denmark - North Sea, F Denmark - North Sea
+	// Result: North Sea is dislodged; the convoy goes through the Channel.
+	{
+		description: "DATC 6.F.13 - the unwanted alternative",
+		orders: []*result{
+			{order: "A Lon-Bel", position: "bel"},
+			{order: "F Nth C A Lon-Bel", position: "nth", defeated: true},
+			{order: "F Ech C A Lon-Bel", position: "ech"},
+			{order: "F Hol S F Den-Nth", position: "hol"},
+			{order: "F Den-Nth", position: "nth"},
+		},
+	},
+
+	// DATC 6.F.14. SIMPLE CONVOY PARADOX
+	// England: F London Supports F Wales - English Channel, F Wales - English Channel
+	// France: A Brest - London, F English Channel Convoys A Brest - London
+	// Result: the convoy is in a paradox, so under the Szykman rule Brest
+	//         stays and cuts nothing; English Channel is dislodged.
+	{
+		description: "DATC 6.F.14 - simple convoy paradox",
+		orders: []*result{
+			{order: "F Lon S F Wal-Ech", position: "lon"},
+			{order: "F Wal-Ech", position: "ech"},
+			{order: "A Bre-Lon", position: "bre"},
+			{order: "F Ech C A Bre-Lon", position: "ech", defeated: true},
+		},
+	},
+
+	// DATC 6.F.16. PANDIN'S PARADOX
+	// England: F London Supports F Wales - English Channel, F Wales - English Channel
+	// France: A Brest - London, F English Channel Convoys A Brest - London
+	// Germany: F North Sea Supports F Belgium - English Channel,
+	//          F Belgium - English Channel
+	// Result: the attacks on English Channel bounce; no unit moves.
+	{
+		description: "DATC 6.F.16 - pandin's paradox",
+		orders: []*result{
+			{order: "F Lon S F Wal-Ech", position: "lon"},
+			{order: "F Wal-Ech", position: "wal"},
+			{order: "A Bre-Lon", position: "bre"},
+			{order: "F Ech C A Bre-Lon", position: "ech"},
+			{order: "F Nth S F Bel-Ech", position: "nth"},
+			{order: "F Bel-Ech", position: "bel"},
+		},
+	},
+
+	// DATC 6.F.17. PANDIN'S EXTENDED PARADOX
+	// As 6.F.16, and France adds F Yorkshire Supports A Brest - London.
+	// Result: the convoy is in a paradox, so under the Szykman rule Brest
+	//         stays; the attacks on English Channel bounce and no unit moves.
+	{
+		description: "DATC 6.F.17 - pandin's extended paradox",
+		orders: []*result{
+			{order: "F Lon S F Wal-Ech", position: "lon", country: "england"},
+			{order: "F Wal-Ech", position: "wal", country: "england"},
+			{order: "A Bre-Lon", position: "bre", country: "france"},
+			{order: "F Ech C A Bre-Lon", position: "ech", country: "france"},
+			{order: "F Yor S A Bre-Lon", position: "yor", country: "france"},
+			{order: "F Nth S F Bel-Ech", position: "nth", country: "germany"},
+			{order: "F Bel-Ech", position: "bel", country: "germany"},
+		},
+	},
+
+	// DATC 6.F.19. MULTI-ROUTE CONVOY DISRUPTION PARADOX
+	// France: A Tunis - Naples, F Tyrrhenian Sea Convoys A Tunis - Naples,
+	//         F Ionian Sea Convoys A Tunis - Naples
+	// Italy: F Naples Supports F Rome - Tyrrhenian Sea, F Rome - Tyrrhenian Sea
+	// Result: the route through the Ionian Sea stands whatever happens, so
+	//         Tunis cuts the support from Naples and no unit moves.
+	{
+		description: "DATC 6.F.19 - multi-route convoy disruption paradox",
+		orders: []*result{
+			{order: "A Tun-Nap", position: "tun", country: "france"},
+			{order: "F Tys C A Tun-Nap", position: "tys", country: "france"},
+			{order: "F Ion C A Tun-Nap", position: "ion", country: "france"},
+			{order: "F Nap S F Rom-Tys", position: "nap", country: "italy"},
+			{order: "F Rom-Tys", position: "rom", country: "italy"},
+		},
+	},
+
+	// DATC 6.F.21. DAD'S ARMY CONVOY
+	// Russia: A Edinburgh Supports A Norway - Clyde,
+	//         F Norwegian Sea Convoys A Norway - Clyde, A Norway - Clyde
+	// France: F Irish Sea Supports F Mid-Atlantic Ocean - North Atlantic Ocean,
+	//         F Mid-Atlantic Ocean - North Atlantic Ocean
+	// England: A Liverpool - Clyde via convoy,
+	//          F North Atlantic Ocean Convoys A Liverpool - Clyde,
+	//          F Clyde Supports F North Atlantic Ocean
+	// Result: North Atlantic Ocean is dislodged, so Liverpool stays and
+	//         cannot cut the support for Norway, which dislodges Clyde.
+	{
+		description: "DATC 6.F.21 - dad's army convoy",
+		orders: []*result{
+			{order: "A Edi S A Nwy-Cly", position: "edi", country: "russia"},
+			{order: "F Nwg C A Nwy-Cly", position: "nwg", country: "russia"},
+			{order: "A Nwy-Cly", position: "cly", country: "russia"},
+			{order: "F Iri S F Mao-Nao", position: "iri", country: "france"},
+			{order: "F Mao-Nao", position: "nao", country: "france"},
+			{order: "A Lvp-Cly via convoy", position: "lvp", country: "england"},
+			{order: "F Nao C A Lvp-Cly", position: "nao", defeated: true, country: "england"},
+			{order: "F Cly S F Nao", position: "cly", defeated: true, country: "england"},
+		},
+	},
+
+	// DATC 6.F.22. SECOND ORDER PARADOX WITH TWO RESOLUTIONS
+	// England: F Edinburgh - North Sea, F London Supports F Edinburgh - North Sea
+	// France: A Brest - London, F English Channel Convoys A Brest - London
+	// Germany: F Belgium Supports F Picardy - English Channel,
+	//          F Picardy - English Channel
+	// Russia: A Norway - Belgium, F North Sea Convoys A Norway - Belgium
+	// Result: both convoys are in the paradox, so under the Szykman rule
+	//         neither army moves and both convoying fleets are dislodged.
+	{
+		description: "DATC 6.F.22 - second order paradox with two resolutions",
+		orders: []*result{
+			{order: "F Edi-Nth", position: "nth", country: "england"},
+			{order: "F Lon S F Edi-Nth", position: "lon", country: "england"},
+			{order: "A Bre-Lon", position: "bre", country: "france"},
+			{order: "F Ech C A Bre-Lon", position: "ech", defeated: true, country: "france"},
+			{order: "F Bel S F Pic-Ech", position: "bel", country: "germany"},
+			{order: "F Pic-Ech", position: "ech", country: "germany"},
+			{order: "A Nwy-Bel", position: "nwy", country: "russia"},
+			{order: "F Nth C A Nwy-Bel", position: "nth", defeated: true, country: "russia"},
+		},
+	},
+
+	// DATC 6.F.22 extended: as 6.F.22, and Russia adds A St Petersburg -
+	// Edinburgh convoyed by F Norwegian Sea and F Barents Sea.
+	// Result: that convoy is not part of the paradox and goes ahead into the
+	//         province Edinburgh leaves.
+	{
+		description: "DATC 6.F.22 extended - convoy outside the paradox",
+		orders: []*result{
+			{order: "F Edi-Nth", position: "nth", country: "england"},
+			{order: "F Lon S F Edi-Nth", position: "lon", country: "england"},
+			{order: "A Bre-Lon", position: "bre", country: "france"},
+			{order: "F Ech C A Bre-Lon", position: "ech", defeated: true, country: "france"},
+			{order: "F Bel S F Pic-Ech", position: "bel", country: "germany"},
+			{order: "F Pic-Ech", position: "ech", country: "germany"},
+			{order: "A Nwy-Bel", position: "nwy", country: "russia"},
+			{order: "F Nth C A Nwy-Bel", position: "nth", defeated: true, country: "russia"},
+			{order: "F Nwg C A Stp-Edi", position: "nwg", country: "russia"},
+			{order: "F Bar C A Stp-Edi", position: "bar", country: "russia"},
+			{order: "A Stp-Edi", position: "edi", country: "russia"},
+		},
+	},
 
 	/*
-		// DATC 6.F.3–6.F.25: Convoy mechanics, paradox resolutions, and multi-route convoys.
-		// All need convoy model (Phase 5). See DATC.txt for full scenario details.
+		// DATC 6.F.23–6.F.24: Further second order paradoxes.
+		// Not yet written out. See DATC.txt for full scenario details.
 	*/
 
 	// ===== DATC 6.G. CONVOYING TO ADJACENT PROVINCES =====
-	// All 6.G tests require the convoy model (Phase 5). See CLAUDE.md.
 
-	/*
-		// DATC 6.G.1. TWO UNITS CAN SWAP PROVINCES BY CONVOY
-		// England: A Norway - Sweden (via convoy), F Skagerrak Convoys A Norway - Sweden
-		// Russia: A Sweden - Norway
-		// Result: convoy intent given by own fleet; armies swap.
-		{
-			description: "DATC 6.G.1 - two units can swap provinces by convoy",
-			orders: []*result{
-				{order: "A Nwy-Swe", position: "swe"},
-				{order: "F Ska C A Nwy-Swe", position: "ska"},
-				{order: "A Swe-Nwy", position: "nwy"},
-			},
+	// DATC 6.G.1. TWO UNITS CAN SWAP PROVINCES BY CONVOY
+	// England: A Norway - Sweden (via convoy), F Skagerrak Convoys A Norway - Sweden
+	// Russia: A Sweden - Norway
+	// Result: convoy intent given by own fleet; armies swap.
+	{
+		description: "DATC 6.G.1 - two units can swap provinces by convoy",
+		orders: []*result{
+			{order: "A Nwy-Swe", position: "swe"},
+			{order: "F Ska C A Nwy-Swe", position: "ska"},
+			{order: "A Swe-Nwy", position: "nwy"},
 		},
+	},
+
+	/*
+		// DATC 6.G.2–6.G.4: Adjacent-province convoy edge cases.
+		// Not yet written out. See DATC.txt for full scenario details.
 	*/
 
+	// DATC 6.G.5. SWAPPING WITH INTENT
+	// Italy: A Rome - Apulia, F Tyrrhenian Sea Convoys A Apulia - Rome
+	// Turkey: A Apulia - Rome, F Ionian Sea Convoys A Apulia - Rome
+	// Result: Turkey ordered a fleet on the chain to convoy, so Apulia goes
+	//         by convoy, through an Italian fleet, and the armies swap.
+	{
+		description: "DATC 6.G.5 - swapping with intent",
+		orders: []*result{
+			{order: "A Rom-Apu", position: "apu", country: "italy"},
+			{order: "F Tys C A Apu-Rom", position: "tys", country: "italy"},
+			{order: "A Apu-Rom", position: "rom", country: "turkey"},
+			{order: "F Ion C A Apu-Rom", position: "ion", country: "turkey"},
+		},
+	},
+
+	// DATC 6.G.6. SWAPPING WITH UNINTENDED INTENT
+	// England: A Liverpool - Edinburgh, F English Channel Convoys A Liverpool - Edinburgh
+	// Germany: A Edinburgh - Liverpool
+	// France: F Irish Sea Hold, F North Sea Hold
+	// Russia: F Norwegian Sea Convoys A Liverpool - Edinburgh,
+	//         F North Atlantic Ocean Convoys A Liverpool - Edinburgh
+	// Result: the English Channel lies on a chain of fleets between the two
+	//         coasts, so Liverpool goes by the Russian convoy and the armies
+	//         swap.
+	{
+		description: "DATC 6.G.6 - swapping with unintended intent",
+		orders: []*result{
+			{order: "A Lvp-Edi", position: "edi", country: "england"},
+			{order: "F Ech C A Lvp-Edi", position: "ech", country: "england"},
+			{order: "A Edi-Lvp", position: "lvp", country: "germany"},
+			{order: "F Iri H", position: "iri", country: "france"},
+			{order: "F Nth H", position: "nth", country: "france"},
+			{order: "F Nwg C A Lvp-Edi", position: "nwg", country: "russia"},
+			{order: "F Nao C A Lvp-Edi", position: "nao", country: "russia"},
+		},
+	},
+
+	// DATC 6.G.7. SWAPPING WITH ILLEGAL INTENT
+	// England: F Skagerrak Convoys A Sweden - Norway, F Norway - Sweden
+	// Russia: A Sweden - Norway, F Gulf of Bothnia Convoys A Sweden - Norway
+	// Result: no chain of fleets links the Gulf of Bothnia to Norway, so
+	//         Sweden does not go by convoy and the two units bounce.
+	{
+		description: "DATC 6.G.7 - swapping with illegal intent",
+		orders: []*result{
+			{order: "F Ska C A Swe-Nwy", position: "ska", country: "england"},
+			{order: "F Nwy-Swe", position: "nwy", country: "england"},
+			{order: "A Swe-Nwy", position: "swe", country: "russia"},
+			{order: "F Gob C A Swe-Nwy", position: "gob", country: "russia"},
+		},
+	},
+
 	/*
-		// DATC 6.G.2–6.G.10: Adjacent-province convoy edge cases.
-		// All need convoy model (Phase 5). See DATC.txt for full scenario details.
+		// DATC 6.G.8–6.G.10: Adjacent-province convoy edge cases.
+		// Not yet written out. See DATC.txt for full scenario details.
 	*/
 }
 
@@ -1338,7 +1547,9 @@ type result struct {
 	order    string
 	position string
 	defeated bool
-	unit     *board.Unit
+	// country gives the order for another country than the default.
+	country string
+	unit    *board.Unit
 }
 
 type spec struct {
@@ -1361,6 +1572,10 @@ func TestMainPhaseResolver_ResolveCases(t *testing.T) {
 			orders := order.Set{}
 
 			for _, result := range spec.orders {
+				country := country
+				if result.country != "" {
+					country = result.country
+				}
 				o, err := order.Decode(result.order, country)
 				is.NoErr(err)
 				var terr board.Territory
@@ -1383,13 +1598,14 @@ func TestMainPhaseResolver_ResolveCases(t *testing.T) {
 				}
 
 				u := &board.Unit{Country: country}
-				positionManager.AddUnit(u, board.LookupTerritory(terr.Abbr))
+				positionManager.AddUnit(u, terr)
 				result.unit = u
 			}
 
-			validator := order.NewValidator(board.CreateArmyGraph())
+			validator := order.NewValidator(board.CreateArmyGraph(), board.CreateFleetGraph())
 			orderHandler := game.OrderHandler{
-				Validator: validator,
+				Validator:   validator,
+				ConvoyGraph: board.CreateConvoyGraph(),
 			}
 			orderHandler.ApplyOrders(orders, positionManager)
 			game.ResolveOrders(positionManager)
@@ -1428,7 +1644,7 @@ func logTableRow(t *testing.T, o result) {
 	t.Helper()
 	t.Logf("  | %s%s| %s    | %t%s|",
 		o.order,
-		strings.Repeat(" ", 18-len(o.order)),
+		strings.Repeat(" ", max(0, 18-len(o.order))),
 		o.position,
 		o.defeated,
 		strings.Repeat(" ", 9-len(fmt.Sprintf("%t", o.defeated))))
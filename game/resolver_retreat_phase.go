@@ -0,0 +1,64 @@
+package game
+
+import (
+	"github.com/burrbd/dip/game/order"
+	"github.com/burrbd/dip/game/order/board"
+)
+
+// ApplyRetreats resolves the retreat phase that follows a movement phase
+// resolved on manager.
+//
+// A dislodged unit retreats where it is ordered if it could move there, the
+// territory is empty, its attacker did not come from there and no standoff
+// left it empty. Units retreating to the same territory are all disbanded,
+// as is every unit without a retreat it can make.
+func (h OrderHandler) ApplyRetreats(orders order.Set, manager board.Manager) {
+	blocked := make(map[string]bool)
+	for _, pos := range manager.Positions() {
+		if standing(pos) {
+			blocked[pos.Territory.Abbr] = true
+		}
+	}
+	for _, terr := range manager.Standoffs() {
+		blocked[terr.Abbr] = true
+	}
+
+	dislodged := manager.Dislodgements()
+	destinations := make(map[*board.Unit]board.Territory)
+	retreating := make(map[string]int)
+	for _, d := range dislodged {
+		r, ok := retreatFor(d, orders.Retreats)
+		if !ok || blocked[r.To.Abbr] || r.To.Is(d.By) {
+			continue
+		}
+		move := order.Move{Country: r.Country, UnitType: r.UnitType, From: d.Territory, To: r.To}
+		if err := h.Validator.ValidateMove(*d.Unit, move); err != nil {
+			continue
+		}
+		destinations[d.Unit] = r.To
+		retreating[r.To.Abbr]++
+	}
+	for _, d := range dislodged {
+		if to, ok := destinations[d.Unit]; ok && retreating[to.Abbr] == 1 {
+			manager.Retreat(d.Unit, to)
+		} else {
+			manager.Disband(d.Unit)
+		}
+	}
+}
+
+// retreatFor returns the retreat ordered for the dislodged unit d.
+func retreatFor(d board.Dislodgement, retreats []order.Retreat) (order.Retreat, bool) {
+	for _, r := range retreats {
+		if r.From.Is(d.Territory) {
+			return r, true
+		}
+	}
+	return order.Retreat{}, false
+}
+
+// standing reports whether the unit at pos holds its territory: it has been
+// neither dislodged nor disbanded.
+func standing(pos board.Position) bool {
+	return pos.Cause != board.Defeated && pos.Cause != board.Disbanded
+}
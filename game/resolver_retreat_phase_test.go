@@ -0,0 +1,210 @@
+package game_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/burrbd/dip/game"
+	"github.com/burrbd/dip/game/order"
+	"github.com/burrbd/dip/game/order/board"
+	"github.com/cheekybits/is"
+)
+
+func newOrderHandler() game.OrderHandler {
+	return game.OrderHandler{
+		Validator:   order.NewValidator(board.CreateArmyGraph(), board.CreateFleetGraph()),
+		ConvoyGraph: board.CreateConvoyGraph(),
+	}
+}
+
+// decodeOrders decodes orders written as "Country: order", e.g.
+// "England: F Lon-Nth", into a set.
+func decodeOrders(t *testing.T, orders ...string) order.Set {
+	t.Helper()
+	var set order.Set
+	for _, text := range orders {
+		country, text, _ := strings.Cut(text, ": ")
+		o, err := order.Decode(text, country)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch v := o.(type) {
+		case order.Move:
+			set.AddMove(v)
+		case order.Hold:
+			set.AddHold(v)
+		case order.MoveSupport:
+			set.AddMoveSupport(v)
+		case order.HoldSupport:
+			set.AddHoldSupport(v)
+		case order.MoveConvoy:
+			set.AddMoveConvoy(v)
+		}
+	}
+	return set
+}
+
+// playMovement places a unit for each order, in the territory it is given
+// from, resolves the movement phase and returns the board with its units
+// keyed by the abbr they started in.
+func playMovement(t *testing.T, orders ...string) (board.PositionManager, map[string]*board.Unit) {
+	t.Helper()
+	set := decodeOrders(t, orders...)
+	manager := board.NewPositionManager()
+	units := make(map[string]*board.Unit)
+	place := func(country string, unitType board.UnitType, terr board.Territory) {
+		u := &board.Unit{Country: country, Type: unitType}
+		manager.AddUnit(u, terr)
+		units[terr.Abbr] = u
+	}
+	for _, m := range set.Moves {
+		place(m.Country, m.UnitType, m.From)
+	}
+	for _, h := range set.Holds {
+		place(h.Country, h.UnitType, h.At)
+	}
+	for _, s := range set.MoveSupports {
+		place(s.Country, s.UnitType, s.By)
+	}
+	for _, s := range set.HoldSupports {
+		place(s.Country, s.UnitType, s.By)
+	}
+	for _, c := range set.MoveConvoys {
+		place(c.Country, board.Fleet, c.By)
+	}
+	handler := newOrderHandler()
+	handler.ApplyOrders(set, manager)
+	game.ResolveOrders(manager)
+	return manager, units
+}
+
+func retreat(country string, unitType board.UnitType, from, to string) order.Retreat {
+	return order.Retreat{
+		Country:  country,
+		UnitType: unitType,
+		From:     board.LookupTerritory(from),
+		To:       board.LookupTerritory(to),
+	}
+}
+
+// munichDislodged is a movement phase in which France dislodges the German
+// army in Munich from Burgundy.
+var munichDislodged = []string{
+	"Germany: A Mun H",
+	"France: A Bur-Mun",
+	"France: A Ruh S A Bur-Mun",
+}
+
+func TestApplyRetreats(t *testing.T) {
+	specs := []struct {
+		desc     string
+		movement []string
+		retreats []order.Retreat
+		position string
+		cause    board.PositionEvent
+	}{
+		{
+			desc:     "retreat to an empty territory",
+			movement: munichDislodged,
+			retreats: []order.Retreat{retreat("Germany", board.Army, "mun", "boh")},
+			position: "boh",
+			cause:    board.Retreated,
+		},
+		{
+			desc:     "no retreat order disbands",
+			movement: munichDislodged,
+			position: "mun",
+			cause:    board.Disbanded,
+		},
+		{
+			desc:     "retreat toward the attacker disbands",
+			movement: munichDislodged,
+			retreats: []order.Retreat{retreat("Germany", board.Army, "mun", "bur")},
+			position: "mun",
+			cause:    board.Disbanded,
+		},
+		{
+			desc:     "retreat to an occupied territory disbands",
+			movement: munichDislodged,
+			retreats: []order.Retreat{retreat("Germany", board.Army, "mun", "ruh")},
+			position: "mun",
+			cause:    board.Disbanded,
+		},
+		{
+			desc:     "retreat to a territory that is not adjacent disbands",
+			movement: munichDislodged,
+			retreats: []order.Retreat{retreat("Germany", board.Army, "mun", "pru")},
+			position: "mun",
+			cause:    board.Disbanded,
+		},
+		{
+			desc: "retreat to a standoff disbands",
+			movement: append([]string{
+				"Austria: A Tyr-Boh",
+				"Russia: A Sil-Boh",
+			}, munichDislodged...),
+			retreats: []order.Retreat{retreat("Germany", board.Army, "mun", "boh")},
+			position: "mun",
+			cause:    board.Disbanded,
+		},
+		{
+			desc:     "retreat of another country's unit disbands",
+			movement: munichDislodged,
+			retreats: []order.Retreat{retreat("Austria", board.Army, "mun", "boh")},
+			position: "mun",
+			cause:    board.Disbanded,
+		},
+	}
+	for _, spec := range specs {
+		t.Run(spec.desc, func(t *testing.T) {
+			is := is.New(t)
+			manager, units := playMovement(t, spec.movement...)
+			mun := units["mun"]
+			is.True(manager.Defeated(mun))
+
+			handler := newOrderHandler()
+			handler.ApplyRetreats(order.Set{Retreats: spec.retreats}, manager)
+
+			is.Equal(spec.position, manager.Position(mun).Territory.Abbr)
+			is.Equal(spec.cause, manager.Position(mun).Cause)
+		})
+	}
+}
+
+func TestApplyRetreats_SameTerritoryDisbandsBoth(t *testing.T) {
+	is := is.New(t)
+	manager, units := playMovement(t,
+		"Germany: A Mun H",
+		"France: A Bur-Mun",
+		"France: A Ruh S A Bur-Mun",
+		"Austria: A Vie H",
+		"Russia: A Gal-Vie",
+		"Russia: A Bud S A Gal-Vie",
+	)
+
+	handler := newOrderHandler()
+	handler.ApplyRetreats(order.Set{Retreats: []order.Retreat{
+		retreat("Germany", board.Army, "mun", "boh"),
+		retreat("Austria", board.Army, "vie", "boh"),
+	}}, manager)
+
+	is.Equal(board.Disbanded, manager.Position(units["mun"]).Cause)
+	is.Equal(board.Disbanded, manager.Position(units["vie"]).Cause)
+}
+
+func TestApplyRetreats_FleetAlongCoast(t *testing.T) {
+	is := is.New(t)
+	manager, units := playMovement(t,
+		"England: F Nth H",
+		"Germany: F Hel-Nth",
+		"Germany: F Den S F Hel-Nth",
+	)
+
+	handler := newOrderHandler()
+	handler.ApplyRetreats(order.Set{Retreats: []order.Retreat{
+		retreat("England", board.Fleet, "nth", "edi"),
+	}}, manager)
+
+	is.Equal("edi", manager.Position(units["nth"]).Territory.Abbr)
+	is.Equal(board.Retreated, manager.Position(units["nth"]).Cause)
+}
@@ -0,0 +1,220 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/burrbd/dip/game/order"
+	"github.com/burrbd/dip/game/order/board"
+	"github.com/zond/godip"
+)
+
+// Phase is a game phase loaded from an engine snapshot: the board at the
+// start of the phase, the orders staged for it and who owns each supply
+// center.
+type Phase struct {
+	Year   int
+	Season string
+	// Type is the phase type: Movement, Retreat or Adjustment.
+	Type  string
+	Board board.PositionManager
+	// Units are the units on Board keyed by the godip province they stand
+	// in, e.g. "spa/nc".
+	Units map[string]*board.Unit
+	// Dislodged are the units on Board waiting to retreat, keyed by the
+	// godip province they were dislodged from.
+	Dislodged map[string]*board.Unit
+	// Orders are the staged orders that could be decoded. Moves in a
+	// retreat phase are decoded as retreats.
+	Orders order.Set
+	// SupplyCenters maps the abbr of each owned supply center to its
+	// owner's country.
+	SupplyCenters map[string]string
+	// Unread are the staged orders that could not be decoded, each prefixed
+	// with its country, e.g. "England: F Lon-Xyz".
+	Unread []string
+}
+
+// snapshot is the part of an engine snapshot that sets up a phase.
+type snapshot struct {
+	Year          int                                        `json:"year"`
+	Season        godip.Season                               `json:"season"`
+	PhaseType     godip.PhaseType                            `json:"phase_type"`
+	Units         map[godip.Province]godip.Unit              `json:"units"`
+	SupplyCenters map[godip.Province]godip.Nation            `json:"supply_centers"`
+	Dislodgeds    map[godip.Province]godip.Unit              `json:"dislodgeds"`
+	Dislodgers    map[godip.Province]godip.Province          `json:"dislodgers"`
+	Bounces       map[godip.Province]map[godip.Province]bool `json:"bounces"`
+}
+
+// LoadSnapshot builds the phase described by data, a snapshot written by
+// engine.Dump, with the orders in staged: canonical order text keyed
+// by country, as a session stages it. Orders recorded in the snapshot itself
+// are ignored.
+//
+// In a retreat phase each dislodged unit is on the board as defeated, and
+// the moves that dislodged it and the standoffs that left territories empty
+// are replayed so that ApplyRetreats sees them.
+func LoadSnapshot(data []byte, staged map[string][]string) (Phase, error) {
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return Phase{}, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	phase := Phase{
+		Year:          snap.Year,
+		Season:        string(snap.Season),
+		Type:          string(snap.PhaseType),
+		Board:         board.NewPositionManager(),
+		Units:         make(map[string]*board.Unit),
+		Dislodged:     make(map[string]*board.Unit),
+		SupplyCenters: make(map[string]string),
+	}
+
+	place := func(prov godip.Province, u godip.Unit, units map[string]*board.Unit) (*board.Unit, error) {
+		terr := board.LookupGodip(prov)
+		if terr.ID() < 0 {
+			return nil, fmt.Errorf("invalid snapshot: unknown province %q", prov)
+		}
+		unit := &board.Unit{Country: string(u.Nation), Type: nativeUnitType(u.Type)}
+		phase.Board.AddUnit(unit, terr)
+		units[string(prov)] = unit
+		return unit, nil
+	}
+	for prov, u := range snap.Units {
+		if _, err := place(prov, u, phase.Units); err != nil {
+			return Phase{}, err
+		}
+	}
+	for prov, u := range snap.Dislodgeds {
+		unit, err := place(prov, u, phase.Dislodged)
+		if err != nil {
+			return Phase{}, err
+		}
+		phase.Board.SetDefeated(unit)
+	}
+	phase.replayDislodgers(snap.Dislodgers)
+	phase.replayBounces(snap.Bounces)
+
+	for prov, nation := range snap.SupplyCenters {
+		terr := board.LookupGodip(prov)
+		if terr.ID() < 0 {
+			return Phase{}, fmt.Errorf("invalid snapshot: unknown province %q", prov)
+		}
+		phase.SupplyCenters[terr.Abbr] = string(nation)
+	}
+
+	countries := make([]string, 0, len(staged))
+	for country := range staged {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	for _, country := range countries {
+		for _, text := range staged[country] {
+			if !phase.addOrder(text, country) {
+				phase.Unread = append(phase.Unread, country+": "+text)
+			}
+		}
+	}
+	return phase, nil
+}
+
+// replayDislodgers moves each unit that dislodged another onto the board
+// from where it attacked, so the dislodged unit may not retreat there.
+// dislodgers maps the province each attacker came from to the one it took.
+func (p Phase) replayDislodgers(dislodgers map[godip.Province]godip.Province) {
+	for from, to := range dislodgers {
+		unit, terr, ok := p.standingIn(to)
+		if !ok {
+			continue
+		}
+		p.Board.AddUnit(unit, board.LookupGodip(from))
+		p.Board.Move(unit, terr, 0)
+	}
+}
+
+// replayBounces bounces each unit that was in a standoff back to where it
+// came from. bounces maps each province units bounced from to the
+// provinces they came from.
+func (p Phase) replayBounces(bounces map[godip.Province]map[godip.Province]bool) {
+	for dst, sources := range bounces {
+		for src := range sources {
+			unit, terr, ok := p.standingIn(src)
+			if !ok {
+				continue
+			}
+			p.Board.AddUnit(unit, terr)
+			p.Board.Move(unit, board.LookupGodip(dst), 0)
+			p.Board.Bounce(unit)
+		}
+	}
+}
+
+// standingIn returns the unit that was not dislodged in prov, on any of its
+// coasts, and the territory it stands in.
+func (p Phase) standingIn(prov godip.Province) (*board.Unit, board.Territory, bool) {
+	for unit, pos := range p.Board.Positions() {
+		if pos.Cause != board.Defeated && pos.Territory.Is(board.LookupGodip(prov.Super())) {
+			return unit, pos.Territory, true
+		}
+	}
+	return nil, board.Territory{}, false
+}
+
+// addOrder decodes text for country and adds it to the phase's orders. It
+// reports whether text could be decoded.
+func (p *Phase) addOrder(text, country string) bool {
+	decoded, err := DecodeOrder(text, country)
+	if err != nil {
+		return false
+	}
+	switch v := decoded.(type) {
+	case order.Move:
+		if p.Type == string(godip.Retreat) {
+			p.Orders.AddRetreat(order.Retreat{Country: v.Country, UnitType: v.UnitType, From: v.From, To: v.To})
+		} else {
+			p.Orders.AddMove(v)
+		}
+	case order.Hold:
+		p.Orders.AddHold(v)
+	case order.MoveSupport:
+		p.Orders.AddMoveSupport(v)
+	case order.HoldSupport:
+		p.Orders.AddHoldSupport(v)
+	case order.MoveConvoy:
+		p.Orders.AddMoveConvoy(v)
+	case order.Retreat:
+		p.Orders.AddRetreat(v)
+	case order.Disband:
+		p.Orders.AddDisband(v)
+	case order.Build:
+		p.Orders.AddBuild(v)
+	default:
+		return false
+	}
+	return true
+}
+
+var provinceRe = regexp.MustCompile(`\b[a-z]{3}(/[a-z]{2})?\b`)
+
+// DecodeOrder decodes canonical order text as the engine writes it, e.g.
+// "F Eng-Bel" or "A Lon-Bel via convoy", into an order for country, with
+// board abbrs in place of godip's.
+func DecodeOrder(text, country string) (interface{}, error) {
+	native := provinceRe.ReplaceAllStringFunc(strings.ToLower(text), func(p string) string {
+		if terr := board.LookupGodip(godip.Province(p)); terr.ID() >= 0 {
+			return terr.String()
+		}
+		return p
+	})
+	return order.Decode(native, country)
+}
+
+func nativeUnitType(t godip.UnitType) board.UnitType {
+	if t == godip.Fleet {
+		return board.Fleet
+	}
+	return board.Army
+}
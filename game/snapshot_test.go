@@ -0,0 +1,155 @@
+package game_test
+
+import (
+	"testing"
+
+	"github.com/burrbd/dip/engine"
+	"github.com/burrbd/dip/game"
+	"github.com/burrbd/dip/game/order"
+	"github.com/burrbd/dip/game/order/board"
+	"github.com/cheekybits/is"
+)
+
+// retreatSnapshot returns an engine snapshot of the retreat phase after
+// France dislodges Munich from Burgundy while Austria and Russia stand each
+// other off in Bohemia.
+func retreatSnapshot(t *testing.T) []byte {
+	t.Helper()
+	is := is.New(t)
+	eng, err := engine.Load([]byte(`{
+		"year": 1901, "season": "Spring", "phase_type": "Movement",
+		"units": {
+			"mun": {"Type": "Army", "Nation": "Germany"},
+			"bur": {"Type": "Army", "Nation": "France"},
+			"ruh": {"Type": "Army", "Nation": "France"},
+			"tyr": {"Type": "Army", "Nation": "Austria"},
+			"sil": {"Type": "Army", "Nation": "Russia"}
+		}
+	}`))
+	is.NoErr(err)
+	for nation, text := range map[string]string{
+		"France":  "A Bur-Mun",
+		"Austria": "A Tyr-Boh",
+		"Russia":  "A Sil-Boh",
+	} {
+		is.NoErr(eng.SubmitOrder(nation, text))
+	}
+	is.NoErr(eng.SubmitOrder("France", "A Ruh S A Bur-Mun"))
+	_, err = eng.Resolve()
+	is.NoErr(err)
+	data, err := eng.Dump()
+	is.NoErr(err)
+	return data
+}
+
+func TestLoadSnapshot_Movement(t *testing.T) {
+	is := is.New(t)
+	eng, err := engine.New("classical")
+	is.NoErr(err)
+	data, err := eng.Dump()
+	is.NoErr(err)
+
+	phase, err := game.LoadSnapshot(data, map[string][]string{
+		"England": {"F Lon-Eng", "A Lvp-Yor", "F Edi S F Lon-Nth"},
+		"France":  {"F Bre-Mid", "A Par H", "A Mar-Xyz"},
+	})
+
+	is.NoErr(err)
+	is.Equal(1901, phase.Year)
+	is.Equal("Spring", phase.Season)
+	is.Equal("Movement", phase.Type)
+	is.Equal(22, len(phase.Units))
+	is.Equal(board.Fleet, phase.Units["stp/sc"].Type)
+	is.Equal("Russia", phase.Units["stp/sc"].Country)
+	is.Equal("stp/sc", phase.Board.Position(phase.Units["stp/sc"]).Territory.String())
+	is.Equal(22, len(phase.SupplyCenters))
+	is.Equal("England", phase.SupplyCenters["lvp"])
+	is.Equal(3, len(phase.Orders.Moves))
+	is.Equal("ech", phase.Orders.Moves[0].To.Abbr)
+	is.Equal("mao", phase.Orders.Moves[2].To.Abbr)
+	is.Equal(1, len(phase.Orders.MoveSupports))
+	is.Equal(1, len(phase.Orders.Holds))
+	is.Equal([]string{"France: A Mar-Xyz"}, phase.Unread)
+
+	handler := newOrderHandler()
+	handler.ApplyOrders(phase.Orders, phase.Board)
+	game.ResolveOrders(phase.Board)
+
+	is.Equal("ech", phase.Board.Position(phase.Units["lon"]).Territory.Abbr)
+	is.Equal("yor", phase.Board.Position(phase.Units["lvp"]).Territory.Abbr)
+}
+
+func TestLoadSnapshot_Retreat(t *testing.T) {
+	is := is.New(t)
+
+	phase, err := game.LoadSnapshot(retreatSnapshot(t), map[string][]string{
+		"Germany": {"A Mun-Bur"},
+	})
+
+	is.NoErr(err)
+	is.Equal("Retreat", phase.Type)
+	is.Equal([]order.Retreat{{
+		Country:  "Germany",
+		UnitType: board.Army,
+		From:     board.LookupTerritory("mun"),
+		To:       board.LookupTerritory("bur"),
+	}}, phase.Orders.Retreats)
+	is.Equal(0, len(phase.Orders.Moves))
+
+	dislodged := phase.Board.Dislodgements()
+	is.Equal(1, len(dislodged))
+	is.Equal(phase.Dislodged["mun"], dislodged[0].Unit)
+	is.Equal("Germany", dislodged[0].Unit.Country)
+	is.Equal("bur", dislodged[0].By.Abbr)
+	is.Equal("France", phase.Units["mun"].Country)
+	is.Equal([]board.Territory{board.LookupTerritory("boh")}, phase.Board.Standoffs())
+	is.Equal("mun", phase.Board.Position(phase.Dislodged["mun"]).Territory.Abbr)
+}
+
+func TestLoadSnapshot_RetreatIntoStandoffDisbands(t *testing.T) {
+	is := is.New(t)
+	phase, err := game.LoadSnapshot(retreatSnapshot(t), map[string][]string{
+		"Germany": {"A Mun-Boh"},
+	})
+	is.NoErr(err)
+
+	handler := newOrderHandler()
+	handler.ApplyRetreats(phase.Orders, phase.Board)
+
+	is.Equal(board.Disbanded, phase.Board.Position(phase.Dislodged["mun"]).Cause)
+}
+
+func TestLoadSnapshot_Errors(t *testing.T) {
+	for _, data := range []string{
+		`{`,
+		`{"year": 1901, "units": {"xyz": {"Type": "Army", "Nation": "France"}}}`,
+		`{"year": 1901, "supply_centers": {"xyz": "France"}}`,
+	} {
+		_, err := game.LoadSnapshot([]byte(data), nil)
+		is.New(t).Err(err)
+	}
+}
+
+func TestDecodeOrder(t *testing.T) {
+	specs := []struct {
+		text     string
+		from, to string
+	}{
+		{"F Eng-Bel", "ech", "bel"},
+		{"A Lon-Bel via convoy", "lon", "bel"},
+		{"F Mid-Spa/nc", "mao", "spa/nc"},
+		{"F Nrg-Nat", "nwg", "nao"},
+	}
+	for _, s := range specs {
+		t.Run(s.text, func(t *testing.T) {
+			is := is.New(t)
+			o, err := game.DecodeOrder(s.text, "England")
+			is.NoErr(err)
+			move, ok := o.(order.Move)
+			is.True(ok)
+			is.Equal("England", move.Country)
+			is.Equal(s.from, move.From.String())
+			is.Equal(s.to, move.To.String())
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Package lint looks for likely mistakes in a nation's staged orders. Its
+// warnings are advisory: every order it inspects is already legal, but
+// together they probably do not do what the player meant, e.g. a support
+// for a move the supported unit was not ordered to make.
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/burrbd/dip/engine"
+)
+
+// Kind identifies the mistake a Warning reports.
+type Kind string
+
+const (
+	// SupportUnordered: a support for one of the nation's own units that is
+	// not ordered to do what the support expects.
+	SupportUnordered Kind = "support-unordered"
+	// ConvoyUnmatched: a convoy for one of the nation's own armies that is
+	// not ordered to make the convoyed move.
+	ConvoyUnmatched Kind = "convoy-unmatched"
+	// ConvoyMissing: an army move that needs a convoy none of the nation's
+	// fleets is ordered to provide.
+	ConvoyMissing Kind = "convoy-missing"
+	// SelfAttack: a move into a province held by one of the nation's own
+	// units that is not ordered to leave, or is ordered to swap places with
+	// the moving unit without a convoy and so bounces against it.
+	SelfAttack Kind = "self-attack"
+	// Unordered: a unit with no staged order, which will hold.
+	Unordered Kind = "unordered"
+	// ForeignIntoOwnSC: a support helping a foreign unit into one of the
+	// nation's own supply centers.
+	ForeignIntoOwnSC Kind = "foreign-into-own-sc"
+)
+
+// Warning is one likely mistake. Order is the staged order it concerns, or
+// the unit (e.g. "A Bud") for Unordered.
+type Warning struct {
+	Kind    Kind
+	Order   string
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Order, w.Message)
+}
+
+// order is a parsed canonical movement order.
+type order struct {
+	text      string
+	unit      string // "A" or "F"
+	src       string // province of the ordered unit, without coast
+	kind      string // "H", "-", "S" or "C"
+	dst       string // destination of a move, or of the supported/convoyed move
+	target    string // unit supported or convoyed
+	viaConvoy bool
+}
+
+var (
+	moveRe    = regexp.MustCompile(`(?i)^(A|F)\s+(\S+)\s*-\s*(\S+)(\s+via\s+convoy)?$`)
+	holdRe    = regexp.MustCompile(`(?i)^(A|F)\s+(\S+)\s+H$`)
+	supportRe = regexp.MustCompile(`(?i)^(A|F)\s+(\S+)\s+S\s+(?:A|F)\s+([^-\s]+)(?:\s*-\s*(\S+))?$`)
+	convoyRe  = regexp.MustCompile(`(?i)^(A|F)\s+(\S+)\s+C\s+A\s+(\S+)\s*-\s*(\S+)$`)
+)
+
+// parse reads canonical order text as produced by engine.NormalizeOrder.
+// It returns false for text it does not recognise.
+func parse(text string) (order, bool) {
+	o := order{text: text}
+	switch {
+	case moveRe.MatchString(text):
+		m := moveRe.FindStringSubmatch(text)
+		o.unit, o.src, o.kind, o.dst, o.viaConvoy = m[1], m[2], "-", m[3], m[4] != ""
+	case holdRe.MatchString(text):
+		m := holdRe.FindStringSubmatch(text)
+		o.unit, o.src, o.kind = m[1], m[2], "H"
+	case supportRe.MatchString(text):
+		m := supportRe.FindStringSubmatch(text)
+		o.unit, o.src, o.kind, o.target, o.dst = m[1], m[2], "S", m[3], m[4]
+	case convoyRe.MatchString(text):
+		m := convoyRe.FindStringSubmatch(text)
+		o.unit, o.src, o.kind, o.target, o.dst = m[1], m[2], "C", m[3], m[4]
+	default:
+		return order{}, false
+	}
+	o.unit = strings.ToUpper(o.unit)
+	o.src, o.target = province(o.src), province(o.target)
+	o.dst = strings.ToLower(o.dst)
+	return o, true
+}
+
+// province lower-cases name and drops any coast, e.g. "Stp/sc" → "stp".
+func province(name string) string {
+	return strings.ToLower(strings.SplitN(name, "/", 2)[0])
+}
+
+// Check inspects nation's staged orders against the position in eng and
+// returns the likely mistakes it finds, ordered by order text. Only
+// Movement phases are checked; other phases return nil.
+func Check(eng engine.Engine, nation string, orders []string) []Warning {
+	if !strings.HasSuffix(eng.Phase(), "Movement") {
+		return nil
+	}
+	units := make(map[string]engine.UnitInfo)
+	for prov, u := range eng.Units() {
+		units[province(prov)] = u
+	}
+	own := func(prov string) bool { return units[prov].Nation == nation }
+
+	byUnit := make(map[string]order)
+	var parsed []order
+	for _, text := range orders {
+		if o, ok := parse(text); ok {
+			byUnit[o.src] = o
+			parsed = append(parsed, o)
+		}
+	}
+
+	var warnings []Warning
+	warn := func(k Kind, text, format string, args ...interface{}) {
+		warnings = append(warnings, Warning{Kind: k, Order: text, Message: fmt.Sprintf(format, args...)})
+	}
+	for _, o := range parsed {
+		switch o.kind {
+		case "-":
+			dst := province(o.dst)
+			if u, ok := units[dst]; ok && own(dst) {
+				next, ordered := byUnit[dst]
+				switch {
+				case !ordered || next.kind != "-":
+					warn(SelfAttack, o.text, "moves into %s, held by your own %s, which is not ordered to leave", title(dst), strings.ToLower(u.Type))
+				case province(next.dst) == o.src && !o.viaConvoy && !next.viaConvoy:
+					warn(SelfAttack, o.text, "swaps places with your own %s in %s, so the two bounce", strings.ToLower(u.Type), title(dst))
+				}
+			}
+			if o.unit == "A" && (o.viaConvoy || !eng.Adjacent(units[o.src].Type, o.src, o.dst)) && !convoyed(parsed, o) {
+				warn(ConvoyMissing, o.text, "needs a convoy, but none of your fleets is ordered to convoy it")
+			}
+		case "S":
+			if own(o.target) {
+				if !supportMatches(byUnit[o.target], o) {
+					warn(SupportUnordered, o.text, "supports %s, but that unit is %s", describe(o), orderedAs(byUnit, o.target))
+				}
+			} else if _, ok := units[o.target]; ok && o.dst != "" && eng.SupplyCenterOwners()[province(o.dst)] == nation {
+				warn(ForeignIntoOwnSC, o.text, "helps a %s unit into your own supply center %s", units[o.target].Nation, title(province(o.dst)))
+			}
+		case "C":
+			if own(o.target) {
+				if m, ok := byUnit[o.target]; !ok || m.kind != "-" || province(m.dst) != province(o.dst) {
+					warn(ConvoyUnmatched, o.text, "convoys A %s-%s, but that army is %s", title(o.target), title(province(o.dst)), orderedAs(byUnit, o.target))
+				}
+			}
+		}
+	}
+	for prov, u := range units {
+		if u.Nation != nation {
+			continue
+		}
+		if _, ok := byUnit[prov]; !ok {
+			warn(Unordered, fmt.Sprintf("%s %s", unitLetter(u.Type), title(prov)), "has no order and will hold")
+		}
+	}
+	sort.SliceStable(warnings, func(i, j int) bool { return warnings[i].Order < warnings[j].Order })
+	return warnings
+}
+
+// supportMatches reports whether supported carries out what support expects:
+// the move it supports, or staying put for a hold support.
+func supportMatches(supported, support order) bool {
+	if support.dst == "" {
+		return supported.text == "" || supported.kind != "-"
+	}
+	return supported.kind == "-" && province(supported.dst) == province(support.dst)
+}
+
+// convoyed reports whether any order in orders convoys move.
+func convoyed(orders []order, move order) bool {
+	for _, o := range orders {
+		if o.kind == "C" && o.target == move.src && province(o.dst) == province(move.dst) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe renders what a support is for, e.g. "Mar-Bur" or "Rom to hold".
+func describe(support order) string {
+	if support.dst == "" {
+		return title(support.target) + " to hold"
+	}
+	return title(support.target) + "-" + title(province(support.dst))
+}
+
+// orderedAs describes how the unit at prov is ordered.
+func orderedAs(byUnit map[string]order, prov string) string {
+	if o, ok := byUnit[prov]; ok {
+		return "ordered " + o.text
+	}
+	return "not ordered"
+}
+
+// title capitalises a province abbreviation, e.g. "bud" → "Bud".
+func title(prov string) string {
+	if prov == "" {
+		return prov
+	}
+	return strings.ToUpper(prov[:1]) + prov[1:]
+}
+
+func unitLetter(unitType string) string {
+	if unitType == "Fleet" {
+		return "F"
+	}
+	return "A"
+}
@@ -0,0 +1,104 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/burrbd/dip/engine"
+	"github.com/cheekybits/is"
+)
+
+func newClassical(t *testing.T) engine.Engine {
+	t.Helper()
+	e, err := engine.New("classical")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+// kinds returns the warning kinds keyed by the order they concern.
+func kinds(ws []Warning) map[string]Kind {
+	m := make(map[string]Kind)
+	for _, w := range ws {
+		m[w.Order] = w.Kind
+	}
+	return m
+}
+
+func TestCheck_CleanOrdersHaveNoWarnings(t *testing.T) {
+	is := is.New(t)
+	ws := Check(newClassical(t), "England", []string{"F Lon-Nth", "F Edi-Nrg", "A Lvp-Yor"})
+	is.Equal(len(ws), 0)
+}
+
+func TestCheck_SupportForMoveNotOrdered(t *testing.T) {
+	is := is.New(t)
+	ws := Check(newClassical(t), "England", []string{"F Lon S A Lvp-Yor", "A Lvp-Wal", "F Edi H"})
+	is.Equal(kinds(ws), map[string]Kind{"F Lon S A Lvp-Yor": SupportUnordered})
+	is.Equal(ws[0].Message, "supports Lvp-Yor, but that unit is ordered A Lvp-Wal")
+}
+
+func TestCheck_HoldSupportForUnitThatMoves(t *testing.T) {
+	is := is.New(t)
+	ws := Check(newClassical(t), "England", []string{"F Lon S A Lvp", "A Lvp-Wal", "F Edi H"})
+	is.Equal(kinds(ws), map[string]Kind{"F Lon S A Lvp": SupportUnordered})
+}
+
+func TestCheck_ConvoyWithoutMatchingArmyOrder(t *testing.T) {
+	is := is.New(t)
+	ws := Check(newClassical(t), "England", []string{"F Edi C A Lvp-Nwy", "A Lvp-Wal", "F Lon H"})
+	is.Equal(kinds(ws), map[string]Kind{"F Edi C A Lvp-Nwy": ConvoyUnmatched})
+}
+
+func TestCheck_ArmyMoveNeedingConvoy(t *testing.T) {
+	is := is.New(t)
+	ws := Check(newClassical(t), "England", []string{"A Lvp-Nwy", "F Lon H", "F Edi H"})
+	is.Equal(kinds(ws), map[string]Kind{"A Lvp-Nwy": ConvoyMissing})
+
+	ws = Check(newClassical(t), "England", []string{"A Lvp-Nwy", "F Edi C A Lvp-Nwy", "F Lon H"})
+	is.Equal(len(ws), 0)
+}
+
+func TestCheck_AttackOnOwnUnit(t *testing.T) {
+	is := is.New(t)
+	ws := Check(newClassical(t), "England", []string{"A Lvp-Edi", "F Edi H", "F Lon H"})
+	is.Equal(kinds(ws), map[string]Kind{"A Lvp-Edi": SelfAttack})
+
+	ws = Check(newClassical(t), "England", []string{"A Lvp-Edi", "F Edi-Nth", "F Lon H"})
+	is.Equal(len(ws), 0)
+}
+
+func TestCheck_SwapWithOwnUnit(t *testing.T) {
+	is := is.New(t)
+	ws := Check(newClassical(t), "England", []string{"A Lvp-Edi", "F Edi-Lvp", "F Lon H"})
+	is.Equal(kinds(ws), map[string]Kind{"A Lvp-Edi": SelfAttack, "F Edi-Lvp": SelfAttack})
+	is.Equal(ws[0].Message, "swaps places with your own fleet in Edi, so the two bounce")
+}
+
+func TestCheck_UnorderedUnits(t *testing.T) {
+	is := is.New(t)
+	ws := Check(newClassical(t), "England", []string{"F Lon H"})
+	is.Equal(kinds(ws), map[string]Kind{"A Lvp": Unordered, "F Edi": Unordered})
+	is.Equal(ws[0].String(), "A Lvp: has no order and will hold")
+}
+
+func TestCheck_SupportForeignUnitIntoOwnSC(t *testing.T) {
+	is := is.New(t)
+	orders := []string{"A Par S A Mun-Bur", "A Mar S A Mun-Par", "F Bre H"}
+	ws := Check(newClassical(t), "France", orders)
+	is.Equal(kinds(ws), map[string]Kind{"A Mar S A Mun-Par": ForeignIntoOwnSC})
+}
+
+// phaseEngine reports a fixed phase for an otherwise real engine.
+type phaseEngine struct {
+	engine.Engine
+	phase string
+}
+
+func (e phaseEngine) Phase() string { return e.phase }
+
+func TestCheck_IgnoresNonMovementPhases(t *testing.T) {
+	is := is.New(t)
+	eng := phaseEngine{Engine: newClassical(t), phase: "Fall 1901 Adjustment"}
+	is.Equal(len(Check(eng, "England", nil)), 0)
+}
@@ -132,7 +132,7 @@ func (c *Channel) ParseUpdate(body []byte) (bot.Command, bool) {
 		c.setUserChannel(userID, channelID)
 	}
 
-	tokens := strings.Fields(msg.Text)
+	tokens := commandTokens(msg.Text)
 	name := strings.TrimPrefix(tokens[0], "/")
 	// Strip @botname suffix: "/order@mybotname" → "order"
 	if i := strings.Index(name, "@"); i > 0 {
@@ -154,6 +154,24 @@ func (c *Channel) ParseUpdate(body []byte) (bot.Command, bool) {
 	}, true
 }
 
+// commandTokens splits message text into whitespace-separated tokens. Line
+// breaks are kept as "\n" tokens so that multi-line order blocks reach the
+// bot intact.
+func commandTokens(text string) []string {
+	var tokens []string
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(tokens) > 0 {
+			tokens = append(tokens, "\n")
+		}
+		tokens = append(tokens, fields...)
+	}
+	return tokens
+}
+
 func (c *Channel) setUserChannel(userID, channelID string) {
 	c.userChannelMu.Lock()
 	c.userChannelMap[userID] = channelID
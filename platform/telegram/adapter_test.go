@@ -336,6 +336,17 @@ func TestParseUpdate_CommandWithArgs_ParsesArgs(t *testing.T) {
 	is.Equal(cmd.Args[0], "England")
 }
 
+func TestParseUpdate_MultiLineCommand_KeepsLineBreaks(t *testing.T) {
+	is := is.New(t)
+	srv := mockServer(t, http.StatusOK)
+	ch := newTestChannel(t, srv)
+
+	cmd, ok := ch.ParseUpdate(makeUpdate("private", 42, 42, "/order A Vie-Gal\n\nA Bud-Rum\nF Tri H"))
+	is.Equal(ok, true)
+	is.Equal(cmd.Name, "order")
+	is.Equal(cmd.Args, []string{"A", "Vie-Gal", "\n", "A", "Bud-Rum", "\n", "F", "Tri", "H"})
+}
+
 // ---- New (production constructor) -------------------------------------------
 
 func TestNew_ReturnsNonNilChannel(t *testing.T) {
@@ -3,6 +3,7 @@ package session
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/burrbd/dip/events"
@@ -11,8 +12,9 @@ import (
 // AdvanceTurn adjudicates the current phase and advances the game to the next.
 //
 // It runs: cancel existing timer → resolve staged orders → post PhaseResolved
-// event → notify players → check for solo winner → advance phase → reset staged
-// orders → start new deadline timer.
+// event → notify players → check for solo winner → advance phase → post
+// NMRRecorded for orders given while advancing → reset staged orders → start
+// new deadline timer → post DeadlineSet event.
 func (s *Session) AdvanceTurn() error {
 	s.CancelDeadline()
 
@@ -27,7 +29,7 @@ func (s *Session) AdvanceTurn() error {
 	}
 
 	summary, _ := json.Marshal(result)
-	if err := events.Write(s.ch, s.ChannelID, events.TypePhaseResolved, events.PhaseResolved{
+	if err := events.Record(s.store, s.ChannelID, events.TypePhaseResolved, events.PhaseResolved{
 		Phase:         result.Phase,
 		StateSnapshot: snapshot,
 		ResultSummary: summary,
@@ -35,6 +37,10 @@ func (s *Session) AdvanceTurn() error {
 		return fmt.Errorf("session: write PhaseResolved: %w", err)
 	}
 
+	if err := s.recordNMR(result.NMR); err != nil {
+		return err
+	}
+
 	if s.notifier != nil {
 		msg := fmt.Sprintf("Phase %s resolved. %d orders adjudicated.", result.Phase, len(result.Orders))
 		_ = s.notifier.Notify(s.ChannelID, msg)
@@ -43,28 +49,59 @@ func (s *Session) AdvanceTurn() error {
 	// Check for solo winner before advancing to the next phase.
 	if winner := s.Eng.SoloWinner(); winner != "" {
 		finalState, _ := s.Eng.Dump()
-		return events.Write(s.ch, s.ChannelID, events.TypeGameEnded, events.GameEnded{
+		return events.Record(s.store, s.ChannelID, events.TypeGameEnded, events.GameEnded{
 			Result:     "solo",
 			Winner:     winner,
 			FinalState: finalState,
 		})
 	}
 
-	if err := s.Eng.Advance(); err != nil {
+	nmr, err := s.Eng.Advance()
+	if err != nil {
 		return fmt.Errorf("session: advance: %w", err)
 	}
+	// Orders given in the empty phases Advance skips are recorded too.
+	if err := s.recordNMR(nmr); err != nil {
+		return err
+	}
 
 	s.StagedOrders = make(map[string][]string)
 	s.Submitted = make(map[string]bool)
+	s.warned = nil
 	s.Phase = s.Eng.Phase()
 
 	s.startDeadline()
+	return s.RecordDeadline()
+}
+
+// recordNMR posts an NMRRecorded event for each nation the engine gave
+// orders for, in nation order, so players can see what was done for them.
+func (s *Session) recordNMR(nmr map[string][]string) error {
+	nations := make([]string, 0, len(nmr))
+	for n := range nmr {
+		nations = append(nations, n)
+	}
+	sort.Strings(nations)
+	for _, n := range nations {
+		if err := events.Record(s.store, s.ChannelID, events.TypeNMRRecorded, events.NMRRecorded{
+			Nation:     n,
+			Phase:      s.Phase,
+			AutoOrders: nmr[n],
+		}); err != nil {
+			return fmt.Errorf("session: write NMRRecorded: %w", err)
+		}
+	}
 	return nil
 }
 
-// startDeadline starts the deadline timer using s.DeadlineHours. When it
-// fires, onDeadline is called automatically. Does nothing if DeadlineHours ≤ 0.
+// startDeadline starts the deadline timer for a new phase using
+// s.DeadlineHours. When it fires, onDeadline is called automatically. A pause
+// does not carry over from the phase before, just as the log's projection
+// drops it at each snapshot. Starts no timer if DeadlineHours ≤ 0.
 func (s *Session) startDeadline() {
+	s.mu.Lock()
+	s.paused = false
+	s.mu.Unlock()
 	if s.DeadlineHours <= 0 {
 		return
 	}
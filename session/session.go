@@ -4,6 +4,8 @@
 package session
 
 import (
+	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -20,24 +22,32 @@ type Notifier interface {
 type Session struct {
 	ChannelID     string
 	Phase         string
-	StagedOrders  map[string][]string // nation → staged order texts for the current phase
+	StagedOrders  map[string][]string // nation → staged order texts for the current phase, one per unit
 	Players       map[string]string   // userID → nation
 	Submitted     map[string]bool     // nation → true if orders are finalised
 	GMID          string
 	DeadlineHours int
 	Eng           engine.Engine
+	// Rejected are the event messages Load skipped as unsigned, tampered
+	// with or out of sequence.
+	Rejected []events.Rejected
+	// Skipped are the snapshot events Load passed over, newest first, when
+	// it had to recover from an earlier snapshot.
+	Skipped []events.SkippedSnapshot
 
 	mu         sync.Mutex
-	ch         events.Channel
+	store      events.Store
 	notifier   Notifier
 	timer      *time.Timer
-	deadlineAt time.Time // absolute UTC time when the current phase deadline fires
+	deadlineAt time.Time           // absolute UTC time when the current phase deadline fires
+	paused     bool                // true while the GM has the deadline paused
+	warned     map[string][]string // nation → staged orders last shown lint warnings
 }
 
 // New creates a Session with all required dependencies and starts the deadline
-// timer. It is called by the bot after posting a GameStarted event to wire up
+// timer. It is called by the bot after recording a GameStarted event to wire up
 // the in-process deadline manager for the new game.
-func New(ch events.Channel, channelID, gmID, phase string, players map[string]string, deadlineHours int, eng engine.Engine, notifier Notifier) *Session {
+func New(store events.Store, channelID, gmID, phase string, players map[string]string, deadlineHours int, eng engine.Engine, notifier Notifier) *Session {
 	s := &Session{
 		ChannelID:     channelID,
 		Phase:         phase,
@@ -47,7 +57,7 @@ func New(ch events.Channel, channelID, gmID, phase string, players map[string]st
 		GMID:          gmID,
 		DeadlineHours: deadlineHours,
 		Eng:           eng,
-		ch:            ch,
+		store:         store,
 		notifier:      notifier,
 	}
 	for k, v := range players {
@@ -57,6 +67,72 @@ func New(ch events.Channel, channelID, gmID, phase string, players map[string]st
 	return s
 }
 
+// StageOrder stages orderText for nation, replacing in place any order
+// already staged for the same unit, and returns the order it replaced ("" if
+// none). Orders for no unit, such as "Waive", are always appended.
+func (s *Session) StageOrder(nation, orderText string) string {
+	if prov := engine.OrderProvince(orderText); prov != "" {
+		for i, o := range s.StagedOrders[nation] {
+			if engine.OrderProvince(o) == prov {
+				s.StagedOrders[nation][i] = orderText
+				return o
+			}
+		}
+	}
+	s.StagedOrders[nation] = append(s.StagedOrders[nation], orderText)
+	return ""
+}
+
+// UnstageOrder removes nation's staged order whose text is target, written
+// as loosely as an order may be (e.g. "a vie-bud"), or, failing that, whose
+// unit is in the province target names (e.g. "vienna"), and returns it.
+func (s *Session) UnstageOrder(nation, target string) (string, bool) {
+	orders := s.StagedOrders[nation]
+	i := indexOf(orders, func(o string) bool { return o == target })
+	if i < 0 {
+		if canonical, err := s.Eng.NormalizeOrder(nation, target); err == nil {
+			i = indexOf(orders, func(o string) bool { return o == canonical })
+		}
+	}
+	if i < 0 {
+		if prov, err := s.Eng.NormalizeProvince(target); err == nil {
+			i = indexOf(orders, func(o string) bool { return prov != "" && engine.OrderProvince(o) == prov })
+		}
+	}
+	if i < 0 {
+		return "", false
+	}
+	removed := orders[i]
+	s.StagedOrders[nation] = append(orders[:i:i], orders[i+1:]...)
+	return removed, true
+}
+
+// MarkWarned records that nation was shown lint warnings for the orders it
+// has staged now.
+func (s *Session) MarkWarned(nation string) {
+	if s.warned == nil {
+		s.warned = make(map[string][]string)
+	}
+	s.warned[nation] = append([]string(nil), s.StagedOrders[nation]...)
+}
+
+// Warned reports whether nation was last shown lint warnings for exactly the
+// orders it has staged now, so that a confirmation covers what it was shown.
+func (s *Session) Warned(nation string) bool {
+	warned, ok := s.warned[nation]
+	return ok && slices.Equal(warned, s.StagedOrders[nation])
+}
+
+// indexOf returns the index of the first order matching match, or -1.
+func indexOf(orders []string, match func(string) bool) int {
+	for i, o := range orders {
+		if match(o) {
+			return i
+		}
+	}
+	return -1
+}
+
 // CancelDeadline stops any pending deadline timer without firing it.
 func (s *Session) CancelDeadline() {
 	s.mu.Lock()
@@ -89,10 +165,92 @@ func (s *Session) RestartDeadline() {
 	s.timer = time.AfterFunc(remaining, s.onDeadline)
 }
 
+// Pause stops the deadline timer, as CancelDeadline does, and records
+// GamePaused so that a reloaded session stays paused.
+func (s *Session) Pause() error {
+	s.CancelDeadline()
+	s.mu.Lock()
+	s.paused = true
+	s.mu.Unlock()
+	if err := events.Record(s.store, s.ChannelID, events.TypeGamePaused, events.GamePaused{Phase: s.Phase}); err != nil {
+		return fmt.Errorf("session: write GamePaused: %w", err)
+	}
+	return nil
+}
+
+// Resume restarts the deadline timer, as RestartDeadline does, and records
+// GameResumed and the deadline it runs to.
+func (s *Session) Resume() error {
+	s.RestartDeadline()
+	s.mu.Lock()
+	s.paused = false
+	s.mu.Unlock()
+	if err := events.Record(s.store, s.ChannelID, events.TypeGameResumed, events.GameResumed{Phase: s.Phase}); err != nil {
+		return fmt.Errorf("session: write GameResumed: %w", err)
+	}
+	return s.RecordDeadline()
+}
+
+// Extend moves the deadline d later, as ExtendDeadline does, and records
+// the new deadline.
+func (s *Session) Extend(d time.Duration) error {
+	s.ExtendDeadline(d)
+	return s.RecordDeadline()
+}
+
+// RecordDeadline records a DeadlineSet event for the current deadline, so
+// that a reloaded session resolves at the same time. It records nothing
+// when no deadline is set. The bot calls it after New.
+func (s *Session) RecordDeadline() error {
+	s.mu.Lock()
+	at := s.deadlineAt
+	s.mu.Unlock()
+	if at.IsZero() {
+		return nil
+	}
+	if err := events.Record(s.store, s.ChannelID, events.TypeDeadlineSet, events.DeadlineSet{
+		Phase:      s.Phase,
+		DeadlineAt: at.UTC(),
+	}); err != nil {
+		return fmt.Errorf("session: write DeadlineSet: %w", err)
+	}
+	return nil
+}
+
+// FinaliseSubmission marks nation's orders for the phase as final and
+// records SubmissionFinalised.
+func (s *Session) FinaliseSubmission(nation string) error {
+	s.Submitted[nation] = true
+	if err := events.Record(s.store, s.ChannelID, events.TypeSubmissionFinalised, events.SubmissionFinalised{
+		Nation: nation,
+		Phase:  s.Phase,
+	}); err != nil {
+		return fmt.Errorf("session: write SubmissionFinalised: %w", err)
+	}
+	return nil
+}
+
+// WithdrawSubmission marks nation's orders for the phase as no longer
+// final. It records SubmissionWithdrawn only if they were.
+func (s *Session) WithdrawSubmission(nation string) error {
+	if !s.Submitted[nation] {
+		return nil
+	}
+	s.Submitted[nation] = false
+	if err := events.Record(s.store, s.ChannelID, events.TypeSubmissionWithdrawn, events.SubmissionWithdrawn{
+		Nation: nation,
+		Phase:  s.Phase,
+	}); err != nil {
+		return fmt.Errorf("session: write SubmissionWithdrawn: %w", err)
+	}
+	return nil
+}
+
 // ExtendDeadline adds d to the current deadline and resets the timer. If
 // s.deadlineAt is unset but DeadlineHours > 0, the current time plus
 // DeadlineHours is used as the base before extending. No-op when DeadlineHours
-// is 0 and deadlineAt is unset.
+// is 0 and deadlineAt is unset. While the game is paused the deadline moves
+// but the timer stays stopped.
 func (s *Session) ExtendDeadline(d time.Duration) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -107,6 +265,9 @@ func (s *Session) ExtendDeadline(d time.Duration) {
 		s.deadlineAt = time.Now().Add(time.Duration(s.DeadlineHours) * time.Hour)
 	}
 	s.deadlineAt = s.deadlineAt.Add(d)
+	if s.paused {
+		return
+	}
 	if remaining := time.Until(s.deadlineAt); remaining > 0 {
 		s.timer = time.AfterFunc(remaining, s.onDeadline)
 	}
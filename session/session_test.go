@@ -3,6 +3,7 @@ package session
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 type mockChannel struct {
 	mu      sync.Mutex
 	msgs    []string
+	dms     map[string][]string // userID → DM messages
 	postErr error
 	histErr error
 }
@@ -40,9 +42,26 @@ func (m *mockChannel) History(_ string) ([]string, error) {
 	return m.msgs, nil
 }
 
-func (m *mockChannel) SendDM(_, _ string) error             { return nil }
-func (m *mockChannel) DMHistory(_ string) ([]string, error) { return nil, nil }
-func (m *mockChannel) PostImage(_ string, _ []byte) error   { return nil }
+func (m *mockChannel) SendDM(userID, text string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.dms == nil {
+		m.dms = make(map[string][]string)
+	}
+	m.dms[userID] = append(m.dms[userID], text)
+	return nil
+}
+
+func (m *mockChannel) DMHistory(userID string) ([]string, error) {
+	if m.histErr != nil {
+		return nil, m.histErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.dms[userID], nil
+}
+
+func (m *mockChannel) PostImage(_ string, _ []byte) error { return nil }
 
 func (m *mockChannel) msgCount() int {
 	m.mu.Lock()
@@ -83,22 +102,51 @@ type mockEngine struct {
 	resolveResult engine.ResolutionResult
 	resolveErr    error
 	advanceErr    error
+	advanceNMR    map[string][]string
 	dumpData      []byte
 	dumpErr       error
 	soloWinner    string
 	phaseStr      string
 	submitErr     error
+	submitted     []string // "nation: order" for each SubmitOrder call
+	nmrPolicy     engine.NMRPolicy
+	provinces     map[string]string // name → what NormalizeProvince returns
 }
 
-func (e *mockEngine) SubmitOrder(_, _ string) error             { return e.submitErr }
-func (e *mockEngine) Resolve() (engine.ResolutionResult, error) { return e.resolveResult, e.resolveErr }
-func (e *mockEngine) Advance() error                            { return e.advanceErr }
-func (e *mockEngine) SoloWinner() string                        { return e.soloWinner }
-func (e *mockEngine) Dump() ([]byte, error)                     { return e.dumpData, e.dumpErr }
-func (e *mockEngine) Phase() string                             { return e.phaseStr }
-func (e *mockEngine) Dislodgeds() map[string]string             { return make(map[string]string) }
-func (e *mockEngine) SupplyCenters() map[string]int             { return make(map[string]int) }
-func (e *mockEngine) Units() map[string]engine.UnitInfo         { return make(map[string]engine.UnitInfo) }
+func (e *mockEngine) SubmitOrder(nation, text string) error {
+	if e.submitErr != nil {
+		return e.submitErr
+	}
+	e.submitted = append(e.submitted, nation+": "+text)
+	return nil
+}
+
+func (e *mockEngine) NormalizeOrder(_, text string) (string, error) { return text, nil }
+func (e *mockEngine) NormalizeProvince(name string) (string, error) { return e.province(name), nil }
+func (e *mockEngine) ClearOrders(_ string)                          {}
+func (e *mockEngine) Resolve() (engine.ResolutionResult, error)     { return e.resolveResult, e.resolveErr }
+func (e *mockEngine) Advance() (map[string][]string, error)         { return e.advanceNMR, e.advanceErr }
+func (e *mockEngine) SoloWinner() string                            { return e.soloWinner }
+func (e *mockEngine) Dump() ([]byte, error)                         { return e.dumpData, e.dumpErr }
+func (e *mockEngine) Phase() string                                 { return e.phaseStr }
+func (e *mockEngine) Dislodgeds() map[string]string                 { return make(map[string]string) }
+func (e *mockEngine) SupplyCenters() map[string]int                 { return make(map[string]int) }
+func (e *mockEngine) SupplyCenterOwners() map[string]string         { return make(map[string]string) }
+func (e *mockEngine) Adjacent(_, _, _ string) bool                  { return true }
+func (e *mockEngine) Units() map[string]engine.UnitInfo             { return make(map[string]engine.UnitInfo) }
+func (e *mockEngine) ValidOrders(_ string) []string                 { return nil }
+func (e *mockEngine) Variant() string                               { return "classical" }
+func (e *mockEngine) Clone() (engine.Engine, error)                 { c := *e; return &c, nil }
+func (e *mockEngine) SetNMRPolicy(p engine.NMRPolicy)               { e.nmrPolicy = p }
+func (e *mockEngine) province(name string) string {
+	if prov, ok := e.provinces[name]; ok {
+		return prov
+	}
+	return strings.ToLower(name)
+}
+func (e *mockEngine) Preview(_ []string) (engine.ResolutionResult, engine.Engine, error) {
+	return e.resolveResult, e, e.resolveErr
+}
 
 // ---- helpers ----------------------------------------------------------------
 
@@ -121,7 +169,7 @@ func makeSession(ch events.Channel, eng engine.Engine, notifier Notifier) *Sessi
 		Players:       make(map[string]string),
 		Submitted:     make(map[string]bool),
 		Eng:           eng,
-		ch:            ch,
+		store:         events.NewChannelStore(ch),
 		notifier:      notifier,
 		DeadlineHours: 24,
 	}
@@ -136,7 +184,7 @@ func TestNew_SetsFields(t *testing.T) {
 	notifier := &mockNotifier{}
 	players := map[string]string{"u1": "England", "u2": "France"}
 
-	s := New(ch, "chan1", "gm1", "Spring 1901 Movement", players, 0, eng, notifier)
+	s := New(events.NewChannelStore(ch), "chan1", "gm1", "Spring 1901 Movement", players, 0, eng, notifier)
 
 	is.Equal(s.ChannelID, "chan1")
 	is.Equal(s.GMID, "gm1")
@@ -153,7 +201,7 @@ func TestNew_CopiesPlayers(t *testing.T) {
 	ch := &mockChannel{}
 	players := map[string]string{"u1": "England"}
 
-	s := New(ch, "chan1", "gm1", "Spring 1901 Movement", players, 0, defaultEng(), nil)
+	s := New(events.NewChannelStore(ch), "chan1", "gm1", "Spring 1901 Movement", players, 0, defaultEng(), nil)
 
 	// Mutate source map; session copy must be unaffected.
 	players["u2"] = "France"
@@ -162,7 +210,7 @@ func TestNew_CopiesPlayers(t *testing.T) {
 
 func TestNew_StartsDeadlineWhenPositiveHours(t *testing.T) {
 	ch := &mockChannel{}
-	s := New(ch, "chan1", "gm1", "Spring 1901 Movement", nil, 24, defaultEng(), nil)
+	s := New(events.NewChannelStore(ch), "chan1", "gm1", "Spring 1901 Movement", nil, 24, defaultEng(), nil)
 	s.mu.Lock()
 	timerSet := s.timer != nil
 	s.mu.Unlock()
@@ -188,12 +236,38 @@ func TestLoad_RebuildsGMIDAndDeadlineHours(t *testing.T) {
 	})
 	writeGameStarted(ch)
 
-	s, err := Load(ch, "chan1", nil, makeLoader(defaultEng()))
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
 	is.NoErr(err)
 	is.Equal(s.GMID, "gm1")
 	is.Equal(s.DeadlineHours, 24)
 }
 
+func TestLoad_AppliesNMRPolicy(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "chan1", events.TypeGameCreated, events.GameCreated{
+		Variant: "classical", GMUserID: "gm1", NMRPolicy: "civil-disorder",
+	})
+	writeGameStarted(ch)
+	eng := defaultEng()
+
+	_, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(eng))
+	is.NoErr(err)
+	is.Equal(eng.nmrPolicy, engine.NMRCivilDisorder)
+}
+
+func TestLoad_ReturnsErrorForUnknownNMRPolicy(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "chan1", events.TypeGameCreated, events.GameCreated{
+		Variant: "classical", GMUserID: "gm1", NMRPolicy: "anarchy",
+	})
+	writeGameStarted(ch)
+
+	_, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
+	is.Err(err)
+}
+
 func TestLoad_RebuildsPlayers(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
@@ -201,21 +275,37 @@ func TestLoad_RebuildsPlayers(t *testing.T) {
 	_ = events.Write(ch, "chan1", events.TypePlayerJoined, events.PlayerJoined{UserID: "u2", Nation: "France"})
 	writeGameStarted(ch)
 
-	s, err := Load(ch, "chan1", nil, makeLoader(defaultEng()))
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
 	is.NoErr(err)
 	is.Equal(s.Players["u1"], "England")
 	is.Equal(s.Players["u2"], "France")
 }
 
-func TestLoad_RebuildsPhaseFromPhaseResolved(t *testing.T) {
+func TestLoad_AppliesBootsAndReplacements(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "chan1", events.TypePlayerJoined, events.PlayerJoined{UserID: "u1", Nation: "England"})
+	_ = events.Write(ch, "chan1", events.TypePlayerJoined, events.PlayerJoined{UserID: "u2", Nation: "France"})
+	writeGameStarted(ch)
+	_ = events.Write(ch, "chan1", events.TypePlayerBooted, events.PlayerBooted{Nation: "England"})
+	_ = events.Write(ch, "chan1", events.TypePlayerReplaced, events.PlayerReplaced{Nation: "France", NewUserID: "u3"})
+
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
+	is.NoErr(err)
+	is.Equal(s.Players, map[string]string{"u3": "France"})
+}
+
+func TestLoad_TakesPhaseFromRestoredEngine(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
 	writeGameStarted(ch)
 	_ = events.Write(ch, "chan1", events.TypePhaseResolved, events.PhaseResolved{
-		Phase: "Fall 1901 Movement", StateSnapshot: json.RawMessage(`{}`),
+		Phase: "Movement", StateSnapshot: json.RawMessage(`{}`),
 	})
+	eng := defaultEng()
+	eng.phaseStr = "Fall 1901 Movement"
 
-	s, err := Load(ch, "chan1", nil, makeLoader(defaultEng()))
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(eng))
 	is.NoErr(err)
 	is.Equal(s.Phase, "Fall 1901 Movement")
 }
@@ -231,7 +321,7 @@ func TestLoad_StagedOrdersFromEventsAfterSnapshot(t *testing.T) {
 		Nation: "France", Orders: []string{"A Par-Bur"},
 	})
 
-	s, err := Load(ch, "chan1", nil, makeLoader(defaultEng()))
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
 	is.NoErr(err)
 	is.Equal(len(s.StagedOrders["England"]), 2)
 	is.Equal(s.StagedOrders["England"][0], "A Lon-Nth")
@@ -240,6 +330,22 @@ func TestLoad_StagedOrdersFromEventsAfterSnapshot(t *testing.T) {
 	is.Equal(s.StagedOrders["France"][0], "A Par-Bur")
 }
 
+func TestLoad_LaterOrderForUnitReplacesEarlier(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	writeGameStarted(ch)
+	_ = events.Write(ch, "chan1", events.TypeOrderSubmitted, events.OrderSubmitted{
+		Nation: "Austria", Orders: []string{"A Vie-Bud", "F Tri H"},
+	})
+	_ = events.Write(ch, "chan1", events.TypeOrderSubmitted, events.OrderSubmitted{
+		Nation: "Austria", Orders: []string{"A Vie-Tyr"},
+	})
+
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
+	is.NoErr(err)
+	is.Equal(s.StagedOrders["Austria"], []string{"A Vie-Tyr", "F Tri H"})
+}
+
 func TestLoad_OrdersBeforeSnapshotNotIncluded(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
@@ -253,7 +359,7 @@ func TestLoad_OrdersBeforeSnapshotNotIncluded(t *testing.T) {
 		Nation: "France", Orders: []string{"A Par-Bur"},
 	})
 
-	s, err := Load(ch, "chan1", nil, makeLoader(defaultEng()))
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
 	is.NoErr(err)
 	is.Equal(len(s.StagedOrders["England"]), 0)
 	is.Equal(len(s.StagedOrders["France"]), 1)
@@ -275,7 +381,7 @@ func TestLoad_OrdersResetOnNewSnapshot(t *testing.T) {
 		Nation: "France", Orders: []string{"A Par-Bur"},
 	})
 
-	s, err := Load(ch, "chan1", nil, makeLoader(defaultEng()))
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
 	is.NoErr(err)
 	is.Equal(len(s.StagedOrders["England"]), 0)
 	is.Equal(len(s.StagedOrders["France"]), 1)
@@ -284,7 +390,7 @@ func TestLoad_OrdersResetOnNewSnapshot(t *testing.T) {
 func TestLoad_ReturnsErrorWhenChannelFails(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{histErr: errors.New("channel down")}
-	_, err := Load(ch, "chan1", nil, makeLoader(defaultEng()))
+	_, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
 	is.Err(err)
 }
 
@@ -293,7 +399,7 @@ func TestLoad_ReturnsErrorWhenNoSnapshot(t *testing.T) {
 	ch := &mockChannel{}
 	_ = events.Write(ch, "chan1", events.TypePlayerJoined, events.PlayerJoined{UserID: "u1", Nation: "England"})
 	// No GameStarted or PhaseResolved — Rebuild will fail.
-	_, err := Load(ch, "chan1", nil, makeLoader(defaultEng()))
+	_, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
 	is.Err(err)
 }
 
@@ -304,7 +410,7 @@ func TestLoad_ReturnsErrorWhenEngineFails(t *testing.T) {
 	badLoader := func(_ []byte) (engine.Engine, error) {
 		return nil, errors.New("engine load failed")
 	}
-	_, err := Load(ch, "chan1", nil, badLoader)
+	_, err := Load(events.NewChannelStore(ch), "chan1", nil, badLoader)
 	is.Err(err)
 }
 
@@ -312,12 +418,10 @@ func TestLoad_SkipsMalformedGameCreated(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
 	// Inject a malformed GameCreated payload.
-	bad := events.Envelope{Type: events.TypeGameCreated, Payload: json.RawMessage(`"bad"`)}
-	data, _ := json.Marshal(bad)
-	ch.msgs = append(ch.msgs, string(data))
+	_ = events.Write(ch, "chan1", events.TypeGameCreated, json.RawMessage(`"bad"`))
 	writeGameStarted(ch)
 
-	s, err := Load(ch, "chan1", nil, makeLoader(defaultEng()))
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
 	is.NoErr(err)
 	is.Equal(s.GMID, "") // malformed payload → GMID stays empty
 }
@@ -325,12 +429,10 @@ func TestLoad_SkipsMalformedGameCreated(t *testing.T) {
 func TestLoad_SkipsMalformedPlayerJoined(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
-	bad := events.Envelope{Type: events.TypePlayerJoined, Payload: json.RawMessage(`"bad"`)}
-	data, _ := json.Marshal(bad)
-	ch.msgs = append(ch.msgs, string(data))
+	_ = events.Write(ch, "chan1", events.TypePlayerJoined, json.RawMessage(`"bad"`))
 	writeGameStarted(ch)
 
-	s, err := Load(ch, "chan1", nil, makeLoader(defaultEng()))
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
 	is.NoErr(err)
 	is.Equal(len(s.Players), 0)
 }
@@ -339,28 +441,124 @@ func TestLoad_SkipsMalformedPhaseResolved(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
 	writeGameStarted(ch)
-	bad := events.Envelope{Type: events.TypePhaseResolved, Payload: json.RawMessage(`"bad"`)}
-	data, _ := json.Marshal(bad)
-	ch.msgs = append(ch.msgs, string(data))
+	_ = events.Write(ch, "chan1", events.TypePhaseResolved, json.RawMessage(`"bad"`))
 	// A valid PhaseResolved after the malformed one.
 	_ = events.Write(ch, "chan1", events.TypePhaseResolved, events.PhaseResolved{
-		Phase: "Fall 1901 Movement", StateSnapshot: json.RawMessage(`{}`),
+		Phase: "Movement", StateSnapshot: json.RawMessage(`{"n":2}`),
 	})
+	var loaded string
+	loader := func(snap []byte) (engine.Engine, error) {
+		loaded = string(snap)
+		return defaultEng(), nil
+	}
 
-	s, err := Load(ch, "chan1", nil, makeLoader(defaultEng()))
+	_, err := Load(events.NewChannelStore(ch), "chan1", nil, loader)
 	is.NoErr(err)
-	is.Equal(s.Phase, "Fall 1901 Movement")
+	is.Equal(loaded, `{"n":2}`)
+}
+
+// TestLoad_RecoversFromCorruptLatestSnapshot covers a log whose latest
+// snapshot no longer loads: Load restores the one before it, stages the
+// orders submitted after that, and reports the snapshot it skipped.
+func TestLoad_RecoversFromCorruptLatestSnapshot(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "chan1", events.TypeGameStarted, events.GameStarted{InitialState: json.RawMessage(`{"n":1}`)})
+	_ = events.Write(ch, "chan1", events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "England", Orders: []string{"A Lon-Nth"}})
+	_ = events.Write(ch, "chan1", events.TypePhaseResolved, events.PhaseResolved{Phase: "Movement", StateSnapshot: json.RawMessage(`{"n":2}`)})
+	_ = events.Write(ch, "chan1", events.TypeOrderSubmitted, events.OrderSubmitted{Nation: "France", Orders: []string{"A Par-Bur"}})
+	_ = events.Write(ch, "chan1", events.TypeSubmissionFinalised, events.SubmissionFinalised{Nation: "France"})
+	_ = events.Write(ch, "chan1", events.TypeDeadlineSet, events.DeadlineSet{DeadlineAt: time.Now().Add(time.Hour)})
+	eng := defaultEng()
+	eng.phaseStr = "Spring 1901 Movement"
+	loader := func(snap []byte) (engine.Engine, error) {
+		if string(snap) == `{"n":2}` {
+			return nil, engine.ErrInvalidSnapshot
+		}
+		return eng, nil
+	}
+
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, loader)
+	is.NoErr(err)
+	is.Equal(s.Phase, "Spring 1901 Movement")
+	is.Equal(len(s.Skipped), 1)
+	is.Equal(s.Skipped[0].Seq, 2)
+	is.Equal(s.Skipped[0].Type, events.TypePhaseResolved)
+	is.True(errors.Is(s.Skipped[0].Err, engine.ErrInvalidSnapshot))
+	is.Equal(s.StagedOrders, map[string][]string{"England": {"A Lon-Nth"}})
+	is.Equal(eng.submitted, []string{"England: A Lon-Nth"})
+	is.Equal(len(s.Submitted), 0)
+	s.mu.Lock()
+	timerSet := s.timer != nil
+	s.mu.Unlock()
+	is.True(timerSet)
+	s.CancelDeadline()
+}
+
+// TestLoad_RecoveredSessionFiresDeadline covers a recovered game with a
+// deadline: it resolves when the deadline passes rather than stalling.
+func TestLoad_RecoveredSessionFiresDeadline(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	writeGameStarted(ch)
+	_ = events.Write(ch, "chan1", events.TypePhaseResolved, json.RawMessage(`"bad"`))
+	_ = events.Write(ch, "chan1", events.TypeDeadlineSet, events.DeadlineSet{DeadlineAt: time.Now().Add(10 * time.Millisecond)})
+	before := ch.msgCount()
+
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
+	is.NoErr(err)
+	is.Equal(len(s.Skipped), 1)
+
+	for end := time.Now().Add(2 * time.Second); ch.msgCount() == before; {
+		if time.Now().After(end) {
+			t.Fatal("expected the recovered session's deadline to resolve the phase")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestLoad_RecoveredSessionStartsDeadline covers a recovered game with no
+// deadline recorded: the recovered phase is given one.
+func TestLoad_RecoveredSessionStartsDeadline(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "chan1", events.TypeGameCreated, events.GameCreated{DeadlineHours: 24})
+	writeGameStarted(ch)
+	_ = events.Write(ch, "chan1", events.TypePhaseResolved, json.RawMessage(`"bad"`))
+
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
+	is.NoErr(err)
+	defer s.CancelDeadline()
+
+	s.mu.Lock()
+	deadline, timerSet := s.deadlineAt, s.timer != nil
+	s.mu.Unlock()
+	is.True(timerSet)
+	g, _, err := events.ReadGame(events.NewChannelStore(ch), "chan1")
+	is.NoErr(err)
+	is.True(g.Deadline.Equal(deadline.UTC()))
+}
+
+func TestLoad_RecoversFromMalformedLatestSnapshot(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	writeGameStarted(ch)
+	_ = events.Write(ch, "chan1", events.TypePhaseResolved, json.RawMessage(`"bad"`))
+
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
+	is.NoErr(err)
+	is.Equal(len(s.Skipped), 1)
+	is.Equal(s.Skipped[0].Seq, 1)
+	is.Err(s.Skipped[0].Err)
 }
 
 func TestLoad_SkipsMalformedOrderSubmitted(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
 	writeGameStarted(ch)
-	bad := events.Envelope{Type: events.TypeOrderSubmitted, Payload: json.RawMessage(`"bad"`)}
-	data, _ := json.Marshal(bad)
-	ch.msgs = append(ch.msgs, string(data))
+	_ = events.Write(ch, "chan1", events.TypeOrderSubmitted, json.RawMessage(`"bad"`))
 
-	s, err := Load(ch, "chan1", nil, makeLoader(defaultEng()))
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
 	is.NoErr(err)
 	is.Equal(len(s.StagedOrders), 0)
 }
@@ -376,13 +574,60 @@ func TestAdvanceTurn_PostsPhaseResolved(t *testing.T) {
 	s.CancelDeadline()
 
 	is.NoErr(err)
-	is.Equal(ch.msgCount(), 1)
+	is.Equal(ch.msgCount(), 2) // PhaseResolved, then DeadlineSet for the next phase
 
 	var env events.Envelope
 	is.NoErr(json.Unmarshal([]byte(ch.msgAt(0)), &env))
 	is.Equal(env.Type, events.TypePhaseResolved)
 }
 
+func TestAdvanceTurn_PostsNMRRecorded(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	eng := defaultEng()
+	eng.resolveResult.NMR = map[string][]string{
+		"Germany": {"A Mun H"},
+		"England": {"F Edi H", "F Lon H"},
+	}
+	s := makeSession(ch, eng, nil)
+
+	err := s.AdvanceTurn()
+	s.CancelDeadline()
+	is.NoErr(err)
+	is.Equal(ch.msgCount(), 4)
+
+	var nations []string
+	for i := 1; i < 3; i++ {
+		var env events.Envelope
+		is.NoErr(json.Unmarshal([]byte(ch.msgAt(i)), &env))
+		is.Equal(env.Type, events.TypeNMRRecorded)
+		var nmr events.NMRRecorded
+		is.NoErr(json.Unmarshal(env.Payload, &nmr))
+		is.Equal(nmr.Phase, "Spring 1901 Movement")
+		nations = append(nations, nmr.Nation)
+		if nmr.Nation == "England" {
+			is.Equal(nmr.AutoOrders, []string{"F Edi H", "F Lon H"})
+		}
+	}
+	is.Equal(nations, []string{"England", "Germany"})
+}
+
+func TestAdvanceTurn_PostsNMRRecordedWhileAdvancing(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	eng := defaultEng()
+	eng.advanceNMR = map[string][]string{"Austria": {"A Vie disband"}}
+	s := makeSession(ch, eng, nil)
+
+	err := s.AdvanceTurn()
+	s.CancelDeadline()
+	is.NoErr(err)
+
+	g, _, err := events.ReadGame(events.NewChannelStore(ch), "chan1")
+	is.NoErr(err)
+	is.Equal(g.NMR, map[string][]string{"Austria": {"A Vie disband"}})
+}
+
 func TestAdvanceTurn_PhaseResolvedContainsSnapshot(t *testing.T) {
 	is := is.New(t)
 	ch := &mockChannel{}
@@ -566,6 +811,53 @@ func TestAdvanceTurn_AdvanceError(t *testing.T) {
 
 // ---- CancelDeadline tests ---------------------------------------------------
 
+func TestStageOrder_ReplacesOrderForSameUnit(t *testing.T) {
+	is := is.New(t)
+	s := makeSession(&mockChannel{}, defaultEng(), nil)
+
+	is.Equal(s.StageOrder("Austria", "A Vie-Bud"), "")
+	is.Equal(s.StageOrder("Austria", "F Tri H"), "")
+	is.Equal(s.StageOrder("Austria", "A Vie-Tyr"), "A Vie-Bud")
+	is.Equal(s.StagedOrders["Austria"], []string{"A Vie-Tyr", "F Tri H"})
+}
+
+func TestStageOrder_AppendsOrdersForNoUnit(t *testing.T) {
+	is := is.New(t)
+	s := makeSession(&mockChannel{}, defaultEng(), nil)
+
+	s.StageOrder("Austria", "Waive")
+	s.StageOrder("Austria", "Waive")
+	is.Equal(s.StagedOrders["Austria"], []string{"Waive", "Waive"})
+}
+
+func TestUnstageOrder_MatchesTextOrProvince(t *testing.T) {
+	is := is.New(t)
+	s := makeSession(&mockChannel{}, defaultEng(), nil)
+	s.StagedOrders["Austria"] = []string{"A Vie-Bud", "F Tri H", "A Bud-Ser"}
+
+	removed, ok := s.UnstageOrder("Austria", "F Tri H")
+	is.True(ok)
+	is.Equal(removed, "F Tri H")
+	removed, ok = s.UnstageOrder("Austria", "Vie")
+	is.True(ok)
+	is.Equal(removed, "A Vie-Bud")
+	_, ok = s.UnstageOrder("Austria", "A Bud-Gal")
+	is.Equal(ok, false)
+	is.Equal(s.StagedOrders["Austria"], []string{"A Bud-Ser"})
+}
+
+func TestUnstageOrder_MatchesProvinceName(t *testing.T) {
+	is := is.New(t)
+	eng := &mockEngine{provinces: map[string]string{"trieste": "tri"}}
+	s := makeSession(&mockChannel{}, eng, nil)
+	s.StagedOrders["Austria"] = []string{"A Vie-Bud", "F Tri H"}
+
+	removed, ok := s.UnstageOrder("Austria", "trieste")
+	is.True(ok)
+	is.Equal(removed, "F Tri H")
+	is.Equal(s.StagedOrders["Austria"], []string{"A Vie-Bud"})
+}
+
 func TestCancelDeadline_StopsTimer(t *testing.T) {
 	s := &Session{}
 	s.mu.Lock()
@@ -648,7 +940,7 @@ func TestOnDeadline_CallsAdvanceTurn(t *testing.T) {
 	s.onDeadline()
 	s.CancelDeadline()
 
-	is.Equal(ch.msgCount(), 1) // PhaseResolved was posted
+	is.Equal(ch.msgCount(), 2) // PhaseResolved and DeadlineSet were posted
 }
 
 // ---- RestartDeadline tests --------------------------------------------------
@@ -806,3 +1098,276 @@ func TestExtendDeadline_CancelsExistingTimer(t *testing.T) {
 
 	is.Equal(fired, false)
 }
+
+func TestExtendDeadline_KeepsTimerStoppedWhilePaused(t *testing.T) {
+	s := &Session{DeadlineHours: 24, paused: true}
+	s.mu.Lock()
+	s.deadlineAt = time.Now().Add(time.Hour)
+	s.mu.Unlock()
+
+	s.ExtendDeadline(30 * time.Minute)
+
+	s.mu.Lock()
+	timerNil := s.timer == nil
+	s.mu.Unlock()
+	if !timerNil {
+		t.Error("expected no timer while the game is paused")
+	}
+}
+
+// ---- Timing and submission event tests --------------------------------------
+
+// eventAt decodes the i'th event posted to ch.
+func eventAt(t *testing.T, ch *mockChannel, i int) events.Envelope {
+	t.Helper()
+	var env events.Envelope
+	if err := json.Unmarshal([]byte(ch.msgAt(i)), &env); err != nil {
+		t.Fatal(err)
+	}
+	return env
+}
+
+func TestPause_RecordsGamePaused(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	s := makeSession(ch, defaultEng(), nil)
+	s.startDeadline()
+
+	is.NoErr(s.Pause())
+
+	s.mu.Lock()
+	timerNil := s.timer == nil
+	s.mu.Unlock()
+	is.True(timerNil)
+	is.Equal(ch.msgCount(), 1)
+	is.Equal(eventAt(t, ch, 0).Type, events.TypeGamePaused)
+}
+
+// TestAdvanceTurn_ClearsPause covers a game paused until every nation
+// submits: the next phase runs its deadline, and /extend keeps it running.
+func TestAdvanceTurn_ClearsPause(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	s := makeSession(ch, defaultEng(), nil)
+	s.startDeadline()
+	is.NoErr(s.Pause())
+
+	is.NoErr(s.AdvanceTurn())
+	is.NoErr(s.Extend(30 * time.Minute))
+
+	s.mu.Lock()
+	paused, timerSet := s.paused, s.timer != nil
+	s.mu.Unlock()
+	s.CancelDeadline()
+	is.True(!paused)
+	is.True(timerSet)
+	g, _, err := events.ReadGame(events.NewChannelStore(ch), "chan1")
+	is.NoErr(err)
+	is.True(!g.Paused)
+}
+
+func TestResume_RecordsGameResumedAndDeadline(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	s := makeSession(ch, defaultEng(), nil)
+	deadline := time.Now().Add(time.Hour).UTC()
+	s.deadlineAt = deadline
+	s.paused = true
+
+	is.NoErr(s.Resume())
+	s.CancelDeadline()
+
+	is.True(!s.paused)
+	is.Equal(ch.msgCount(), 2)
+	is.Equal(eventAt(t, ch, 0).Type, events.TypeGameResumed)
+	env := eventAt(t, ch, 1)
+	is.Equal(env.Type, events.TypeDeadlineSet)
+	var ds events.DeadlineSet
+	is.NoErr(json.Unmarshal(env.Payload, &ds))
+	is.True(ds.DeadlineAt.Equal(deadline))
+	is.Equal(ds.Phase, "Spring 1901 Movement")
+}
+
+func TestExtend_RecordsNewDeadline(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	s := makeSession(ch, defaultEng(), nil)
+	deadline := time.Now().Add(time.Hour)
+	s.deadlineAt = deadline
+
+	is.NoErr(s.Extend(30 * time.Minute))
+	s.CancelDeadline()
+
+	is.Equal(ch.msgCount(), 1)
+	var ds events.DeadlineSet
+	is.NoErr(json.Unmarshal(eventAt(t, ch, 0).Payload, &ds))
+	is.True(ds.DeadlineAt.Equal(deadline.Add(30 * time.Minute)))
+}
+
+func TestRecordDeadline_NoDeadlineRecordsNothing(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	s := makeSession(ch, defaultEng(), nil)
+
+	is.NoErr(s.RecordDeadline())
+	is.Equal(ch.msgCount(), 0)
+}
+
+func TestRecordDeadline_PostError(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{postErr: errors.New("down")}
+	s := makeSession(ch, defaultEng(), nil)
+	s.deadlineAt = time.Now().Add(time.Hour)
+
+	is.Err(s.RecordDeadline())
+}
+
+func TestFinaliseAndWithdrawSubmission_RecordEvents(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	s := makeSession(ch, defaultEng(), nil)
+
+	is.NoErr(s.WithdrawSubmission("England")) // not submitted: nothing recorded
+	is.NoErr(s.FinaliseSubmission("England"))
+	is.True(s.Submitted["England"])
+	is.NoErr(s.WithdrawSubmission("England"))
+	is.True(!s.Submitted["England"])
+
+	is.Equal(ch.msgCount(), 2)
+	is.Equal(eventAt(t, ch, 0).Type, events.TypeSubmissionFinalised)
+	env := eventAt(t, ch, 1)
+	is.Equal(env.Type, events.TypeSubmissionWithdrawn)
+	var sw events.SubmissionWithdrawn
+	is.NoErr(json.Unmarshal(env.Payload, &sw))
+	is.Equal(sw, events.SubmissionWithdrawn{Nation: "England", Phase: "Spring 1901 Movement"})
+}
+
+func TestLoad_RestoresDeadlinePauseAndSubmissions(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	deadline := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	writeGameStarted(ch)
+	_ = events.Write(ch, "chan1", events.TypeDeadlineSet, events.DeadlineSet{DeadlineAt: deadline})
+	_ = events.Write(ch, "chan1", events.TypeSubmissionFinalised, events.SubmissionFinalised{Nation: "England"})
+	_ = events.Write(ch, "chan1", events.TypeSubmissionFinalised, events.SubmissionFinalised{Nation: "France"})
+	_ = events.Write(ch, "chan1", events.TypeSubmissionWithdrawn, events.SubmissionWithdrawn{Nation: "France"})
+	_ = events.Write(ch, "chan1", events.TypeGamePaused, events.GamePaused{})
+
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
+	is.NoErr(err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	is.True(s.deadlineAt.Equal(deadline))
+	is.True(s.paused)
+	is.Equal(s.timer, (*time.Timer)(nil))
+	is.Equal(s.Submitted, map[string]bool{"England": true})
+}
+
+// TestLoad_RestagesSubmittedOrders covers a restart after a nation
+// submitted: its orders, recorded in the player's private stream, are
+// staged again so its units are not left without orders.
+func TestLoad_RestagesSubmittedOrders(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "chan1", events.TypePlayerJoined, events.PlayerJoined{UserID: "u1", Nation: "England"})
+	_ = events.Write(ch, "chan1", events.TypePlayerJoined, events.PlayerJoined{UserID: "u2", Nation: "France"})
+	writeGameStarted(ch)
+	// An earlier phase's submission is not staged.
+	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, events.OrderSubmitted{
+		Nation: "England", Phase: "Fall 1900 Movement", Orders: []string{"F Lon H"},
+	})
+	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, events.OrderSubmitted{
+		Nation: "England", Phase: "Spring 1901 Movement", Orders: []string{"F Lon-Nth", "A Lvp-Yor"},
+	})
+	_ = events.Write(ch, "chan1", events.TypeSubmissionFinalised, events.SubmissionFinalised{Nation: "England"})
+	// France submitted, then withdrew to change its orders.
+	_ = events.WriteDM(ch, "u2", events.TypeOrderSubmitted, events.OrderSubmitted{
+		Nation: "France", Phase: "Spring 1901 Movement", Orders: []string{"A Par-Bur"},
+	})
+	_ = events.Write(ch, "chan1", events.TypeSubmissionFinalised, events.SubmissionFinalised{Nation: "France"})
+	_ = events.Write(ch, "chan1", events.TypeSubmissionWithdrawn, events.SubmissionWithdrawn{Nation: "France"})
+	eng := defaultEng()
+	eng.phaseStr = "Spring 1901 Movement"
+
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(eng))
+	is.NoErr(err)
+	is.Equal(s.Submitted, map[string]bool{"England": true})
+	is.Equal(s.StagedOrders, map[string][]string{"England": {"F Lon-Nth", "A Lvp-Yor"}})
+	is.Equal(eng.submitted, []string{"England: F Lon-Nth", "England: A Lvp-Yor"})
+}
+
+// TestLoad_RestagesOnlyLatestMovementSubmission covers a nation that
+// submitted, cleared an order and submitted again: only the orders it
+// submitted last are staged on reload.
+func TestLoad_RestagesOnlyLatestMovementSubmission(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "chan1", events.TypePlayerJoined, events.PlayerJoined{UserID: "u1", Nation: "England"})
+	writeGameStarted(ch)
+	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, events.OrderSubmitted{
+		Nation: "England", Phase: "Spring 1901 Movement", Orders: []string{"F Lon-Nth", "A Lvp-Yor"},
+	})
+	_ = events.Write(ch, "chan1", events.TypeSubmissionFinalised, events.SubmissionFinalised{Nation: "England"})
+	// /clear lvp withdraws the submission.
+	_ = events.Write(ch, "chan1", events.TypeSubmissionWithdrawn, events.SubmissionWithdrawn{Nation: "England"})
+	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, events.OrderSubmitted{
+		Nation: "England", Phase: "Spring 1901 Movement", Orders: []string{"F Lon-Nth"},
+	})
+	_ = events.Write(ch, "chan1", events.TypeSubmissionFinalised, events.SubmissionFinalised{Nation: "England"})
+	eng := defaultEng()
+	eng.phaseStr = "Spring 1901 Movement"
+
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(eng))
+	is.NoErr(err)
+	is.Equal(s.StagedOrders, map[string][]string{"England": {"F Lon-Nth"}})
+	is.Equal(eng.submitted, []string{"England: F Lon-Nth"})
+}
+
+func TestLoad_ReturnsErrorWhenSubmittedOrderDoesNotStage(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	_ = events.Write(ch, "chan1", events.TypePlayerJoined, events.PlayerJoined{UserID: "u1", Nation: "England"})
+	writeGameStarted(ch)
+	_ = events.WriteDM(ch, "u1", events.TypeOrderSubmitted, events.OrderSubmitted{
+		Nation: "England", Orders: []string{"F Lon-Nth"},
+	})
+	_ = events.Write(ch, "chan1", events.TypeSubmissionFinalised, events.SubmissionFinalised{Nation: "England"})
+	eng := defaultEng()
+	eng.submitErr = errors.New("illegal")
+
+	_, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(eng))
+	is.Err(err)
+}
+
+func TestLoad_StartsTimerForRestoredDeadline(t *testing.T) {
+	is := is.New(t)
+	ch := &mockChannel{}
+	writeGameStarted(ch)
+	_ = events.Write(ch, "chan1", events.TypeDeadlineSet, events.DeadlineSet{DeadlineAt: time.Now().Add(time.Hour)})
+	_ = events.Write(ch, "chan1", events.TypeGamePaused, events.GamePaused{})
+	_ = events.Write(ch, "chan1", events.TypeGameResumed, events.GameResumed{})
+
+	s, err := Load(events.NewChannelStore(ch), "chan1", nil, makeLoader(defaultEng()))
+	is.NoErr(err)
+
+	s.mu.Lock()
+	timerSet := s.timer != nil
+	s.mu.Unlock()
+	is.True(timerSet)
+	is.True(!s.paused)
+	s.CancelDeadline()
+}
+
+func TestWarned_CoversOnlyTheOrdersWarnedAbout(t *testing.T) {
+	is := is.New(t)
+	s := makeSession(&mockChannel{}, defaultEng(), nil)
+	s.StagedOrders["England"] = []string{"A Lvp-Edi"}
+	is.True(!s.Warned("England"))
+
+	s.MarkWarned("England")
+	is.True(s.Warned("England"))
+
+	s.StageOrder("England", "A Lvp-Yor")
+	is.True(!s.Warned("England"))
+}
@@ -1,8 +1,8 @@
 package session
 
 import (
-	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/burrbd/dip/engine"
 	"github.com/burrbd/dip/events"
@@ -11,84 +11,144 @@ import (
 // EngineLoader restores an engine.Engine from a JSON snapshot produced by Dump.
 type EngineLoader func(snapshot []byte) (engine.Engine, error)
 
-// Load rebuilds a Session for channelID from the channel's event log.
+// Load rebuilds a Session for channelID from the game's event log in store.
 // loader is called to restore the engine from the most recent snapshot;
-// pass engine.Load for production use.
-func Load(ch events.Channel, channelID string, notifier Notifier, loader EngineLoader) (*Session, error) {
-	envs, err := events.Scan(ch, channelID)
+// pass engine.Load for production use. The orders of nations whose
+// submission for the phase is final are read from their players' private
+// streams and staged again. When the last snapshot is malformed or does not
+// load, Load falls back to the newest one that does and reports the
+// snapshots it passed over in Skipped.
+func Load(store events.Store, channelID string, notifier Notifier, loader EngineLoader) (*Session, error) {
+	g, rejected, err := events.ReadGame(store, channelID)
 	if err != nil {
 		return nil, fmt.Errorf("session: scan: %w", err)
 	}
+	userIDs := make([]string, 0, len(g.Players))
+	for userID := range g.Players {
+		userIDs = append(userIDs, userID)
+	}
+	if err := g.ReadDM(store, userIDs...); err != nil {
+		return nil, fmt.Errorf("session: read submissions: %w", err)
+	}
 
 	s := &Session{
-		ChannelID:    channelID,
-		StagedOrders: make(map[string][]string),
-		Players:      make(map[string]string),
-		Submitted:    make(map[string]bool),
-		ch:           ch,
-		notifier:     notifier,
+		ChannelID:     channelID,
+		StagedOrders:  make(map[string][]string),
+		Players:       make(map[string]string),
+		Submitted:     make(map[string]bool),
+		GMID:          g.GMID,
+		DeadlineHours: g.DeadlineHours,
+		Rejected:      rejected,
+		store:         store,
+		notifier:      notifier,
 	}
-
-	// snapshotIdx tracks the position of the last snapshot event so that only
-	// OrderSubmitted events after it are included in StagedOrders.
-	snapshotIdx := -1
-
-	for i, env := range envs {
-		switch env.Type {
-		case events.TypeGameCreated:
-			var gc events.GameCreated
-			if err := json.Unmarshal(env.Payload, &gc); err != nil {
-				continue
-			}
-			s.GMID = gc.GMUserID
-			s.DeadlineHours = gc.DeadlineHours
-
-		case events.TypePlayerJoined:
-			var pj events.PlayerJoined
-			if err := json.Unmarshal(env.Payload, &pj); err != nil {
-				continue
-			}
-			s.Players[pj.UserID] = pj.Nation
-
-		case events.TypeGameStarted:
-			snapshotIdx = i
-			s.StagedOrders = make(map[string][]string)
-			s.Submitted = make(map[string]bool)
-
-		case events.TypePhaseResolved:
-			var pr events.PhaseResolved
-			if err := json.Unmarshal(env.Payload, &pr); err != nil {
-				continue
-			}
-			s.Phase = pr.Phase
-			snapshotIdx = i
-			s.StagedOrders = make(map[string][]string)
-			s.Submitted = make(map[string]bool)
-
-		case events.TypeOrderSubmitted:
-			if snapshotIdx >= 0 && i > snapshotIdx {
-				var os events.OrderSubmitted
-				if err := json.Unmarshal(env.Payload, &os); err != nil {
-					continue
-				}
-				s.StagedOrders[os.Nation] = append(s.StagedOrders[os.Nation], os.Orders...)
-			}
-		}
+	for userID, nation := range g.Players {
+		s.Players[userID] = nation
 	}
 
 	// Rebuild the engine from the last snapshot, replaying any orders after it.
 	var eng engine.Engine
-	if _, err := events.Rebuild(ch, channelID, func(snap []byte) (events.EngineState, error) {
+	load := func(snap []byte) (events.EngineState, error) {
 		e, loadErr := loader(snap)
 		if loadErr != nil {
 			return nil, loadErr
 		}
 		eng = e
 		return e, nil
-	}); err != nil {
-		return nil, fmt.Errorf("session: rebuild engine: %w", err)
+	}
+	var restoreErr error
+	if n := len(g.Snapshots); n > 0 && g.Snapshots[n-1].Err != nil {
+		restoreErr = g.Snapshots[n-1].Err
+	} else {
+		_, restoreErr = g.Restore(load)
+	}
+	if restoreErr != nil {
+		return recoverSession(s, g, load, restoreErr)
 	}
 
-	s.Eng = eng
+	for nation, done := range g.Submitted {
+		s.Submitted[nation] = done
+	}
+	for _, os := range g.Pending {
+		for _, o := range os.Orders {
+			s.StageOrder(os.Nation, o)
+		}
+	}
+	// PhaseResolved names the phase resolved; the engine knows the one now
+	// being played, which is what submissions are recorded against.
+	s.Phase = eng.Phase()
+	for _, os := range g.SubmittedOrders(s.Phase) {
+		for _, o := range os.Orders {
+			if err := eng.SubmitOrder(os.Nation, o); err != nil {
+				return nil, fmt.Errorf("session: restage order %q for %s: %w", o, os.Nation, err)
+			}
+			s.StageOrder(os.Nation, o)
+		}
+	}
+
+	if err := s.setEngine(eng, g.NMRPolicy); err != nil {
+		return nil, err
+	}
+	s.resumeDeadline(g.Deadline, g.Paused)
 	return s, nil
 }
+
+// recoverSession finishes Load when the last snapshot cannot be restored:
+// it restores the engine from the newest snapshot that loads, with
+// events.Recover, and records the snapshots passed over in s.Skipped. The
+// submissions recorded since belong to a phase that can no longer be
+// reached, so only the orders submitted in the channel after the snapshot
+// used are staged again. The deadline last set still stands, so the game
+// does not stall; if none was set, the recovered phase is given a new one.
+func recoverSession(s *Session, g *events.Game, load events.Loader, restoreErr error) (*Session, error) {
+	state, skipped, err := events.Recover(s.store, s.ChannelID, load)
+	if err != nil {
+		return nil, fmt.Errorf("session: rebuild engine: %w (recovery: %w)", restoreErr, err)
+	}
+	s.Skipped = skipped
+	if used := len(g.Snapshots) - 1 - len(skipped); used >= 0 {
+		for _, os := range g.Snapshots[used].Pending {
+			for _, o := range os.Orders {
+				s.StageOrder(os.Nation, o)
+			}
+		}
+	}
+	eng := state.(engine.Engine) // load returns the loader's engine.Engine
+	s.Phase = eng.Phase()
+	if err := s.setEngine(eng, g.NMRPolicy); err != nil {
+		return nil, err
+	}
+	if g.Deadline.IsZero() && !g.Paused {
+		s.startDeadline()
+		if err := s.RecordDeadline(); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	s.resumeDeadline(g.Deadline, g.Paused)
+	return s, nil
+}
+
+// setEngine applies the game's NMR policy to eng and makes it s's engine.
+func (s *Session) setEngine(eng engine.Engine, nmrPolicy string) error {
+	policy, err := engine.ParseNMRPolicy(nmrPolicy)
+	if err != nil {
+		return fmt.Errorf("session: %w", err)
+	}
+	eng.SetNMRPolicy(policy)
+	s.Eng = eng
+	return nil
+}
+
+// resumeDeadline restores a deadline read from the log. The timer is started
+// unless the game is paused; a deadline already passed fires at once.
+func (s *Session) resumeDeadline(at time.Time, paused bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deadlineAt = at
+	s.paused = paused
+	if paused || at.IsZero() {
+		return
+	}
+	s.timer = time.AfterFunc(time.Until(at), s.onDeadline)
+}